@@ -0,0 +1,195 @@
+package trongrid
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// AddressPrefix is the first byte of every TRON address.
+const AddressPrefix = 0x41
+
+// AddressLength is the length of a TRON address in bytes, prefix included.
+const AddressLength = 21
+
+// Address is a TRON account or contract address: the 0x41 prefix followed by 20 bytes.
+type Address [AddressLength]byte
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+// ParseAddress parses a base58check address ("T...") or a hex address ("41..." or "0x...").
+func ParseAddress(s string) (Address, error) {
+	var a Address
+
+	switch {
+	case strings.HasPrefix(s, "T"):
+		b, err := decodeBase58Check(s)
+		if err != nil {
+			return a, err
+		}
+		if len(b) != AddressLength || b[0] != AddressPrefix {
+			return a, fmt.Errorf("%w: %s", ErrInvalidAddress, s)
+		}
+		copy(a[:], b)
+
+		return a, nil
+	case strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X"):
+		b, err := hex.DecodeString(s[2:])
+		if err != nil || len(b) != AddressLength-1 {
+			return a, fmt.Errorf("%w: %s", ErrInvalidAddress, s)
+		}
+		a[0] = AddressPrefix
+		copy(a[1:], b)
+
+		return a, nil
+	default:
+		b, err := hex.DecodeString(s)
+		if err != nil || len(b) != AddressLength || b[0] != AddressPrefix {
+			return a, fmt.Errorf("%w: %s", ErrInvalidAddress, s)
+		}
+		copy(a[:], b)
+
+		return a, nil
+	}
+}
+
+// MustParseAddress is like ParseAddress but panics on invalid input. It is meant for constants.
+func MustParseAddress(s string) Address {
+	a, err := ParseAddress(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return a
+}
+
+// AddressFromBytes builds an address from 20 bytes (e.g. an EVM address or an ABI word tail)
+// or 21 bytes starting with the 0x41 prefix.
+func AddressFromBytes(b []byte) (Address, error) {
+	var a Address
+
+	switch {
+	case len(b) == AddressLength-1:
+		a[0] = AddressPrefix
+		copy(a[1:], b)
+	case len(b) == AddressLength && b[0] == AddressPrefix:
+		copy(a[:], b)
+	default:
+		return a, fmt.Errorf("%w: %x", ErrInvalidAddress, b)
+	}
+
+	return a, nil
+}
+
+// String returns the base58check form, e.g. "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8".
+func (a Address) String() string {
+	return encodeBase58Check(a[:])
+}
+
+// Hex returns the hex form with the 41 prefix, as used by the wallet API without "visible".
+func (a Address) Hex() string {
+	return hex.EncodeToString(a[:])
+}
+
+// Bytes returns the 21 address bytes.
+func (a Address) Bytes() []byte {
+	return append([]byte(nil), a[:]...)
+}
+
+// IsZero reports whether a is the zero value.
+func (a Address) IsZero() bool {
+	return a == Address{}
+}
+
+// MarshalText encodes the address in base58check form.
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.String()), nil
+}
+
+// UnmarshalText accepts any form supported by ParseAddress.
+func (a *Address) UnmarshalText(text []byte) error {
+	v, err := ParseAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*a = v
+
+	return nil
+}
+
+func encodeBase58Check(payload []byte) string {
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+
+	return encodeBase58(append(append([]byte(nil), payload...), second[:4]...))
+}
+
+func decodeBase58Check(s string) ([]byte, error) {
+	b, err := decodeBase58(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) < 5 {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidAddress, s)
+	}
+
+	payload, checksum := b[:len(b)-4], b[len(b)-4:]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(checksum, second[:4]) {
+		return nil, fmt.Errorf("%w: bad checksum: %s", ErrInvalidAddress, s)
+	}
+
+	return payload, nil
+}
+
+func encodeBase58(b []byte) string {
+	x := new(big.Int).SetBytes(b)
+	radix := big.NewInt(58)
+	mod := new(big.Int)
+
+	var out []byte
+	for x.Sign() > 0 {
+		x.DivMod(x, radix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+
+	for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 {
+		out[i], out[j] = out[j], out[i]
+	}
+
+	return string(out)
+}
+
+func decodeBase58(s string) ([]byte, error) {
+	x := new(big.Int)
+	radix := big.NewInt(58)
+
+	for _, c := range s {
+		i := strings.IndexRune(base58Alphabet, c)
+		if i < 0 {
+			return nil, fmt.Errorf("%w: invalid base58 character %q", ErrInvalidAddress, c)
+		}
+		x.Mul(x, radix)
+		x.Add(x, big.NewInt(int64(i)))
+	}
+
+	b := x.Bytes()
+	for _, c := range s {
+		if c != rune(base58Alphabet[0]) {
+			break
+		}
+		b = append([]byte{0}, b...)
+	}
+
+	return b, nil
+}
@@ -0,0 +1,131 @@
+package trongrid
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// TRXDecimals is the number of decimals of TRX: 1 TRX = 1,000,000 sun.
+const TRXDecimals = 6
+
+// Amount is an exact token amount in base units together with the token decimals.
+// The zero value is zero with no decimals.
+type Amount struct {
+	// Value is the amount in base units, e.g. sun for TRX
+	Value *big.Int
+	// Decimals is the number of decimals of the token
+	Decimals int32
+}
+
+// NewAmount returns an amount of value base units.
+func NewAmount(value *big.Int, decimals int32) Amount {
+	return Amount{Value: new(big.Int).Set(value), Decimals: decimals}
+}
+
+// SunAmount returns a TRX amount of sun.
+func SunAmount(sun int64) Amount {
+	return Amount{Value: big.NewInt(sun), Decimals: TRXDecimals}
+}
+
+// AmountFromBaseUnits parses an integer amount in base units, as found in TRC20 transfers.
+func AmountFromBaseUnits(value string, decimals int32) (Amount, error) {
+	v, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return Amount{}, fmt.Errorf("%w: amount %q", ErrInvalidRequest, value)
+	}
+
+	return Amount{Value: v, Decimals: decimals}, nil
+}
+
+// ParseAmount parses a decimal amount in whole tokens, e.g. "12.5", without rounding.
+// It fails when s has more fractional digits than decimals.
+func ParseAmount(s string, decimals int32) (Amount, error) {
+	whole, frac, _ := strings.Cut(strings.TrimSpace(s), ".")
+	if int32(len(frac)) > decimals {
+		return Amount{}, fmt.Errorf("%w: %q has more than %d decimals", ErrInvalidRequest, s, decimals)
+	}
+
+	digits := whole + frac + strings.Repeat("0", int(decimals)-len(frac))
+	if whole == "" || whole == "-" || strings.ContainsAny(digits[1:], "+-") {
+		return Amount{}, fmt.Errorf("%w: amount %q", ErrInvalidRequest, s)
+	}
+
+	return AmountFromBaseUnits(digits, decimals)
+}
+
+// String formats the amount in whole tokens without trailing zeros, e.g. "12.5".
+func (a Amount) String() string {
+	if a.Value == nil {
+		return "0"
+	}
+
+	digits := new(big.Int).Abs(a.Value).String()
+	sign := ""
+	if a.Value.Sign() < 0 {
+		sign = "-"
+	}
+
+	d := int(a.Decimals)
+	if d <= 0 {
+		return sign + digits
+	}
+	if len(digits) <= d {
+		digits = strings.Repeat("0", d-len(digits)+1) + digits
+	}
+
+	whole, frac := digits[:len(digits)-d], strings.TrimRight(digits[len(digits)-d:], "0")
+	if frac == "" {
+		return sign + whole
+	}
+
+	return sign + whole + "." + frac
+}
+
+// Float64 returns the amount in whole tokens, possibly rounded.
+func (a Amount) Float64() float64 {
+	if a.Value == nil {
+		return 0
+	}
+
+	f, _ := new(big.Float).Quo(new(big.Float).SetInt(a.Value), new(big.Float).SetInt(pow10(a.Decimals))).Float64()
+
+	return f
+}
+
+// BaseUnits returns the amount in base units as a decimal string.
+func (a Amount) BaseUnits() string {
+	if a.Value == nil {
+		return "0"
+	}
+
+	return a.Value.String()
+}
+
+// IsZero reports whether the amount is zero.
+func (a Amount) IsZero() bool {
+	return a.Value == nil || a.Value.Sign() == 0
+}
+
+// Cmp compares two amounts of the same token.
+func (a Amount) Cmp(b Amount) int {
+	return a.value().Cmp(b.value())
+}
+
+// MarshalJSON encodes the amount as a string in whole tokens to keep it exact.
+func (a Amount) MarshalJSON() ([]byte, error) {
+	return json.Marshal(a.String())
+}
+
+func (a Amount) value() *big.Int {
+	if a.Value == nil {
+		return new(big.Int)
+	}
+
+	return a.Value
+}
+
+func pow10(n int32) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
@@ -0,0 +1,51 @@
+package trongrid_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+func TestAmount_String(t *testing.T) {
+	tests := []struct {
+		value    int64
+		decimals int32
+		want     string
+	}{
+		{1_500_000, 6, "1.5"},
+		{1, 6, "0.000001"},
+		{-25_000_000, 6, "-25"},
+		{42, 0, "42"},
+		{0, 18, "0"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, trongrid.NewAmount(big.NewInt(tt.value), tt.decimals).String())
+	}
+	assert.Equal(t, "0", trongrid.Amount{}.String())
+	assert.Equal(t, 1.5, trongrid.SunAmount(1_500_000).Float64())
+}
+
+func TestParseAmount(t *testing.T) {
+	a, err := trongrid.ParseAmount("12.5", 6)
+	require.NoError(t, err)
+	assert.Equal(t, "12500000", a.BaseUnits())
+
+	a, err = trongrid.ParseAmount("1000000000000.000000000000000001", 18)
+	require.NoError(t, err)
+	assert.Equal(t, "1000000000000000000000000000001", a.BaseUnits())
+
+	b, err := json.Marshal(a)
+	require.NoError(t, err)
+	assert.Equal(t, `"1000000000000.000000000000000001"`, string(b))
+
+	for _, s := range []string{"1.0000001", "", ".5", "1.-5", "abc"} {
+		_, err = trongrid.ParseAmount(s, 6)
+		require.ErrorIs(t, err, trongrid.ErrInvalidRequest, s)
+	}
+}
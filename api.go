@@ -2,18 +2,138 @@ package trongrid
 
 import (
 	"context"
+	"net/http"
+	"time"
+
 	"github.com/go-resty/resty/v2"
 	"github.com/gorilla/schema"
 	"github.com/rs/zerolog"
-	"golang.org/x/time/rate"
-	"time"
 )
 
-type API interface {
-	// ListTransactions
+// AccountReader reads account state.
+type AccountReader interface {
+	GetAccount(ctx context.Context, req *GetAccountRequest) (resp *Account, err error)
+	GetAccountResource(ctx context.Context, address string) (resp *AccountResourceMessage, err error)
+}
+
+// TransactionReader reads transactions, receipts and account history.
+type TransactionReader interface {
+	// ListTransactionsTrc20
 	// Docs: https://developers.tron.network/reference/get-trc20-transaction-info-by-account-address
 	ListTransactionsTrc20(ctx context.Context, req *ListTransactionsRequest) (resp *TRC20Response, err error)
 	ListTransactions(ctx context.Context, req *ListTransactionsRequest) (resp *ListTransactionsResponse, err error)
+	GetTransactionByID(ctx context.Context, req *GetTransactionRequest) (resp *Transaction, err error)
+	GetTransactionInfoByID(ctx context.Context, req *GetTransactionRequest) (resp *TransactionInfo, err error)
+	ListInternalTransactions(
+		ctx context.Context, req *ListTransactionsRequest,
+	) (resp *ListInternalTransactionsResponse, err error)
+}
+
+// BlockReader reads blocks.
+type BlockReader interface {
+	GetBlockByNum(ctx context.Context, req *GetBlockRequest) (resp *Block, err error)
+	GetNowBlock(ctx context.Context, onlyConfirmed bool) (resp *Block, err error)
+}
+
+// EventReader reads contract events.
+type EventReader interface {
+	ListTransactionEvents(ctx context.Context, req *ListTransactionEventsRequest) (resp *ListEventsResponse, err error)
+	ListContractEvents(ctx context.Context, req *ListContractEventsRequest) (resp *ListEventsResponse, err error)
+}
+
+// ContractCaller reads smart contracts and builds calls to them.
+type ContractCaller interface {
+	GetContract(ctx context.Context, address string) (resp *SmartContract, err error)
+	TriggerSmartContract(
+		ctx context.Context, req *TriggerSmartContractRequest,
+	) (resp *TriggerSmartContractResponse, err error)
+	TriggerConstantContract(
+		ctx context.Context, req *TriggerSmartContractRequest,
+	) (resp *TriggerSmartContractResponse, err error)
+	EstimateEnergy(ctx context.Context, req *TriggerSmartContractRequest) (energy int64, err error)
+}
+
+// ChainReader reads network wide state.
+type ChainReader interface {
+	GetChainParameters(ctx context.Context) (resp *ChainParameters, err error)
+	GetEnergyPrices(ctx context.Context) (PriceHistory, error)
+	GetBandwidthPrices(ctx context.Context) (PriceHistory, error)
+}
+
+// AssetReader reads TRC10 assets.
+type AssetReader interface {
+	ListAssets(ctx context.Context, req *ListAssetsRequest) (resp *ListAssetsResponse, err error)
+	GetAssetByID(ctx context.Context, req *GetAssetRequest) (resp *AssetIssue, err error)
+	GetAssetsByName(ctx context.Context, req *GetAssetsByNameRequest) (resp *ListAssetsResponse, err error)
+	GetAssetIssueByID(ctx context.Context, req *GetAssetRequest) (resp *AssetIssue, err error)
+	GetAssetIssueByAccount(ctx context.Context, address string) (resp []*AssetIssue, err error)
+	GetAssetIssueListByName(ctx context.Context, name string) (resp []*AssetIssue, err error)
+}
+
+// TokenReader reads TRC20 balances, with decimals applied.
+type TokenReader interface {
+	ListTokenHolders(ctx context.Context, req *ListTokenHoldersRequest) (resp *ListTokenHoldersResponse, err error)
+	ListTRC20Balances(ctx context.Context, req *ListTRC20BalancesRequest) (resp *ListTRC20BalancesResponse, err error)
+}
+
+// Broadcaster builds transfers and broadcasts signed transactions.
+type Broadcaster interface {
+	CreateTransaction(ctx context.Context, req *CreateTransactionRequest) (resp *Transaction, err error)
+	TransferAsset(ctx context.Context, req *TransferAssetRequest) (resp *Transaction, err error)
+	BroadcastTransaction(ctx context.Context, tx *Transaction) (resp *BroadcastResponse, err error)
+}
+
+// Staker builds Stake 2.0 transactions and reads staking and delegation state.
+type Staker interface {
+	FreezeBalanceV2(ctx context.Context, req *FreezeBalanceV2Request) (resp *Transaction, err error)
+	UnfreezeBalanceV2(ctx context.Context, req *UnfreezeBalanceV2Request) (resp *Transaction, err error)
+	WithdrawExpireUnfreeze(ctx context.Context, req *WithdrawExpireUnfreezeRequest) (resp *Transaction, err error)
+	CancelAllUnfreezeV2(ctx context.Context, req *CancelAllUnfreezeV2Request) (resp *Transaction, err error)
+	DelegateResource(ctx context.Context, req *DelegateResourceRequest) (resp *Transaction, err error)
+	UndelegateResource(ctx context.Context, req *UndelegateResourceRequest) (resp *Transaction, err error)
+	GetDelegatedResourceV2(ctx context.Context, from, to string) (resp []*DelegatedResource, err error)
+	GetDelegatedResourceAccountIndexV2(
+		ctx context.Context, address string,
+	) (resp *DelegatedResourceAccountIndex, err error)
+	GetCanWithdrawUnfreezeAmount(ctx context.Context, address string, at time.Time) (int64, error)
+	GetAvailableUnfreezeCount(ctx context.Context, address string) (int64, error)
+	GetCanDelegatedMaxSize(ctx context.Context, address, resource string) (int64, error)
+}
+
+// Voter reads witnesses and rewards and builds votes and reward claims.
+type Voter interface {
+	ListWitnesses(ctx context.Context) (resp []*Witness, err error)
+	GetBrokerage(ctx context.Context, address string) (int64, error)
+	GetReward(ctx context.Context, address string) (int64, error)
+	GetNextMaintenanceTime(ctx context.Context) (time.Time, error)
+	VoteWitnessAccount(ctx context.Context, req *VoteWitnessRequest) (resp *Transaction, err error)
+	WithdrawBalance(ctx context.Context, req *WithdrawBalanceRequest) (resp *Transaction, err error)
+}
+
+// PermissionManager updates account permissions and checks the signatures of multisig transactions.
+type PermissionManager interface {
+	AccountPermissionUpdate(ctx context.Context, req *AccountPermissionUpdateRequest) (resp *Transaction, err error)
+	GetSignWeight(ctx context.Context, tx *Transaction) (resp *SignWeight, err error)
+	GetApprovedList(ctx context.Context, tx *Transaction) ([]string, error)
+}
+
+// API is the full TronGrid client. Depend on the narrower interfaces where possible,
+// so tests only need to fake what the code uses.
+type API interface {
+	AccountReader
+	TransactionReader
+	BlockReader
+	EventReader
+	ContractCaller
+	ChainReader
+	AssetReader
+	TokenReader
+	Broadcaster
+	Staker
+	Voter
+	PermissionManager
+	// Network returns the network the client is connected to
+	Network() *Network
 }
 
 type api struct {
@@ -21,9 +141,30 @@ type api struct {
 	decoder *schema.Decoder
 	logger  *zerolog.Logger
 	cl      *resty.Client
+	network *Network
+	limiter RateLimiter
+	retry   RetryPolicy
+	timeout time.Duration
 	token   string
 	uri     string
 	debug   bool
+
+	httpClient    *http.Client
+	transport     http.RoundTripper
+	userAgent     string
+	beforeRequest []BeforeRequestHook
+	afterResponse []AfterResponseHook
+
+	instrumentation Instrumentation
+	cache           Cache
+	coalesce        bool
+	flights         *flightGroup
+
+	tokenOptions []TokenRegistryOption
+	tokens       *TokenRegistry
+
+	// err is a misconfiguration returned by every call
+	err error
 }
 
 func NewAPI(opts ...Option) API {
@@ -32,43 +173,81 @@ func NewAPI(opts ...Option) API {
 		decoder: NewDecoder(),
 		logger:  nil,
 		cl:      nil,
+		network: nil,
+		limiter: defaultRateLimiter(),
+		retry:   DefaultRetryPolicy(),
+		timeout: timeout,
 		token:   "",
 		uri:     "",
 		debug:   false,
+
+		httpClient:    nil,
+		transport:     nil,
+		userAgent:     "",
+		beforeRequest: nil,
+		afterResponse: nil,
+
+		instrumentation: NoopInstrumentation(),
+		cache:           nil,
+		coalesce:        true,
+		flights:         &flightGroup{},
 	}
 	for _, opt := range opts {
 		opt(x)
 	}
 
 	if len(x.uri) == 0 {
-		x.uri = URI
+		if x.network != nil {
+			x.uri = x.network.URI
+		} else {
+			x.uri = URI
+		}
+	}
+	if x.instrumentation == nil {
+		x.instrumentation = NoopInstrumentation()
+	}
+	if x.network == nil {
+		x.network = networkByURI(x.uri)
+	}
+	x.tokens = NewTokenRegistry(x, x.network, x.tokenOptions...)
+
+	// Retries and rate limiting are handled per call in execute,
+	// so the limiter can block instead of failing and broadcasts are never retried.
+	var cl *resty.Client
+	if x.httpClient != nil {
+		// the client may be shared, e.g. http.DefaultClient: configure a copy
+		httpClient := *x.httpClient
+		cl = resty.NewWithClient(&httpClient)
+	} else {
+		cl = resty.New()
+	}
+	if x.transport != nil {
+		cl.SetTransport(x.transport)
 	}
 
-	cl := resty.New().
-		SetBaseURL(x.uri).
+	cl.SetBaseURL(x.uri).
 		SetDebug(x.debug).
-		SetRateLimiter(rate.NewLimiter(rate.Every(time.Second), 1)).
 		SetRedirectPolicy(resty.NoRedirectPolicy()).
-		SetTimeout(timeout)
+		SetTimeout(x.timeout)
 	if x.logger != nil {
 		cl.SetLogger(NewLogger(x.logger))
+	} else {
+		nop := zerolog.Nop()
+		x.logger = &nop
 	}
 
 	if len(x.token) != 0 {
 		cl.SetHeader("TRON-PRO-API-KEY", x.token)
 	}
-
-	// Set default retry settings
-	cl.SetRetryCount(3). // Default to 3 retries
-				SetRetryWaitTime(1 * time.Second).   // Wait 1 second between retries
-				SetRetryMaxWaitTime(5 * time.Second) // Maximum wait time of 5 seconds
-
-	// Configure retry conditions
-	cl.AddRetryCondition(func(response *resty.Response, err error) bool {
-		return err != nil || response.StatusCode() >= 500
-	})
+	if len(x.userAgent) != 0 {
+		cl.SetHeader("User-Agent", x.userAgent)
+	}
 
 	x.cl = cl
 
 	return x
 }
+
+func (api *api) Network() *Network {
+	return api.network
+}
@@ -0,0 +1,41 @@
+package trongrid
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type GetAccountRequest struct {
+	Address       string `url:"-"`
+	OnlyConfirmed bool   `url:"only_confirmed,omitempty"`
+}
+
+// GetAccount returns the balances of an account.
+// Docs: https://developers.tron.network/reference/get-account-info-by-address
+func (api *api) GetAccount(ctx context.Context, req *GetAccountRequest) (resp *Account, err error) {
+	params := url.Values{}
+	if err = api.encoder.Encode(req, params); err != nil {
+		api.logger.Error().Err(err).Send()
+
+		return nil, err
+	}
+
+	var v AccountResponse
+	if err = api.do(ctx, &call{
+		endpoint:   "GetAccount",
+		method:     http.MethodGet,
+		path:       "/v1/accounts/{address}",
+		pathParams: map[string]string{"address": req.Address},
+		query:      params,
+		idempotent: true,
+	}, &v); err != nil {
+		return nil, err
+	}
+
+	if len(v.Data) == 0 {
+		return nil, ErrEmpty
+	}
+
+	return v.Data[0], nil
+}
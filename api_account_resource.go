@@ -0,0 +1,49 @@
+package trongrid
+
+import (
+	"context"
+	"net/http"
+)
+
+// AccountResourceMessage is the bandwidth, energy and TRON Power of an account, with usage
+// already recovered up to the time of the call. Limits and usage are in bandwidth points,
+// energy and votes; the totals are network wide.
+type AccountResourceMessage struct {
+	FreeNetUsed  int64 `json:"freeNetUsed"`
+	FreeNetLimit int64 `json:"freeNetLimit"`
+	// NetUsed and NetLimit are the bandwidth obtained by staking, including delegated to the account
+	NetUsed           int64          `json:"NetUsed"`
+	NetLimit          int64          `json:"NetLimit"`
+	TotalNetLimit     int64          `json:"TotalNetLimit"`
+	TotalNetWeight    int64          `json:"TotalNetWeight"`
+	TronPowerUsed     int64          `json:"tronPowerUsed"`
+	TronPowerLimit    int64          `json:"tronPowerLimit"`
+	EnergyUsed        int64          `json:"EnergyUsed"`
+	EnergyLimit       int64          `json:"EnergyLimit"`
+	TotalEnergyLimit  int64          `json:"TotalEnergyLimit"`
+	TotalEnergyWeight int64          `json:"TotalEnergyWeight"`
+	AssetNetUsed      []AccountAsset `json:"assetNetUsed,omitempty"`
+	AssetNetLimit     []AccountAsset `json:"assetNetLimit,omitempty"`
+}
+
+// GetAccountResource returns the bandwidth, energy and TRON Power of an account.
+// Docs: https://developers.tron.network/reference/getaccountresource
+func (api *api) GetAccountResource(ctx context.Context, address string) (resp *AccountResourceMessage, err error) {
+	resp = new(AccountResourceMessage)
+	if err = api.do(ctx, &call{
+		endpoint:   "GetAccountResource",
+		method:     http.MethodPost,
+		path:       "/wallet/getaccountresource",
+		body:       &walletValueRequest{Address: address, Visible: true},
+		idempotent: true,
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	// every existing account has the network totals; an empty message means no account
+	if resp.TotalNetLimit == 0 {
+		return nil, ErrEmpty
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,217 @@
+package trongrid
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+type ListAssetsRequest struct {
+	// OrderBy is one of "total_supply,asc", "total_supply,desc", "start_time,asc", "start_time,desc",
+	// "end_time,asc", "end_time,desc", "id,asc" or "id,desc"
+	OrderBy       string `url:"order_by,omitempty"`
+	Limit         int32  `url:"limit,omitempty"`
+	Fingerprint   string `url:"fingerprint,omitempty"`
+	OnlyConfirmed bool   `url:"only_confirmed,omitempty"`
+}
+
+type GetAssetRequest struct {
+	// ID is the asset ID, e.g. "1002000"
+	ID            string `url:"-"`
+	OnlyConfirmed bool   `url:"only_confirmed,omitempty"`
+}
+
+type GetAssetsByNameRequest struct {
+	Name          string `url:"-"`
+	OrderBy       string `url:"order_by,omitempty"`
+	Limit         int32  `url:"limit,omitempty"`
+	Fingerprint   string `url:"fingerprint,omitempty"`
+	OnlyConfirmed bool   `url:"only_confirmed,omitempty"`
+}
+
+type TransferAssetRequest struct {
+	OwnerAddress string `json:"owner_address"`
+	ToAddress    string `json:"to_address"`
+	// AssetName is the asset ID, hex encoded unless Visible is set
+	AssetName string `json:"asset_name"`
+	// Amount is in the smallest unit of the asset
+	Amount       int64 `json:"amount"`
+	PermissionID int32 `json:"Permission_id,omitempty"`
+	Visible      bool  `json:"visible"`
+}
+
+type walletValueRequest struct {
+	Value   string `json:"value,omitempty"`
+	Address string `json:"address,omitempty"`
+	Visible bool   `json:"visible"`
+}
+
+type assetIssueList struct {
+	AssetIssue []*AssetIssue `json:"assetIssue"`
+}
+
+// ListAssets returns the TRC10 assets.
+// Docs: https://developers.tron.network/reference/get-assets
+func (api *api) ListAssets(ctx context.Context, req *ListAssetsRequest) (resp *ListAssetsResponse, err error) {
+	params := url.Values{}
+	if err = api.encoder.Encode(req, params); err != nil {
+		api.logger.Error().Err(err).Send()
+
+		return nil, err
+	}
+
+	resp = new(ListAssetsResponse)
+	if err = api.do(ctx, &call{
+		endpoint:   "ListAssets",
+		method:     http.MethodGet,
+		path:       "/v1/assets",
+		query:      params,
+		idempotent: true,
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetAssetByID returns a TRC10 asset.
+// Docs: https://developers.tron.network/reference/get-assets-by-identifier
+func (api *api) GetAssetByID(ctx context.Context, req *GetAssetRequest) (resp *AssetIssue, err error) {
+	params := url.Values{}
+	if err = api.encoder.Encode(req, params); err != nil {
+		api.logger.Error().Err(err).Send()
+
+		return nil, err
+	}
+
+	var v ListAssetsResponse
+	if err = api.do(ctx, &call{
+		endpoint:   "GetAssetByID",
+		method:     http.MethodGet,
+		path:       "/v1/assets/{id}",
+		pathParams: map[string]string{"id": req.ID},
+		query:      params,
+		idempotent: true,
+	}, &v); err != nil {
+		return nil, err
+	}
+
+	if len(v.Data) == 0 {
+		return nil, ErrEmpty
+	}
+
+	return v.Data[0], nil
+}
+
+// GetAssetsByName returns the TRC10 assets with the given name. Names are not unique.
+// Docs: https://developers.tron.network/reference/get-assets-by-name
+func (api *api) GetAssetsByName(
+	ctx context.Context,
+	req *GetAssetsByNameRequest,
+) (resp *ListAssetsResponse, err error) {
+	params := url.Values{}
+	if err = api.encoder.Encode(req, params); err != nil {
+		api.logger.Error().Err(err).Send()
+
+		return nil, err
+	}
+
+	resp = new(ListAssetsResponse)
+	if err = api.do(ctx, &call{
+		endpoint:   "GetAssetsByName",
+		method:     http.MethodGet,
+		path:       "/v1/assets/{name}/list",
+		pathParams: map[string]string{"name": req.Name},
+		query:      params,
+		idempotent: true,
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetAssetIssueByID returns a TRC10 asset from the wallet API.
+// Docs: https://developers.tron.network/reference/getassetissuebyid
+func (api *api) GetAssetIssueByID(ctx context.Context, req *GetAssetRequest) (resp *AssetIssue, err error) {
+	resp = new(AssetIssue)
+	if err = api.do(ctx, &call{
+		endpoint:   "GetAssetIssueByID",
+		method:     http.MethodPost,
+		path:       walletPath(req.OnlyConfirmed, "/getassetissuebyid"),
+		body:       &walletValueRequest{Value: req.ID, Visible: true},
+		idempotent: true,
+		cache: func() time.Duration {
+			if req.OnlyConfirmed && resp.ID != "" {
+				return cacheTTLSlow
+			}
+
+			return 0
+		},
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.ID == "" {
+		return nil, ErrEmpty
+	}
+
+	return resp, nil
+}
+
+// GetAssetIssueByAccount returns the TRC10 assets issued by an account.
+// Docs: https://developers.tron.network/reference/getassetissuebyaccount
+func (api *api) GetAssetIssueByAccount(ctx context.Context, address string) (resp []*AssetIssue, err error) {
+	var v assetIssueList
+	if err = api.do(ctx, &call{
+		endpoint:   "GetAssetIssueByAccount",
+		method:     http.MethodPost,
+		path:       "/wallet/getassetissuebyaccount",
+		body:       &walletValueRequest{Address: address, Visible: true},
+		idempotent: true,
+	}, &v); err != nil {
+		return nil, err
+	}
+
+	return v.AssetIssue, nil
+}
+
+// GetAssetIssueListByName returns the TRC10 assets with the given name from the wallet API.
+// Docs: https://developers.tron.network/reference/getassetissuelistbyname
+func (api *api) GetAssetIssueListByName(ctx context.Context, name string) (resp []*AssetIssue, err error) {
+	var v assetIssueList
+	if err = api.do(ctx, &call{
+		endpoint:   "GetAssetIssueListByName",
+		method:     http.MethodPost,
+		path:       "/wallet/getassetissuelistbyname",
+		body:       &walletValueRequest{Value: name, Visible: true},
+		idempotent: true,
+	}, &v); err != nil {
+		return nil, err
+	}
+
+	return v.AssetIssue, nil
+}
+
+// TransferAsset builds an unsigned TRC10 transfer.
+// Docs: https://developers.tron.network/reference/transferasset
+func (api *api) TransferAsset(ctx context.Context, req *TransferAssetRequest) (resp *Transaction, err error) {
+	resp = new(Transaction)
+	if err = api.do(ctx, &call{
+		endpoint:   "TransferAsset",
+		method:     http.MethodPost,
+		path:       "/wallet/transferasset",
+		body:       req,
+		idempotent: true,
+		unique:     true,
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.TxID == "" {
+		return nil, ErrEmpty
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,66 @@
+package trongrid_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+func TestApi_Assets(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	btt := &trongrid.AssetIssue{
+		ID:           "1002000",
+		OwnerAddress: "TF5Bn4cJCT6GVeUgyCN4rBhDg42KBrpAjg",
+		Name:         "BitTorrent",
+		Abbr:         "BTT",
+		TotalSupply:  990_000_000_000_000_000,
+		TrxNum:       1,
+		Num:          1,
+		Precision:    6,
+	}
+	srv.AddAsset(btt)
+	srv.AddAsset(&trongrid.AssetIssue{ID: "1000001", OwnerAddress: "TPqG9VfqXycvNTaoBunqLWxUi69gnnQ6Fq", Name: "SEED"})
+
+	api := srv.Client()
+	ctx := context.Background()
+
+	list, err := api.ListAssets(ctx, &trongrid.ListAssetsRequest{Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, "1000001", list.Data[0].ID)
+	assert.NotEmpty(t, list.Meta.Fingerprint)
+
+	asset, err := api.GetAssetByID(ctx, &trongrid.GetAssetRequest{ID: "1002000"})
+	require.NoError(t, err)
+	assert.Equal(t, btt, asset)
+
+	_, err = api.GetAssetByID(ctx, &trongrid.GetAssetRequest{ID: "1999999"})
+	require.ErrorIs(t, err, trongrid.ErrEmpty)
+
+	byName, err := api.GetAssetsByName(ctx, &trongrid.GetAssetsByNameRequest{Name: "BitTorrent"})
+	require.NoError(t, err)
+	require.Len(t, byName.Data, 1)
+
+	asset, err = api.GetAssetIssueByID(ctx, &trongrid.GetAssetRequest{ID: "1002000"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, asset.Precision)
+	srv.AssertRequested(t, "POST", "/wallet/getassetissuebyid")
+
+	issued, err := api.GetAssetIssueByAccount(ctx, "TF5Bn4cJCT6GVeUgyCN4rBhDg42KBrpAjg")
+	require.NoError(t, err)
+	require.Len(t, issued, 1)
+
+	named, err := api.GetAssetIssueListByName(ctx, "SEED")
+	require.NoError(t, err)
+	require.Len(t, named, 1)
+	assert.Equal(t, "1000001", named[0].ID)
+}
@@ -0,0 +1,61 @@
+package trongrid
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type GetBlockRequest struct {
+	Num int64 `json:"num"`
+	// OnlyConfirmed queries the solidity node, so only solidified blocks are returned
+	OnlyConfirmed bool `json:"-"`
+}
+
+// GetBlockByNum returns a block with its transactions.
+// Docs: https://developers.tron.network/reference/wallet-getblockbynum
+func (api *api) GetBlockByNum(ctx context.Context, req *GetBlockRequest) (resp *Block, err error) {
+	resp = new(Block)
+	if err = api.do(ctx, &call{
+		endpoint:   "GetBlockByNum",
+		method:     http.MethodPost,
+		path:       walletPath(req.OnlyConfirmed, "/getblockbynum"),
+		body:       req,
+		idempotent: true,
+		cache: func() time.Duration {
+			if req.OnlyConfirmed && resp.BlockID != "" {
+				return cacheTTLImmutable
+			}
+
+			return 0
+		},
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.BlockID == "" {
+		return nil, ErrEmpty
+	}
+
+	return resp, nil
+}
+
+// GetNowBlock returns the latest block, or the latest solidified block when onlyConfirmed is set.
+// Docs: https://developers.tron.network/reference/wallet-getnowblock
+func (api *api) GetNowBlock(ctx context.Context, onlyConfirmed bool) (resp *Block, err error) {
+	resp = new(Block)
+	if err = api.do(ctx, &call{
+		endpoint:   "GetNowBlock",
+		method:     http.MethodPost,
+		path:       walletPath(onlyConfirmed, "/getnowblock"),
+		idempotent: true,
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.BlockID == "" {
+		return nil, ErrEmpty
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,278 @@
+package trongrid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Well-known chain parameter keys.
+const (
+	// ParamMaintenanceTimeInterval is the time between maintenance periods, in milliseconds
+	ParamMaintenanceTimeInterval = "getMaintenanceTimeInterval"
+	// ParamAccountUpgradeCost is the sun burned to apply as a witness
+	ParamAccountUpgradeCost = "getAccountUpgradeCost"
+	// ParamCreateAccountFee is the sun burned instead of bandwidth by a transaction activating an account
+	ParamCreateAccountFee = "getCreateAccountFee"
+	// ParamTransactionFee is the sun burned per byte of bandwidth
+	ParamTransactionFee = "getTransactionFee"
+	// ParamAssetIssueFee is the sun burned to issue a TRC10 asset
+	ParamAssetIssueFee = "getAssetIssueFee"
+	// ParamWitnessPayPerBlock is the sun rewarded for producing a block
+	ParamWitnessPayPerBlock = "getWitnessPayPerBlock"
+	// ParamWitness127PayPerBlock is the sun shared by the top 127 witnesses per block
+	ParamWitness127PayPerBlock = "getWitness127PayPerBlock"
+	// ParamCreateNewAccountFeeInSystemContract is the sun charged for activating an account
+	ParamCreateNewAccountFeeInSystemContract = "getCreateNewAccountFeeInSystemContract"
+	// ParamCreateNewAccountBandwidthRate is the bandwidth consumed by activating an account
+	ParamCreateNewAccountBandwidthRate = "getCreateNewAccountBandwidthRate"
+	// ParamEnergyFee is the sun burned per unit of energy
+	ParamEnergyFee = "getEnergyFee"
+	// ParamMaxFeeLimit is the highest fee_limit of a smart contract call, in sun
+	ParamMaxFeeLimit = "getMaxFeeLimit"
+	// ParamTotalEnergyCurrentLimit is the energy shared by all stakers per day
+	ParamTotalEnergyCurrentLimit = "getTotalEnergyCurrentLimit"
+	// ParamFreeNetLimit is the free bandwidth of an account per day
+	ParamFreeNetLimit = "getFreeNetLimit"
+	// ParamTotalNetLimit is the bandwidth shared by all stakers per day
+	ParamTotalNetLimit = "getTotalNetLimit"
+	// ParamMemoFee is the sun burned by a transaction with a memo
+	ParamMemoFee = "getMemoFee"
+	// ParamUnfreezeDelayDays is the days unstaked TRX waits before it can be withdrawn
+	ParamUnfreezeDelayDays = "getUnfreezeDelayDays"
+	// ParamUpdateAccountPermissionFee is the sun burned by AccountPermissionUpdate
+	ParamUpdateAccountPermissionFee = "getUpdateAccountPermissionFee"
+	// ParamMultiSignFee is the sun burned by a transaction with more than one signature
+	ParamMultiSignFee = "getMultiSignFee"
+)
+
+// ChainParameter is a network parameter set by committee proposals.
+type ChainParameter struct {
+	Key   string `json:"key"`
+	Value int64  `json:"value"`
+}
+
+// ChainParameters is the response of GetChainParameters. The well-known parameters are decoded
+// into typed fields, prices and fees in sun; the others are read with Get.
+type ChainParameters struct {
+	ChainParameter []ChainParameter `json:"chainParameter"`
+
+	MaintenanceTimeInterval             time.Duration `json:"-"`
+	AccountUpgradeCost                  int64         `json:"-"`
+	CreateAccountFee                    int64         `json:"-"`
+	TransactionFee                      int64         `json:"-"`
+	AssetIssueFee                       int64         `json:"-"`
+	WitnessPayPerBlock                  int64         `json:"-"`
+	Witness127PayPerBlock               int64         `json:"-"`
+	CreateNewAccountFeeInSystemContract int64         `json:"-"`
+	CreateNewAccountBandwidthRate       int64         `json:"-"`
+	EnergyFee                           int64         `json:"-"`
+	MaxFeeLimit                         int64         `json:"-"`
+	TotalEnergyCurrentLimit             int64         `json:"-"`
+	FreeNetLimit                        int64         `json:"-"`
+	TotalNetLimit                       int64         `json:"-"`
+	MemoFee                             int64         `json:"-"`
+	UnfreezeDelay                       time.Duration `json:"-"`
+	UpdateAccountPermissionFee          int64         `json:"-"`
+	MultiSignFee                        int64         `json:"-"`
+}
+
+// NewChainParameters returns the parameters params with their typed fields set.
+func NewChainParameters(params ...ChainParameter) *ChainParameters {
+	p := &ChainParameters{ChainParameter: params}
+	p.decode()
+
+	return p
+}
+
+func (p *ChainParameters) UnmarshalJSON(b []byte) error {
+	var v struct {
+		ChainParameter []ChainParameter `json:"chainParameter"`
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	*p = ChainParameters{ChainParameter: v.ChainParameter}
+	p.decode()
+
+	return nil
+}
+
+// decode sets the typed fields from the parameter list.
+func (p *ChainParameters) decode() {
+	fields := map[string]*int64{
+		ParamAccountUpgradeCost:                  &p.AccountUpgradeCost,
+		ParamCreateAccountFee:                    &p.CreateAccountFee,
+		ParamTransactionFee:                      &p.TransactionFee,
+		ParamAssetIssueFee:                       &p.AssetIssueFee,
+		ParamWitnessPayPerBlock:                  &p.WitnessPayPerBlock,
+		ParamWitness127PayPerBlock:               &p.Witness127PayPerBlock,
+		ParamCreateNewAccountFeeInSystemContract: &p.CreateNewAccountFeeInSystemContract,
+		ParamCreateNewAccountBandwidthRate:       &p.CreateNewAccountBandwidthRate,
+		ParamEnergyFee:                           &p.EnergyFee,
+		ParamMaxFeeLimit:                         &p.MaxFeeLimit,
+		ParamTotalEnergyCurrentLimit:             &p.TotalEnergyCurrentLimit,
+		ParamFreeNetLimit:                        &p.FreeNetLimit,
+		ParamTotalNetLimit:                       &p.TotalNetLimit,
+		ParamMemoFee:                             &p.MemoFee,
+		ParamUpdateAccountPermissionFee:          &p.UpdateAccountPermissionFee,
+		ParamMultiSignFee:                        &p.MultiSignFee,
+	}
+	for _, param := range p.ChainParameter {
+		switch param.Key {
+		case ParamMaintenanceTimeInterval:
+			p.MaintenanceTimeInterval = time.Duration(param.Value) * time.Millisecond
+		case ParamUnfreezeDelayDays:
+			p.UnfreezeDelay = time.Duration(param.Value) * 24 * time.Hour
+		default:
+			if field, ok := fields[param.Key]; ok {
+				*field = param.Value
+			}
+		}
+	}
+}
+
+// Get returns the value of the parameter key, e.g. ParamEnergyFee.
+func (p *ChainParameters) Get(key string) (int64, bool) {
+	for _, param := range p.ChainParameter {
+		if param.Key == key {
+			return param.Value, true
+		}
+	}
+
+	return 0, false
+}
+
+// GetChainParameters returns the network parameters, e.g. resource prices.
+// Docs: https://developers.tron.network/reference/wallet-getchainparameters
+func (api *api) GetChainParameters(ctx context.Context) (resp *ChainParameters, err error) {
+	resp = new(ChainParameters)
+	if err = api.do(ctx, &call{
+		endpoint:   "GetChainParameters",
+		method:     http.MethodPost,
+		path:       "/wallet/getchainparameters",
+		idempotent: true,
+		cache: func() time.Duration {
+			if len(resp.ChainParameter) != 0 {
+				return cacheTTLSlow
+			}
+
+			return 0
+		},
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	if len(resp.ChainParameter) == 0 {
+		return nil, ErrEmpty
+	}
+
+	return resp, nil
+}
+
+// Price is a resource price in sun, in effect from Since.
+type Price struct {
+	Since time.Time
+	Price int64
+}
+
+// PriceHistory is the history of a resource price, oldest first.
+type PriceHistory []Price
+
+// ParsePriceHistory parses the "timestamp:price" list returned by the price history endpoints,
+// e.g. "0:100,1575871200000:10". Timestamps are in milliseconds.
+func ParsePriceHistory(s string) (PriceHistory, error) {
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty price history", ErrInvalidRequest)
+	}
+
+	entries := strings.Split(s, ",")
+	history := make(PriceHistory, 0, len(entries))
+	for _, entry := range entries {
+		ts, price, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, fmt.Errorf("%w: price history entry %q", ErrInvalidRequest, entry)
+		}
+		ms, err := strconv.ParseInt(ts, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: price history entry %q: %v", ErrInvalidRequest, entry, err)
+		}
+		sun, err := strconv.ParseInt(price, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: price history entry %q: %v", ErrInvalidRequest, entry, err)
+		}
+		history = append(history, Price{Since: time.UnixMilli(ms), Price: sun})
+	}
+	sort.SliceStable(history, func(i, j int) bool { return history[i].Since.Before(history[j].Since) })
+
+	return history, nil
+}
+
+// At returns the price in effect at t, e.g. the time of the block of a transaction.
+// It returns false if t is before the history starts.
+func (h PriceHistory) At(t time.Time) (int64, bool) {
+	i := sort.Search(len(h), func(i int) bool { return h[i].Since.After(t) })
+	if i == 0 {
+		return 0, false
+	}
+
+	return h[i-1].Price, true
+}
+
+// AtBlock returns the price in effect at a block timestamp in milliseconds, e.g. TransactionInfo.BlockTimeStamp.
+func (h PriceHistory) AtBlock(timestamp int64) (int64, bool) {
+	return h.At(time.UnixMilli(timestamp))
+}
+
+// Current returns the latest price.
+func (h PriceHistory) Current() int64 {
+	if len(h) == 0 {
+		return 0
+	}
+
+	return h[len(h)-1].Price
+}
+
+// GetEnergyPrices returns the history of the price of energy.
+// Docs: https://developers.tron.network/reference/getenergyprices
+func (api *api) GetEnergyPrices(ctx context.Context) (PriceHistory, error) {
+	return api.getPrices(ctx, "GetEnergyPrices", "/wallet/getenergyprices")
+}
+
+// GetBandwidthPrices returns the history of the price of bandwidth.
+// Docs: https://developers.tron.network/reference/getbandwidthprices
+func (api *api) GetBandwidthPrices(ctx context.Context) (PriceHistory, error) {
+	return api.getPrices(ctx, "GetBandwidthPrices", "/wallet/getbandwidthprices")
+}
+
+func (api *api) getPrices(ctx context.Context, endpoint, path string) (PriceHistory, error) {
+	var v struct {
+		Prices string `json:"prices"`
+	}
+	if err := api.do(ctx, &call{
+		endpoint:   endpoint,
+		method:     http.MethodPost,
+		path:       path,
+		idempotent: true,
+		cache: func() time.Duration {
+			if v.Prices != "" {
+				return cacheTTLSlow
+			}
+
+			return 0
+		},
+	}, &v); err != nil {
+		return nil, err
+	}
+
+	if v.Prices == "" {
+		return nil, ErrEmpty
+	}
+
+	return ParsePriceHistory(v.Prices)
+}
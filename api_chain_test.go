@@ -0,0 +1,85 @@
+package trongrid_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+func TestApi_GetChainParameters(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	srv.SetChainParameter(trongrid.ParamEnergyFee, 210)
+	srv.SetChainParameter("getAllowTvmCancun", 1)
+
+	params, err := srv.Client().GetChainParameters(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(210), params.EnergyFee)
+	assert.Equal(t, int64(1_000), params.TransactionFee)
+	assert.Equal(t, int64(15_000_000_000), params.MaxFeeLimit)
+	assert.Equal(t, 6*time.Hour, params.MaintenanceTimeInterval)
+	assert.Equal(t, trongrid.UnfreezeDelay, params.UnfreezeDelay)
+
+	value, ok := params.Get("getAllowTvmCancun")
+	require.True(t, ok)
+	assert.Equal(t, int64(1), value)
+	_, ok = params.Get("getUnknown")
+	assert.False(t, ok)
+}
+
+func TestApi_GetEnergyPrices(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	srv.SetPrices("0:100,1575871200000:10,1606537680000:40", "")
+	api := srv.Client()
+
+	prices, err := api.GetEnergyPrices(context.Background())
+	require.NoError(t, err)
+	require.Len(t, prices, 3)
+	assert.Equal(t, int64(40), prices.Current())
+
+	// the price of a transaction is the one in effect when its block was produced
+	price, ok := prices.AtBlock(1_600_000_000_000)
+	require.True(t, ok)
+	assert.Equal(t, int64(10), price)
+	price, ok = prices.At(time.UnixMilli(1_606_537_680_000))
+	require.True(t, ok)
+	assert.Equal(t, int64(40), price)
+	price, ok = prices.AtBlock(0)
+	require.True(t, ok)
+	assert.Equal(t, int64(100), price)
+
+	_, err = api.GetBandwidthPrices(context.Background())
+	require.ErrorIs(t, err, trongrid.ErrEmpty)
+}
+
+func TestParsePriceHistory(t *testing.T) {
+	t.Parallel()
+
+	prices, err := trongrid.ParsePriceHistory("1606537680000:40,0:10")
+	require.NoError(t, err)
+	assert.Equal(t, trongrid.PriceHistory{
+		{Since: time.UnixMilli(0), Price: 10},
+		{Since: time.UnixMilli(1_606_537_680_000), Price: 40},
+	}, prices)
+
+	_, ok := trongrid.PriceHistory{{Since: time.UnixMilli(1_000), Price: 10}}.AtBlock(999)
+	assert.False(t, ok)
+
+	for _, s := range []string{"", "0:10,", "0", "x:10", "0:ten"} {
+		_, err = trongrid.ParsePriceHistory(s)
+		require.ErrorIs(t, err, trongrid.ErrInvalidRequest, s)
+	}
+}
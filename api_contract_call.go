@@ -0,0 +1,125 @@
+package trongrid
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+)
+
+// TriggerConstantContract runs a contract method locally on the node without creating a transaction,
+// e.g. a view method like balanceOf. The returned words are in ConstantResult.
+// Docs: https://developers.tron.network/reference/triggerconstantcontract
+func (api *api) TriggerConstantContract(
+	ctx context.Context,
+	req *TriggerSmartContractRequest,
+) (resp *TriggerSmartContractResponse, err error) {
+	resp = new(TriggerSmartContractResponse)
+	if err = api.do(ctx, &call{
+		endpoint:   "TriggerConstantContract",
+		method:     http.MethodPost,
+		path:       "/wallet/triggerconstantcontract",
+		body:       req,
+		idempotent: true,
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	if !resp.Result.Result {
+		return nil, NewAPIError(http.StatusOK, decodeMessage(resp.Result.Message), ErrInvalidRequest)
+	}
+
+	return resp, nil
+}
+
+// EstimateEnergy returns the energy a smart contract call would use. Nodes may have the endpoint
+// disabled, in which case the error wraps ErrInvalidRequest; TriggerConstantContract's EnergyUsed
+// is the fallback.
+// Docs: https://developers.tron.network/reference/estimateenergy
+func (api *api) EstimateEnergy(ctx context.Context, req *TriggerSmartContractRequest) (energy int64, err error) {
+	var v struct {
+		Result struct {
+			Result  bool   `json:"result"`
+			Message string `json:"message"`
+		} `json:"result"`
+		EnergyRequired int64 `json:"energy_required"`
+	}
+	if err = api.do(ctx, &call{
+		endpoint:   "EstimateEnergy",
+		method:     http.MethodPost,
+		path:       "/wallet/estimateenergy",
+		body:       req,
+		idempotent: true,
+	}, &v); err != nil {
+		return 0, err
+	}
+
+	if !v.Result.Result {
+		return 0, NewAPIError(http.StatusOK, decodeMessage(v.Result.Message), ErrInvalidRequest)
+	}
+
+	return v.EnergyRequired, nil
+}
+
+// callConstant calls a view method of contract taking no arguments and returns the raw result.
+func callConstant(ctx context.Context, caller ContractCaller, contract, selector string) ([]byte, error) {
+	resp, err := caller.TriggerConstantContract(ctx, &TriggerSmartContractRequest{
+		OwnerAddress:     contract,
+		ContractAddress:  contract,
+		FunctionSelector: selector,
+		Visible:          true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.ConstantResult) == 0 || resp.ConstantResult[0] == "" {
+		return nil, fmt.Errorf("%w: %s returned nothing for %s", ErrInvalidToken, contract, selector)
+	}
+
+	b, err := hex.DecodeString(resp.ConstantResult[0])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidToken, selector, err)
+	}
+
+	return b, nil
+}
+
+// decodeABIUint decodes a single uint256 return value.
+func decodeABIUint(b []byte) (*big.Int, error) {
+	if len(b) < 32 {
+		return nil, fmt.Errorf("%w: short uint256", ErrInvalidToken)
+	}
+
+	return new(big.Int).SetBytes(b[:32]), nil
+}
+
+// decodeABIString decodes a single string return value. Old tokens returning bytes32 are also accepted.
+func decodeABIString(b []byte) (string, error) {
+	if len(b) == 32 {
+		end := 0
+		for end < len(b) && b[end] != 0 {
+			end++
+		}
+
+		return string(b[:end]), nil
+	}
+
+	if len(b) < 64 {
+		return "", fmt.Errorf("%w: short string", ErrInvalidToken)
+	}
+
+	offset := new(big.Int).SetBytes(b[:32])
+	if !offset.IsInt64() || offset.Int64()+32 > int64(len(b)) {
+		return "", fmt.Errorf("%w: bad string offset", ErrInvalidToken)
+	}
+
+	start := offset.Int64() + 32
+	length := new(big.Int).SetBytes(b[start-32 : start])
+	if !length.IsInt64() || start+length.Int64() > int64(len(b)) {
+		return "", fmt.Errorf("%w: bad string length", ErrInvalidToken)
+	}
+
+	return string(b[start : start+length.Int64()]), nil
+}
@@ -0,0 +1,40 @@
+package trongrid
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type GetContractRequest struct {
+	Address string `json:"value"`
+	Visible bool   `json:"visible"`
+}
+
+// GetContract returns a smart contract with its ABI.
+// Docs: https://developers.tron.network/reference/wallet-getcontract
+func (api *api) GetContract(ctx context.Context, address string) (resp *SmartContract, err error) {
+	resp = new(SmartContract)
+	if err = api.do(ctx, &call{
+		endpoint:   "GetContract",
+		method:     http.MethodPost,
+		path:       "/wallet/getcontract",
+		body:       &GetContractRequest{Address: address, Visible: true},
+		idempotent: true,
+		cache: func() time.Duration {
+			if resp.ContractAddress != "" {
+				return cacheTTLSlow
+			}
+
+			return 0
+		},
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.ContractAddress == "" {
+		return nil, ErrEmpty
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,82 @@
+package trongrid
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type ListTransactionEventsRequest struct {
+	ID            string `url:"-"`
+	OnlyConfirmed bool   `url:"only_confirmed,omitempty"`
+}
+
+type ListContractEventsRequest struct {
+	Address      string `url:"-"`
+	EventName    string `url:"event_name,omitempty"`
+	BlockNumber  int64  `url:"block_number,omitempty"`
+	MinTimestamp int64  `url:"min_block_timestamp,omitempty"`
+	MaxTimestamp int64  `url:"max_block_timestamp,omitempty"`
+	Fingerprint  string `url:"fingerprint,omitempty"`
+	OrderBy      string `url:"order_by,omitempty"`
+	Limit        int32  `url:"limit,omitempty"`
+	// OnlyConfirmed and OnlyUnconfirmed are mutually exclusive
+	OnlyConfirmed   bool `url:"only_confirmed,omitempty"`
+	OnlyUnconfirmed bool `url:"only_unconfirmed,omitempty"`
+}
+
+// ListTransactionEvents returns the events emitted by a transaction.
+// Docs: https://developers.tron.network/reference/events-by-transaction-id
+func (api *api) ListTransactionEvents(
+	ctx context.Context,
+	req *ListTransactionEventsRequest,
+) (resp *ListEventsResponse, err error) {
+	params := url.Values{}
+	if err = api.encoder.Encode(req, params); err != nil {
+		api.logger.Error().Err(err).Send()
+
+		return nil, err
+	}
+
+	resp = new(ListEventsResponse)
+	if err = api.do(ctx, &call{
+		endpoint:   "ListTransactionEvents",
+		method:     http.MethodGet,
+		path:       "/v1/transactions/{id}/events",
+		pathParams: map[string]string{"id": req.ID},
+		query:      params,
+		idempotent: true,
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// ListContractEvents returns the events emitted by a contract.
+// Docs: https://developers.tron.network/reference/events-by-contract-address
+func (api *api) ListContractEvents(
+	ctx context.Context,
+	req *ListContractEventsRequest,
+) (resp *ListEventsResponse, err error) {
+	params := url.Values{}
+	if err = api.encoder.Encode(req, params); err != nil {
+		api.logger.Error().Err(err).Send()
+
+		return nil, err
+	}
+
+	resp = new(ListEventsResponse)
+	if err = api.do(ctx, &call{
+		endpoint:   "ListContractEvents",
+		method:     http.MethodGet,
+		path:       "/v1/contracts/{address}/events",
+		pathParams: map[string]string{"address": req.Address},
+		query:      params,
+		idempotent: true,
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,108 @@
+package trongrid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Result codes of GetSignWeight and GetApprovedList.
+const (
+	SignWeightEnough    = "ENOUGH_PERMISSION"
+	SignWeightNotEnough = "NOT_ENOUGH_PERMISSION"
+)
+
+type AccountPermissionUpdateRequest struct {
+	OwnerAddress string `json:"owner_address"`
+	// Owner is required; Witness only for witnesses. Actives replace all active permissions.
+	Owner        *Permission   `json:"owner"`
+	Witness      *Permission   `json:"witness,omitempty"`
+	Actives      []*Permission `json:"actives,omitempty"`
+	PermissionID int32         `json:"Permission_id,omitempty"`
+	Visible      bool          `json:"visible"`
+}
+
+// SignWeight is the signature weight of a transaction against the permission it uses.
+type SignWeight struct {
+	Permission *Permission `json:"permission"`
+	// CurrentWeight is the sum of the weights of ApprovedList
+	CurrentWeight int64    `json:"current_weight"`
+	ApprovedList  []string `json:"approved_list"`
+	Result        struct {
+		// Code is SignWeightEnough or SignWeightNotEnough for a valid transaction
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"result"`
+}
+
+// Enough reports whether the transaction can be broadcast.
+func (w *SignWeight) Enough() bool {
+	return w.Result.Code == SignWeightEnough
+}
+
+// AccountPermissionUpdate builds an unsigned update of the owner, witness and active permissions
+// of an account. It must be signed by the current owner permission and burns
+// ChainParameters.UpdateAccountPermissionFee.
+// Docs: https://developers.tron.network/reference/accountpermissionupdate
+func (api *api) AccountPermissionUpdate(
+	ctx context.Context,
+	req *AccountPermissionUpdateRequest,
+) (resp *Transaction, err error) {
+	if req.Owner == nil {
+		return nil, fmt.Errorf("%w: owner permission is required", ErrInvalidRequest)
+	}
+
+	return api.build(ctx, "AccountPermissionUpdate", "/wallet/accountpermissionupdate", req)
+}
+
+// GetSignWeight returns the weight of the signatures of tx against its permission.
+// Docs: https://developers.tron.network/reference/getsignweight
+func (api *api) GetSignWeight(ctx context.Context, tx *Transaction) (resp *SignWeight, err error) {
+	resp = new(SignWeight)
+	if err = api.do(ctx, &call{
+		endpoint:   "GetSignWeight",
+		method:     http.MethodPost,
+		path:       "/wallet/getsignweight",
+		body:       tx,
+		idempotent: true,
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	// the zero value of the code is omitted
+	if resp.Result.Code == "" {
+		resp.Result.Code = SignWeightEnough
+	}
+	if code := resp.Result.Code; code != SignWeightEnough && code != SignWeightNotEnough {
+		return nil, NewAPIError(http.StatusOK, code+": "+resp.Result.Message, ErrInvalidRequest)
+	}
+
+	return resp, nil
+}
+
+// GetApprovedList returns the addresses that signed tx.
+// Docs: https://developers.tron.network/reference/getapprovedlist
+func (api *api) GetApprovedList(ctx context.Context, tx *Transaction) ([]string, error) {
+	var v struct {
+		ApprovedList []string `json:"approved_list"`
+		Result       struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"result"`
+	}
+	if err := api.do(ctx, &call{
+		endpoint:   "GetApprovedList",
+		method:     http.MethodPost,
+		path:       "/wallet/getapprovedlist",
+		body:       tx,
+		idempotent: true,
+	}, &v); err != nil {
+		return nil, err
+	}
+
+	if v.Result.Code != "" && v.Result.Code != "SUCCESS" {
+		return nil, NewAPIError(http.StatusOK, v.Result.Code+": "+v.Result.Message, ErrInvalidRequest)
+	}
+
+	return v.ApprovedList, nil
+}
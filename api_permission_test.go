@@ -0,0 +1,94 @@
+package trongrid_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+func TestApi_AccountPermissionUpdate(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("/wallet/accountpermissionupdate", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"txID": "a0b1", "raw_data": {"contract": [{"type": "AccountPermissionUpdateContract"}]}}`))
+	})
+	api := srv.Client()
+
+	_, err := api.AccountPermissionUpdate(context.Background(), &trongrid.AccountPermissionUpdateRequest{
+		OwnerAddress: holderA,
+	})
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+
+	active := &trongrid.Permission{
+		Type:      trongrid.PermissionActive,
+		Name:      "payments",
+		Threshold: 1,
+		Keys:      []trongrid.PermissionKey{{Address: holderB, Weight: 1}},
+	}
+	require.NoError(t, active.SetOperations(trongrid.ContractTypeTRX))
+	tx, err := api.AccountPermissionUpdate(context.Background(), &trongrid.AccountPermissionUpdateRequest{
+		OwnerAddress: holderA,
+		Owner: &trongrid.Permission{
+			Name:      "owner",
+			Threshold: 1,
+			Keys:      []trongrid.PermissionKey{{Address: holderA, Weight: 1}},
+		},
+		Actives: []*trongrid.Permission{active},
+		Visible: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "a0b1", tx.TxID)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(srv.RequestsTo("/wallet/accountpermissionupdate")[0].Body, &body))
+	assert.NotContains(t, body, "witness")
+	assert.Equal(t, "payments", body["actives"].([]interface{})[0].(map[string]interface{})["permission_name"])
+}
+
+func TestApi_GetSignWeight(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	responses := []string{
+		`{"permission": {"permission_name": "owner", "threshold": 2,
+			"keys": [{"address": "` + holderA + `", "weight": 1}, {"address": "` + holderB + `", "weight": 1}]},
+			"current_weight": 1, "approved_list": ["` + holderA + `"],
+			"result": {"code": "NOT_ENOUGH_PERMISSION", "message": "Signature weight is 1, threshold is 2"}}`,
+		`{"permission": {"permission_name": "owner", "threshold": 2}, "current_weight": 2,
+			"approved_list": ["` + holderA + `", "` + holderB + `"], "result": {}}`,
+		`{"result": {"code": "PERMISSION_ERROR", "message": "Permission denied"}}`,
+	}
+	srv.Handle("/wallet/getsignweight", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(responses[0]))
+		responses = responses[1:]
+	})
+	api := srv.Client()
+	tx := &trongrid.Transaction{TxID: "a0b1"}
+
+	weight, err := api.GetSignWeight(context.Background(), tx)
+	require.NoError(t, err)
+	assert.False(t, weight.Enough())
+	assert.Equal(t, int64(1), weight.CurrentWeight)
+	assert.Equal(t, int64(2), weight.Permission.Threshold)
+
+	weight, err = api.GetSignWeight(context.Background(), tx)
+	require.NoError(t, err)
+	assert.True(t, weight.Enough())
+	assert.Len(t, weight.ApprovedList, 2)
+
+	_, err = api.GetSignWeight(context.Background(), tx)
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+	assert.Contains(t, err.Error(), "PERMISSION_ERROR")
+}
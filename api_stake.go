@@ -0,0 +1,270 @@
+package trongrid
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type FreezeBalanceV2Request struct {
+	OwnerAddress string `json:"owner_address"`
+	// FrozenBalance is in sun
+	FrozenBalance int64 `json:"frozen_balance"`
+	// Resource is ResourceBandwidth, ResourceEnergy or ResourceTronPower; empty is bandwidth
+	Resource     string `json:"resource,omitempty"`
+	PermissionID int32  `json:"Permission_id,omitempty"`
+	Visible      bool   `json:"visible"`
+}
+
+type UnfreezeBalanceV2Request struct {
+	OwnerAddress string `json:"owner_address"`
+	// UnfreezeBalance is in sun. It can be withdrawn UnfreezeDelay later.
+	UnfreezeBalance int64  `json:"unfreeze_balance"`
+	Resource        string `json:"resource,omitempty"`
+	PermissionID    int32  `json:"Permission_id,omitempty"`
+	Visible         bool   `json:"visible"`
+}
+
+type WithdrawExpireUnfreezeRequest struct {
+	OwnerAddress string `json:"owner_address"`
+	PermissionID int32  `json:"Permission_id,omitempty"`
+	Visible      bool   `json:"visible"`
+}
+
+type CancelAllUnfreezeV2Request struct {
+	OwnerAddress string `json:"owner_address"`
+	PermissionID int32  `json:"Permission_id,omitempty"`
+	Visible      bool   `json:"visible"`
+}
+
+type DelegateResourceRequest struct {
+	OwnerAddress    string `json:"owner_address"`
+	ReceiverAddress string `json:"receiver_address"`
+	// Balance is the staked TRX whose resource is delegated, in sun
+	Balance  int64  `json:"balance"`
+	Resource string `json:"resource,omitempty"`
+	// Lock prevents undelegating for LockPeriod blocks, 3 days when LockPeriod is 0
+	Lock         bool  `json:"lock,omitempty"`
+	LockPeriod   int64 `json:"lock_period,omitempty"`
+	PermissionID int32 `json:"Permission_id,omitempty"`
+	Visible      bool  `json:"visible"`
+}
+
+type UndelegateResourceRequest struct {
+	OwnerAddress    string `json:"owner_address"`
+	ReceiverAddress string `json:"receiver_address"`
+	Balance         int64  `json:"balance"`
+	Resource        string `json:"resource,omitempty"`
+	PermissionID    int32  `json:"Permission_id,omitempty"`
+	Visible         bool   `json:"visible"`
+}
+
+// DelegatedResource is the TRX staked by From whose resources are delegated to To.
+// Expire times are in milliseconds and are set while a delegation is locked.
+type DelegatedResource struct {
+	From                      string `json:"from"`
+	To                        string `json:"to"`
+	FrozenBalanceForBandwidth int64  `json:"frozen_balance_for_bandwidth"`
+	FrozenBalanceForEnergy    int64  `json:"frozen_balance_for_energy"`
+	ExpireTimeForBandwidth    int64  `json:"expire_time_for_bandwidth"`
+	ExpireTimeForEnergy       int64  `json:"expire_time_for_energy"`
+}
+
+// DelegatedResourceAccountIndex lists the accounts delegating resources to Account
+// and the accounts Account delegates to.
+type DelegatedResourceAccountIndex struct {
+	Account      string   `json:"account"`
+	FromAccounts []string `json:"fromAccounts"`
+	ToAccounts   []string `json:"toAccounts"`
+}
+
+type delegatedResourceRequest struct {
+	FromAddress string `json:"fromAddress"`
+	ToAddress   string `json:"toAddress"`
+	Visible     bool   `json:"visible"`
+}
+
+type delegatedResourceList struct {
+	DelegatedResource []*DelegatedResource `json:"delegatedResource"`
+}
+
+type stakeQueryRequest struct {
+	OwnerAddress string `json:"owner_address"`
+	Timestamp    int64  `json:"timestamp,omitempty"`
+	Type         uint64 `json:"type,omitempty"`
+	Visible      bool   `json:"visible"`
+}
+
+// FreezeBalanceV2 builds an unsigned Stake 2.0 freeze, staking TRX for bandwidth or energy.
+// Docs: https://developers.tron.network/reference/freezebalancev2-1
+func (api *api) FreezeBalanceV2(ctx context.Context, req *FreezeBalanceV2Request) (resp *Transaction, err error) {
+	return api.build(ctx, "FreezeBalanceV2", "/wallet/freezebalancev2", req)
+}
+
+// UnfreezeBalanceV2 builds an unsigned Stake 2.0 unfreeze.
+// Docs: https://developers.tron.network/reference/unfreezebalancev2-1
+func (api *api) UnfreezeBalanceV2(ctx context.Context, req *UnfreezeBalanceV2Request) (resp *Transaction, err error) {
+	return api.build(ctx, "UnfreezeBalanceV2", "/wallet/unfreezebalancev2", req)
+}
+
+// WithdrawExpireUnfreeze builds an unsigned withdrawal of the unfrozen TRX whose waiting period has passed.
+// Docs: https://developers.tron.network/reference/withdrawexpireunfreeze
+func (api *api) WithdrawExpireUnfreeze(
+	ctx context.Context,
+	req *WithdrawExpireUnfreezeRequest,
+) (resp *Transaction, err error) {
+	return api.build(ctx, "WithdrawExpireUnfreeze", "/wallet/withdrawexpireunfreeze", req)
+}
+
+// CancelAllUnfreezeV2 builds an unsigned cancellation of all pending unfreezes. Unfreezes still
+// waiting are staked again and expired ones are withdrawn.
+// Docs: https://developers.tron.network/reference/cancelallunfreezev2
+func (api *api) CancelAllUnfreezeV2(
+	ctx context.Context,
+	req *CancelAllUnfreezeV2Request,
+) (resp *Transaction, err error) {
+	return api.build(ctx, "CancelAllUnfreezeV2", "/wallet/cancelallunfreezev2", req)
+}
+
+// DelegateResource builds an unsigned delegation of staked bandwidth or energy to another account.
+// Docs: https://developers.tron.network/reference/delegateresource-1
+func (api *api) DelegateResource(ctx context.Context, req *DelegateResourceRequest) (resp *Transaction, err error) {
+	return api.build(ctx, "DelegateResource", "/wallet/delegateresource", req)
+}
+
+// UndelegateResource builds an unsigned reclaim of delegated bandwidth or energy.
+// Docs: https://developers.tron.network/reference/undelegateresource-1
+func (api *api) UndelegateResource(
+	ctx context.Context,
+	req *UndelegateResourceRequest,
+) (resp *Transaction, err error) {
+	return api.build(ctx, "UndelegateResource", "/wallet/undelegateresource", req)
+}
+
+// GetDelegatedResourceV2 returns the resources delegated from one account to another.
+// Docs: https://developers.tron.network/reference/getdelegatedresourcev2
+func (api *api) GetDelegatedResourceV2(ctx context.Context, from, to string) (resp []*DelegatedResource, err error) {
+	var v delegatedResourceList
+	if err = api.do(ctx, &call{
+		endpoint:   "GetDelegatedResourceV2",
+		method:     http.MethodPost,
+		path:       "/wallet/getdelegatedresourcev2",
+		body:       &delegatedResourceRequest{FromAddress: from, ToAddress: to, Visible: true},
+		idempotent: true,
+	}, &v); err != nil {
+		return nil, err
+	}
+
+	return v.DelegatedResource, nil
+}
+
+// GetDelegatedResourceAccountIndexV2 returns the accounts an account delegates resources to and from.
+// Docs: https://developers.tron.network/reference/getdelegatedresourceaccountindexv2-1
+func (api *api) GetDelegatedResourceAccountIndexV2(
+	ctx context.Context,
+	address string,
+) (resp *DelegatedResourceAccountIndex, err error) {
+	resp = new(DelegatedResourceAccountIndex)
+	if err = api.do(ctx, &call{
+		endpoint:   "GetDelegatedResourceAccountIndexV2",
+		method:     http.MethodPost,
+		path:       "/wallet/getdelegatedresourceaccountindexv2",
+		body:       &walletValueRequest{Value: address, Visible: true},
+		idempotent: true,
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// GetCanWithdrawUnfreezeAmount returns the unfrozen TRX an account can withdraw at a time, in sun.
+// A zero time means now.
+// Docs: https://developers.tron.network/reference/getcanwithdrawunfreezeamount-1
+func (api *api) GetCanWithdrawUnfreezeAmount(ctx context.Context, address string, at time.Time) (int64, error) {
+	if at.IsZero() {
+		at = time.Now()
+	}
+
+	var v struct {
+		Amount int64 `json:"amount"`
+	}
+	if err := api.do(ctx, &call{
+		endpoint:   "GetCanWithdrawUnfreezeAmount",
+		method:     http.MethodPost,
+		path:       "/wallet/getcanwithdrawunfreezeamount",
+		body:       &stakeQueryRequest{OwnerAddress: address, Timestamp: at.UnixMilli(), Visible: true},
+		idempotent: true,
+	}, &v); err != nil {
+		return 0, err
+	}
+
+	return v.Amount, nil
+}
+
+// GetAvailableUnfreezeCount returns how many more unfreezes an account can start,
+// out of MaxUnfreezingCount.
+// Docs: https://developers.tron.network/reference/getavailableunfreezecount-1
+func (api *api) GetAvailableUnfreezeCount(ctx context.Context, address string) (int64, error) {
+	var v struct {
+		Count int64 `json:"count"`
+	}
+	if err := api.do(ctx, &call{
+		endpoint:   "GetAvailableUnfreezeCount",
+		method:     http.MethodPost,
+		path:       "/wallet/getavailableunfreezecount",
+		body:       &stakeQueryRequest{OwnerAddress: address, Visible: true},
+		idempotent: true,
+	}, &v); err != nil {
+		return 0, err
+	}
+
+	return v.Count, nil
+}
+
+// GetCanDelegatedMaxSize returns the largest balance an account can delegate for a resource, in sun.
+// resource is ResourceBandwidth or ResourceEnergy.
+// Docs: https://developers.tron.network/reference/getcandelegatedmaxsize-1
+func (api *api) GetCanDelegatedMaxSize(ctx context.Context, address, resource string) (int64, error) {
+	code, err := resourceCode(resource)
+	if err != nil {
+		return 0, err
+	}
+
+	var v struct {
+		MaxSize int64 `json:"max_size"`
+	}
+	if err = api.do(ctx, &call{
+		endpoint:   "GetCanDelegatedMaxSize",
+		method:     http.MethodPost,
+		path:       "/wallet/getcandelegatedmaxsize",
+		body:       &stakeQueryRequest{OwnerAddress: address, Type: code, Visible: true},
+		idempotent: true,
+	}, &v); err != nil {
+		return 0, err
+	}
+
+	return v.MaxSize, nil
+}
+
+// build posts a transaction builder request and returns the unsigned transaction.
+// Builder calls are retried but never coalesced.
+func (api *api) build(ctx context.Context, endpoint, path string, req interface{}) (resp *Transaction, err error) {
+	resp = new(Transaction)
+	if err = api.do(ctx, &call{
+		endpoint:   endpoint,
+		method:     http.MethodPost,
+		path:       path,
+		body:       req,
+		idempotent: true,
+		unique:     true,
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.TxID == "" {
+		return nil, ErrEmpty
+	}
+
+	return resp, nil
+}
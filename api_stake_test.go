@@ -0,0 +1,109 @@
+package trongrid_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+func TestApi_DelegateResource(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("/wallet/delegateresource", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"txID": "d3f1", "raw_data": {"contract": [{"type": "DelegateResourceContract"}]}}`))
+	})
+
+	tx, err := srv.Client().DelegateResource(context.Background(), &trongrid.DelegateResourceRequest{
+		OwnerAddress:    holderA,
+		ReceiverAddress: holderB,
+		Balance:         100_000_000,
+		Resource:        trongrid.ResourceEnergy,
+		Lock:            true,
+		LockPeriod:      trongrid.BlocksPerLockDay,
+		Visible:         true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "d3f1", tx.TxID)
+	assert.Equal(t, trongrid.ContractTypeDelegateResource, tx.RawData.Contract[0].Type)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(srv.RequestsTo("/wallet/delegateresource")[0].Body, &body))
+	assert.Equal(t, "ENERGY", body["resource"])
+	assert.Equal(t, true, body["lock"])
+	assert.Equal(t, float64(trongrid.BlocksPerLockDay), body["lock_period"])
+}
+
+func TestApi_FreezeBalanceV2_Error(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("/wallet/freezebalancev2", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"Error": "frozenBalance must be less than or equal to accountBalance"}`))
+	})
+
+	_, err := srv.Client().FreezeBalanceV2(context.Background(), &trongrid.FreezeBalanceV2Request{
+		OwnerAddress:  holderA,
+		FrozenBalance: 1_000_000_000,
+		Visible:       true,
+	})
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+}
+
+func TestApi_GetCanDelegatedMaxSize(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("/wallet/getcandelegatedmaxsize", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"max_size": 200000000}`))
+	})
+
+	size, err := srv.Client().GetCanDelegatedMaxSize(context.Background(), holderA, trongrid.ResourceEnergy)
+	require.NoError(t, err)
+	assert.Equal(t, int64(200_000_000), size)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(srv.RequestsTo("/wallet/getcandelegatedmaxsize")[0].Body, &body))
+	assert.Equal(t, float64(1), body["type"])
+
+	_, err = srv.Client().GetCanDelegatedMaxSize(context.Background(), holderA, "CPU")
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+}
+
+func TestApi_GetCanWithdrawUnfreezeAmount(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("/wallet/getcanwithdrawunfreezeamount", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"amount": 5000000}`))
+	})
+
+	// a zero time asks for the amount withdrawable now
+	before := time.Now().UnixMilli()
+	amount, err := srv.Client().GetCanWithdrawUnfreezeAmount(context.Background(), holderA, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5_000_000), amount)
+
+	var body struct {
+		Timestamp int64 `json:"timestamp"`
+	}
+	require.NoError(t, json.Unmarshal(srv.RequestsTo("/wallet/getcanwithdrawunfreezeamount")[0].Body, &body))
+	assert.GreaterOrEqual(t, body.Timestamp, before)
+	assert.LessOrEqual(t, body.Timestamp, time.Now().UnixMilli())
+}
@@ -0,0 +1,168 @@
+package trongrid
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+type ListTokenHoldersRequest struct {
+	// Address is the TRC20 contract
+	Address string `url:"-"`
+	// OrderBy is "balance,asc" or "balance,desc"
+	OrderBy     string `url:"order_by,omitempty"`
+	Limit       int32  `url:"limit,omitempty"`
+	Fingerprint string `url:"fingerprint,omitempty"`
+	// OnlyConfirmed and OnlyUnconfirmed are mutually exclusive
+	OnlyConfirmed   bool `url:"only_confirmed,omitempty"`
+	OnlyUnconfirmed bool `url:"only_unconfirmed,omitempty"`
+}
+
+type ListTRC20BalancesRequest struct {
+	Address       string `url:"-"`
+	OrderBy       string `url:"order_by,omitempty"`
+	Limit         int32  `url:"limit,omitempty"`
+	Fingerprint   string `url:"fingerprint,omitempty"`
+	OnlyConfirmed bool   `url:"only_confirmed,omitempty"`
+}
+
+// TokenHolder is an account holding a TRC20 token.
+type TokenHolder struct {
+	Address string `json:"address"`
+	Balance Amount `json:"balance"`
+}
+
+type ListTokenHoldersResponse struct {
+	// Token is the token the balances are in
+	Token Token         `json:"token"`
+	Data  []TokenHolder `json:"data"`
+	Meta  *Meta         `json:"meta"`
+}
+
+// TokenBalance is the balance of an account in a TRC20 token.
+type TokenBalance struct {
+	Token   Token  `json:"token"`
+	Balance Amount `json:"balance"`
+	// Unresolved is set for tokens whose metadata could not be read, e.g. non-standard or spam
+	// contracts: Token only has its address and Balance is in base units, with 0 decimals
+	Unresolved bool `json:"unresolved,omitempty"`
+}
+
+type ListTRC20BalancesResponse struct {
+	Data []TokenBalance `json:"data"`
+	Meta *Meta          `json:"meta"`
+}
+
+// balancesResponse is the raw form of both endpoints: one {address: balance} object per row.
+type balancesResponse struct {
+	Data    []map[string]string `json:"data"`
+	Success bool                `json:"success"`
+	Meta    *Meta               `json:"meta"`
+}
+
+// ListTokenHolders returns the holders of a TRC20 token and their balances.
+// Docs: https://developers.tron.network/reference/get-trc20-token-holder-balances
+func (api *api) ListTokenHolders(
+	ctx context.Context,
+	req *ListTokenHoldersRequest,
+) (resp *ListTokenHoldersResponse, err error) {
+	token, err := api.tokens.Lookup(ctx, req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	if err = api.encoder.Encode(req, params); err != nil {
+		api.logger.Error().Err(err).Send()
+
+		return nil, err
+	}
+
+	var v balancesResponse
+	if err = api.do(ctx, &call{
+		endpoint:   "ListTokenHolders",
+		method:     http.MethodGet,
+		path:       "/v1/contracts/{address}/tokens",
+		pathParams: map[string]string{"address": req.Address},
+		query:      params,
+		idempotent: true,
+	}, &v); err != nil {
+		return nil, err
+	}
+
+	resp = &ListTokenHoldersResponse{Token: token, Data: make([]TokenHolder, 0, len(v.Data)), Meta: v.Meta}
+	for _, row := range v.Data {
+		for address, balance := range row {
+			amount, err := AmountFromBaseUnits(balance, token.Decimals)
+			if err != nil {
+				return nil, err
+			}
+			resp.Data = append(resp.Data, TokenHolder{Address: address, Balance: amount})
+		}
+	}
+
+	return resp, nil
+}
+
+// ListTRC20Balances returns the TRC20 balances of an account. Tokens rejected by the
+// token registry, e.g. denylisted lookalikes, are left out; tokens whose decimals, symbol
+// or name cannot be read are returned Unresolved.
+//
+// The registry reads the metadata of every token it does not know with up to three constant
+// calls, about three seconds each under the default rate limit of one request per second.
+// Resolved tokens are remembered, unresolved ones for DefaultUnresolvedTokenTTL, see
+// WithUnresolvedTokenTTL.
+// Docs: https://developers.tron.network/reference/trc20-balance
+func (api *api) ListTRC20Balances(
+	ctx context.Context,
+	req *ListTRC20BalancesRequest,
+) (resp *ListTRC20BalancesResponse, err error) {
+	params := url.Values{}
+	if err = api.encoder.Encode(req, params); err != nil {
+		api.logger.Error().Err(err).Send()
+
+		return nil, err
+	}
+
+	var v balancesResponse
+	if err = api.do(ctx, &call{
+		endpoint:   "ListTRC20Balances",
+		method:     http.MethodGet,
+		path:       "/v1/accounts/{address}/trc20/balance",
+		pathParams: map[string]string{"address": req.Address},
+		query:      params,
+		idempotent: true,
+	}, &v); err != nil {
+		return nil, err
+	}
+
+	resp = &ListTRC20BalancesResponse{Data: make([]TokenBalance, 0, len(v.Data)), Meta: v.Meta}
+	for _, row := range v.Data {
+		for contract, balance := range row {
+			token, err := api.tokens.Lookup(ctx, contract)
+			switch {
+			case errors.Is(err, ErrTokenNotAllowed):
+				continue
+			case unresolvableToken(err):
+				amount, err := AmountFromBaseUnits(balance, 0)
+				if err != nil {
+					return nil, err
+				}
+				resp.Data = append(resp.Data, TokenBalance{Token: Token{Address: contract}, Balance: amount, Unresolved: true})
+
+				continue
+			case err != nil:
+				return nil, err
+			}
+
+			amount, err := AmountFromBaseUnits(balance, token.Decimals)
+			if err != nil {
+				return nil, err
+			}
+			resp.Data = append(resp.Data, TokenBalance{Token: token, Balance: amount})
+		}
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,120 @@
+package trongrid_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+const (
+	testToken = "TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf"
+	spamToken = "TG3XXyExBkPp9nzdajDZsozEu4BkaSJozs"
+	holderA   = "TPqG9VfqXycvNTaoBunqLWxUi69gnnQ6Fq"
+	holderB   = "TDuzLK9vBRuSdhLovyy5gCD2bGp4fjecHk"
+)
+
+func TestApi_ListTokenHolders(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	srv.AddToken(trongrid.Token{Address: testToken, Name: "Tether USD", Symbol: "USDT", Decimals: 6})
+	srv.SetTokenBalance(testToken, holderA, big.NewInt(1_500_000))
+	srv.SetTokenBalance(testToken, holderB, big.NewInt(25_000_000))
+
+	api := srv.Client()
+	ctx := context.Background()
+
+	resp, err := api.ListTokenHolders(ctx, &trongrid.ListTokenHoldersRequest{Address: testToken, Limit: 1})
+	require.NoError(t, err)
+	assert.Equal(t, "USDT", resp.Token.Symbol)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, holderB, resp.Data[0].Address)
+	assert.Equal(t, "25", resp.Data[0].Balance.String())
+
+	resp, err = api.ListTokenHolders(ctx, &trongrid.ListTokenHoldersRequest{
+		Address:     testToken,
+		Limit:       1,
+		Fingerprint: resp.Meta.Fingerprint,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, "1.5", resp.Data[0].Balance.String())
+
+	// decimals are resolved once and cached by the token registry
+	srv.AssertRequestCount(t, "/wallet/triggerconstantcontract", 3)
+}
+
+func TestApi_ListTRC20Balances(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	srv.AddToken(trongrid.Token{Address: testToken, Name: "Tether USD", Symbol: "USDT", Decimals: 6})
+	srv.SetTokenBalance(testToken, holderA, big.NewInt(1_500_000))
+	srv.SetTokenBalance(spamToken, holderA, big.NewInt(1_000_000_000))
+
+	api := srv.Client(trongrid.WithTokenRegistry(trongrid.WithTokenDenylist(spamToken)))
+
+	resp, err := api.ListTRC20Balances(context.Background(), &trongrid.ListTRC20BalancesRequest{Address: holderA})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, testToken, resp.Data[0].Token.Address)
+	assert.Equal(t, "1.5", resp.Data[0].Balance.String())
+	assert.Equal(t, "1500000", resp.Data[0].Balance.BaseUnits())
+	assert.False(t, resp.Data[0].Unresolved)
+}
+
+func TestApi_ListTRC20Balances_Unresolved(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	// spamToken answers no metadata call, like most airdropped tokens
+	srv.AddToken(trongrid.Token{Address: testToken, Name: "Tether USD", Symbol: "USDT", Decimals: 6})
+	srv.SetTokenBalance(testToken, holderA, big.NewInt(1_500_000))
+	srv.SetTokenBalance(spamToken, holderA, big.NewInt(1_000_000_000))
+
+	api := srv.Client()
+	resp, err := api.ListTRC20Balances(context.Background(), &trongrid.ListTRC20BalancesRequest{Address: holderA})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 2)
+
+	// the spam token is remembered instead of being called on every listing
+	constantCalls := func() (n int) {
+		for _, r := range srv.Requests() {
+			if r.Path == "/wallet/triggerconstantcontract" {
+				n++
+			}
+		}
+
+		return n
+	}
+	calls := constantCalls()
+	require.Positive(t, calls)
+	again, err := api.ListTRC20Balances(context.Background(), &trongrid.ListTRC20BalancesRequest{Address: holderA})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, resp.Data, again.Data)
+	assert.Equal(t, calls, constantCalls())
+
+	balances := make(map[string]trongrid.TokenBalance)
+	for _, b := range resp.Data {
+		balances[b.Token.Address] = b
+	}
+	assert.Equal(t, "1.5", balances[testToken].Balance.String())
+	assert.False(t, balances[testToken].Unresolved)
+
+	spam := balances[spamToken]
+	assert.True(t, spam.Unresolved)
+	assert.Empty(t, spam.Token.Symbol)
+	assert.Equal(t, "1000000000", spam.Balance.BaseUnits())
+}
@@ -0,0 +1,158 @@
+package trongrid
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/http"
+)
+
+type CreateTransactionRequest struct {
+	OwnerAddress string `json:"owner_address"`
+	ToAddress    string `json:"to_address"`
+	// Amount is in sun
+	Amount       int64 `json:"amount"`
+	PermissionID int32 `json:"Permission_id,omitempty"`
+	// Visible selects base58 instead of hex addresses in the request and the returned raw_data
+	Visible bool `json:"visible"`
+}
+
+type TriggerSmartContractRequest struct {
+	OwnerAddress     string `json:"owner_address"`
+	ContractAddress  string `json:"contract_address"`
+	FunctionSelector string `json:"function_selector,omitempty"`
+	// Parameter is the hex encoded ABI arguments, without the method id
+	Parameter string `json:"parameter,omitempty"`
+	// Data is the hex encoded call data, method id included. It replaces FunctionSelector and Parameter.
+	Data string `json:"data,omitempty"`
+	// FeeLimit is the maximum TRX burned for energy, in sun
+	FeeLimit     int64 `json:"fee_limit"`
+	CallValue    int64 `json:"call_value,omitempty"`
+	PermissionID int32 `json:"Permission_id,omitempty"`
+	Visible      bool  `json:"visible"`
+}
+
+type TriggerSmartContractResponse struct {
+	Result struct {
+		Result  bool   `json:"result"`
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	} `json:"result"`
+	EnergyUsed     int64        `json:"energy_used"`
+	ConstantResult []string     `json:"constant_result"`
+	Transaction    *Transaction `json:"transaction"`
+}
+
+type BroadcastResponse struct {
+	Result  bool   `json:"result"`
+	TxID    string `json:"txid"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type broadcastHexRequest struct {
+	Transaction string `json:"transaction"`
+}
+
+// CreateTransaction builds an unsigned TRX transfer.
+// Docs: https://developers.tron.network/reference/createtransaction
+func (api *api) CreateTransaction(ctx context.Context, req *CreateTransactionRequest) (resp *Transaction, err error) {
+	resp = new(Transaction)
+	if err = api.do(ctx, &call{
+		endpoint:   "CreateTransaction",
+		method:     http.MethodPost,
+		path:       "/wallet/createtransaction",
+		body:       req,
+		idempotent: true,
+		unique:     true,
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.TxID == "" {
+		return nil, ErrEmpty
+	}
+
+	return resp, nil
+}
+
+// TriggerSmartContract builds an unsigned smart contract call, e.g. a TRC20 transfer.
+// Docs: https://developers.tron.network/reference/triggersmartcontract
+func (api *api) TriggerSmartContract(
+	ctx context.Context,
+	req *TriggerSmartContractRequest,
+) (resp *TriggerSmartContractResponse, err error) {
+	resp = new(TriggerSmartContractResponse)
+	if err = api.do(ctx, &call{
+		endpoint:   "TriggerSmartContract",
+		method:     http.MethodPost,
+		path:       "/wallet/triggersmartcontract",
+		body:       req,
+		idempotent: true,
+		unique:     true,
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	if !resp.Result.Result {
+		return nil, NewAPIError(http.StatusOK, decodeMessage(resp.Result.Message), ErrInvalidRequest)
+	}
+
+	return resp, nil
+}
+
+// BroadcastTransaction sends a signed transaction to the network. It is never retried,
+// so a network error leaves the outcome unknown; look the transaction up by ID before resending.
+// Docs: https://developers.tron.network/reference/broadcasthex
+func (api *api) BroadcastTransaction(ctx context.Context, tx *Transaction) (resp *BroadcastResponse, err error) {
+	raw, err := encodeTransaction(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	resp = new(BroadcastResponse)
+	if err = api.do(ctx, &call{
+		endpoint: "BroadcastTransaction",
+		method:   http.MethodPost,
+		path:     "/wallet/broadcasthex",
+		body:     &broadcastHexRequest{Transaction: hex.EncodeToString(raw)},
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	if !resp.Result {
+		return resp, NewAPIError(http.StatusOK, resp.Code+": "+decodeMessage(resp.Message), ErrBroadcastFailed)
+	}
+
+	return resp, nil
+}
+
+// decodeMessage decodes the hex encoded messages of the wallet API, returning other messages unchanged.
+func decodeMessage(message string) string {
+	if b, err := hex.DecodeString(message); err == nil {
+		return string(b)
+	}
+
+	return message
+}
+
+// TRC20TransferSelector is the function selector of the TRC20 transfer method.
+const TRC20TransferSelector = "transfer(address,uint256)"
+
+// EncodeTRC20Transfer returns the TriggerSmartContractRequest parameter of a TRC20 transfer.
+func EncodeTRC20Transfer(to string, amount *big.Int) (string, error) {
+	a, err := ParseAddress(to)
+	if err != nil {
+		return "", err
+	}
+	if amount == nil || amount.Sign() < 0 || amount.BitLen() > 256 {
+		return "", fmt.Errorf("%w: amount", ErrInvalidRequest)
+	}
+
+	word := make([]byte, 64)
+	copy(word[12:32], a[1:])
+	amount.FillBytes(word[32:])
+
+	return hex.EncodeToString(word), nil
+}
@@ -0,0 +1,81 @@
+package trongrid
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type GetTransactionRequest struct {
+	ID string `json:"value"`
+	// OnlyConfirmed queries the solidity node, so only confirmed transactions are returned
+	OnlyConfirmed bool `json:"-"`
+}
+
+// GetTransactionByID returns a transaction by its ID.
+// Docs: https://developers.tron.network/reference/gettransactionbyid
+func (api *api) GetTransactionByID(ctx context.Context, req *GetTransactionRequest) (resp *Transaction, err error) {
+	resp = new(Transaction)
+	if err = api.do(ctx, &call{
+		endpoint:   "GetTransactionByID",
+		method:     http.MethodPost,
+		path:       walletPath(req.OnlyConfirmed, "/gettransactionbyid"),
+		body:       req,
+		idempotent: true,
+		cache: func() time.Duration {
+			if req.OnlyConfirmed && resp.TxID != "" {
+				return cacheTTLImmutable
+			}
+
+			return 0
+		},
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.TxID == "" {
+		return nil, ErrEmpty
+	}
+
+	return resp, nil
+}
+
+// GetTransactionInfoByID returns the fee, receipt and logs of a transaction included in a block.
+// Docs: https://developers.tron.network/reference/transaction-information-by-id
+func (api *api) GetTransactionInfoByID(
+	ctx context.Context,
+	req *GetTransactionRequest,
+) (resp *TransactionInfo, err error) {
+	resp = new(TransactionInfo)
+	if err = api.do(ctx, &call{
+		endpoint:   "GetTransactionInfoByID",
+		method:     http.MethodPost,
+		path:       walletPath(req.OnlyConfirmed, "/gettransactioninfobyid"),
+		body:       req,
+		idempotent: true,
+		cache: func() time.Duration {
+			if req.OnlyConfirmed && resp.ID != "" {
+				return cacheTTLImmutable
+			}
+
+			return 0
+		},
+	}, resp); err != nil {
+		return nil, err
+	}
+
+	if resp.ID == "" {
+		return nil, ErrEmpty
+	}
+
+	return resp, nil
+}
+
+// walletPath returns the full node or, for confirmed data, the solidity node path of a wallet endpoint.
+func walletPath(onlyConfirmed bool, endpoint string) string {
+	if onlyConfirmed {
+		return "/walletsolidity" + endpoint
+	}
+
+	return "/wallet" + endpoint
+}
@@ -2,11 +2,10 @@ package trongrid
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
+	"net/http"
 	"net/url"
 	"time"
-
-	"github.com/go-resty/resty/v2"
 )
 
 type ListTransactionsRequest struct {
@@ -38,72 +37,149 @@ func (api *api) ListTransactions(
 		return nil, err
 	}
 
-	r := api.cl.R().
-		ForceContentType("application/json").
-		SetContext(ctx).
-		SetError(new(Error)).
-		SetHeader("TRON-PRO-API-KEY", api.token).
-		SetPathParam("address", req.Address).
-		SetQueryParamsFromValues(params).
-		SetResult(new(ListTransactionsResponse))
-
-	var httpResp *resty.Response
-	//https://api.shasta.trongrid.io
-	if httpResp, err = r.Get(api.uri + "/v1/accounts/{address}/transactions"); err != nil {
+	resp = new(ListTransactionsResponse)
+	if err = api.do(ctx, &call{
+		endpoint:   "ListTransactions",
+		method:     http.MethodGet,
+		path:       "/v1/accounts/{address}/transactions",
+		pathParams: map[string]string{"address": req.Address},
+		query:      params,
+		idempotent: true,
+	}, resp); err != nil {
 		return nil, err
 	}
 
-	if v, ok := httpResp.Error().(*Error); ok {
-		err = fmt.Errorf("%w: %s", ErrEmpty, v.Error)
-		api.logger.Error().Err(err).Send()
+	return resp, nil
+}
 
+// ListTransactionsTrc20 returns the TRC20 transfers of an account.
+func (api *api) ListTransactionsTrc20(ctx context.Context,
+	req *ListTransactionsRequest,
+) (resp *TRC20Response, err error) {
+	params := url.Values{}
+	if err = api.encoder.Encode(req, params); err != nil {
+		api.logger.Error().Err(err).Send()
 		return nil, err
 	}
 
-	if v, ok := httpResp.Result().(*ListTransactionsResponse); ok {
-		return v, nil
+	resp = new(TRC20Response)
+	if err = api.do(ctx, &call{
+		endpoint:   "ListTransactionsTrc20",
+		method:     http.MethodGet,
+		path:       "/v1/accounts/{address}/transactions/trc20",
+		pathParams: map[string]string{"address": req.Address},
+		query:      params,
+		idempotent: true,
+	}, resp); err != nil {
+		return nil, err
 	}
 
-	return nil, ErrEmpty
+	return resp, nil
 }
 
-// 修改返回类型和结果映射
-func (api *api) ListTransactionsTrc20(ctx context.Context,
+// ListInternalTransactions returns the internal transactions sent or received by an account,
+// e.g. TRX forwarded to it by a contract. TronGrid lists them as separate rows of the account's
+// transaction history, so paging and Meta are those of the history and a page may hold none.
+// OnlyFrom and OnlyTo select by the internal sender and recipient; they are not sent to TronGrid,
+// which would filter by the sender of the outer transaction and drop every incoming transfer.
+//
+// Rejected internal transactions are skipped: they are calls reverted by the contract, and their
+// value never reached the recipient.
+func (api *api) ListInternalTransactions(
+	ctx context.Context,
 	req *ListTransactionsRequest,
-) (resp *TRC20Response, err error) { // 修改返回类型为TRC20Response
+) (resp *ListInternalTransactionsResponse, err error) {
+	address, err := ParseAddress(req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	history := *req
+	history.OnlyFrom, history.OnlyTo = false, false
 	params := url.Values{}
-	if err = api.encoder.Encode(req, params); err != nil {
+	if err = api.encoder.Encode(&history, params); err != nil {
 		api.logger.Error().Err(err).Send()
+
 		return nil, err
 	}
 
-	r := api.cl.R().
-		ForceContentType("application/json").
-		SetContext(ctx).
-		SetError(new(Error)).
-		SetHeader("TRON-PRO-API-KEY", api.token).
-		SetPathParam("address", req.Address).
-		SetQueryParamsFromValues(params).
-		SetResult(new(TRC20Response)) // 修改结果映射
-
-	var httpResp *resty.Response
-	if httpResp, err = r.Get(api.uri + "/v1/accounts/{address}/transactions/trc20"); err != nil {
+	rows := new(listInternalTransactionsRows)
+	if err = api.do(ctx, &call{
+		endpoint:   "ListInternalTransactions",
+		method:     http.MethodGet,
+		path:       "/v1/accounts/{address}/transactions",
+		pathParams: map[string]string{"address": req.Address},
+		query:      params,
+		idempotent: true,
+	}, rows); err != nil {
 		return nil, err
 	}
 
-	if v, ok := httpResp.Error().(*Error); ok {
-		err = fmt.Errorf("%w: %s", ErrEmpty, v.Error)
-		api.logger.Error().Err(err).Send()
+	resp = &ListInternalTransactionsResponse{Data: []InternalTransfer{}, Success: rows.Success, Meta: rows.Meta}
+	for _, raw := range rows.Data {
+		transfers, err := internalTransfers(raw)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range transfers {
+			from, _ := ParseAddress(t.InternalTransaction.CallerAddress)
+			to, _ := ParseAddress(t.InternalTransaction.TransferToAddress)
+			switch {
+			case t.InternalTransaction.Rejected:
+			case from != address && to != address:
+			case req.OnlyFrom && from != address:
+			case req.OnlyTo && to != address:
+			default:
+				resp.Data = append(resp.Data, t)
+			}
+		}
+	}
+
+	return resp, nil
+}
+
+// listInternalTransactionsRows is an account's transaction history, whose rows are either
+// transactions or internal transactions.
+type listInternalTransactionsRows struct {
+	Meta    *Meta             `json:"meta"`
+	Data    []json.RawMessage `json:"data"`
+	Success bool              `json:"success"`
+}
+
+// internalTransfers returns the internal transactions of a history row: the row itself when it is
+// an internal transaction, otherwise those nested in the transaction.
+func internalTransfers(raw json.RawMessage) ([]InternalTransfer, error) {
+	var row struct {
+		InternalTxID   string `json:"internal_tx_id"`
+		TxID           string `json:"tx_id"`
+		BlockTimestamp int64  `json:"block_timestamp"`
+	}
+	if err := json.Unmarshal(raw, &row); err != nil {
 		return nil, err
 	}
 
-	if v, ok := httpResp.Result().(*TRC20Response); ok {
-		// 添加分页参数处理（如果需要）
-		//if req.Fingerprint == "" && v.Meta != nil {
-		//	// 可以在这里处理分页指纹
-		//}
-		return v, nil
+	if row.InternalTxID != "" {
+		t := InternalTransfer{TransactionID: row.TxID, BlockTimestamp: row.BlockTimestamp}
+		if err := json.Unmarshal(raw, &t.InternalTransaction); err != nil {
+			return nil, err
+		}
+
+		return []InternalTransfer{t}, nil
+	}
+
+	var tx Transaction
+	if err := json.Unmarshal(raw, &tx); err != nil {
+		return nil, err
+	}
+	transfers := make([]InternalTransfer, 0, len(tx.InternalTransactions))
+	for _, itx := range tx.InternalTransactions {
+		transfers = append(transfers, InternalTransfer{
+			InternalTransaction: itx,
+			TransactionID:       tx.TxID,
+			BlockNumber:         tx.BlockNumber,
+			BlockTimestamp:      tx.BlockTimestamp,
+		})
 	}
 
-	return nil, ErrEmpty
+	return transfers, nil
 }
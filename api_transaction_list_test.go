@@ -2,33 +2,43 @@ package trongrid_test
 
 import (
 	"context"
+	"encoding/json"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
 )
 
 func TestApi_ListTransactions(t *testing.T) {
 	t.Parallel()
 
+	now := time.Now()
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	tx := &trongrid.Transaction{TxID: "5f2b", BlockTimestamp: now.Add(-time.Hour).UnixMilli()}
+	tx.RawData.Contract = []trongrid.Contract{{Type: trongrid.ContractTypeTRX}}
+	tx.RawData.Contract[0].Parameter.Value.Amount = 1_500_000
+	srv.AddTransaction("TPqG9VfqXycvNTaoBunqLWxUi69gnnQ6Fq", tx)
+
 	logger := zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).With().Timestamp().Logger()
-	api := trongrid.NewAPI(
-		trongrid.WithURI("https://api.shasta.trongrid.io"),
+	api := srv.Client(
 		trongrid.WithDebug(),
 		trongrid.WithLogger(&logger),
 		trongrid.WithToken("622ec85e-7406-431d-9caf-0a19501469a4"),
 	)
 
 	ctx := context.Background()
-	now := time.Now()
 
 	modelListTransactionsRequest, err := api.ListTransactions(ctx, &trongrid.ListTransactionsRequest{
-		MaxTimestamp:  now.Add(-(time.Hour * 24)),
-		MinTimestamp:  now,
+		MaxTimestamp:  now,
+		MinTimestamp:  now.Add(-(time.Hour * 24)),
 		Address:       "TPqG9VfqXycvNTaoBunqLWxUi69gnnQ6Fq",
 		Fingerprint:   "",
 		OrderBy:       "block_timestamp,desc",
@@ -38,6 +48,9 @@ func TestApi_ListTransactions(t *testing.T) {
 		OnlyTo:        false,
 	})
 	require.NoError(t, err)
+	require.Len(t, modelListTransactionsRequest.Data, 1)
+	srv.AssertQuery(t, "/v1/accounts/TPqG9VfqXycvNTaoBunqLWxUi69gnnQ6Fq/transactions", "limit", "200")
+	require.Equal(t, "622ec85e-7406-431d-9caf-0a19501469a4", srv.Requests()[0].Header.Get("TRON-PRO-API-KEY"))
 	for i := 0; i < len(modelListTransactionsRequest.Data); i++ {
 		item := modelListTransactionsRequest.Data[i]
 		//t.Logf("交易ID: %v", item)
@@ -49,16 +62,28 @@ func TestApi_ListTransactions(t *testing.T) {
 func TestApi_ListTransactionsTrc20(t *testing.T) {
 	t.Parallel()
 
+	now := time.Now()
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	srv.AddTRC20Transfer(trongrid.TRC20Transaction{
+		TransactionID:  "9a3c",
+		TokenInfo:      trongrid.TokenInfo{Symbol: "USDT", Address: "TG3XXyExBkPp9nzdajDZsozEu4BkaSJozs", Decimals: 6, Name: "Tether USD"},
+		BlockTimestamp: now.Add(-time.Hour).UnixMilli(),
+		From:           "TPqG9VfqXycvNTaoBunqLWxUi69gnnQ6Fq",
+		To:             "TDuzLK9vBRuSdhLovyy5gCD2bGp4fjecHk",
+		Type:           "Transfer",
+		Value:          "2500000",
+	})
+
 	logger := zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).With().Timestamp().Logger()
-	api := trongrid.NewAPI(
-		trongrid.WithURI("https://api.shasta.trongrid.io"),
+	api := srv.Client(
 		trongrid.WithDebug(),
 		trongrid.WithLogger(&logger),
 		trongrid.WithToken("622ec85e-7406-431d-9caf-0a19501469a4"),
 	)
 
 	ctx := context.Background()
-	now := time.Now()
 	// TDkHqdvt6ZRnBCbhj3ytYdWTgJkE6LHNfH
 
 	modelListTransactionsRequest, err := api.ListTransactionsTrc20(ctx, &trongrid.ListTransactionsRequest{
@@ -73,6 +98,8 @@ func TestApi_ListTransactionsTrc20(t *testing.T) {
 		OnlyTo:        false,
 	})
 	require.NoError(t, err)
+	require.Len(t, modelListTransactionsRequest.Data, 1)
+	require.Equal(t, 2.5, trongrid.ParseValue(modelListTransactionsRequest.Data[0].Value, 6))
 	for i := 0; i < len(modelListTransactionsRequest.Data); i++ {
 		item := modelListTransactionsRequest.Data[i]
 		t.Logf("交易ID: %v", item)
@@ -85,21 +112,38 @@ func TestApi_ListTransactionsTrc20(t *testing.T) {
 func TestApi_ListTransactionsTrc20Main(t *testing.T) {
 	t.Parallel()
 
+	now := time.Now()
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	mainnet, err := trongrid.GetNetwork(trongrid.Mainnet)
+	require.NoError(t, err)
+	usdt, _ := mainnet.Token("USDT")
+	for i, to := range []string{"TDkHqdvt6ZRnBCbhj3ytYdWTgJkE6LHNfH", "TPqG9VfqXycvNTaoBunqLWxUi69gnnQ6Fq"} {
+		srv.AddTRC20Transfer(trongrid.TRC20Transaction{
+			TransactionID:  string(rune('a' + i)),
+			TokenInfo:      trongrid.TokenInfo{Symbol: usdt.Symbol, Address: usdt.Address, Decimals: usdt.Decimals, Name: usdt.Name},
+			BlockTimestamp: now.Add(-time.Minute).UnixMilli(),
+			From:           "TDkHqdvt6ZRnBCbhj3ytYdWTgJkE6LHNfH",
+			To:             to,
+			Type:           "Transfer",
+			Value:          "1000000",
+		})
+	}
+
 	//logger := zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).With().Timestamp().Logger()
-	api := trongrid.NewAPI(
-		trongrid.WithURI("https://api.trongrid.io"),
+	api := srv.Client(
 		//trongrid.WithDebug(),
 		//trongrid.WithLogger(&logger),
 		trongrid.WithToken("622ec85e-7406-431d-9caf-0a19501469a4"),
 	)
 
 	ctx := context.Background()
-	now := time.Now()
 	// TDkHqdvt6ZRnBCbhj3ytYdWTgJkE6LHNfH
 
 	modelListTransactionsRequest, err := api.ListTransactionsTrc20(ctx, &trongrid.ListTransactionsRequest{
-		MaxTimestamp:  now.Add(-(time.Hour * 1)),
-		MinTimestamp:  now,
+		MaxTimestamp:  now,
+		MinTimestamp:  now.Add(-(time.Hour * 1)),
 		Address:       "TDkHqdvt6ZRnBCbhj3ytYdWTgJkE6LHNfH",
 		Fingerprint:   "",
 		OrderBy:       "block_timestamp,desc",
@@ -110,6 +154,7 @@ func TestApi_ListTransactionsTrc20Main(t *testing.T) {
 	})
 	require.NoError(t, err)
 	//require.NotNil(t, modelListTransactionsRequest)
+	require.Len(t, modelListTransactionsRequest.Data, 1)
 	for i := 0; i < len(modelListTransactionsRequest.Data); i++ {
 		item := modelListTransactionsRequest.Data[i]
 		t.Logf("交易ID: %v", item)
@@ -118,3 +163,128 @@ func TestApi_ListTransactionsTrc20Main(t *testing.T) {
 
 	}
 }
+
+func TestApi_ListInternalTransactions(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	multisend := trongrid.MustParseAddress("TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf").Hex()
+	user := trongrid.MustParseAddress("TPqG9VfqXycvNTaoBunqLWxUi69gnnQ6Fq")
+	other := trongrid.MustParseAddress("TDuzLK9vBRuSdhLovyy5gCD2bGp4fjecHk")
+
+	// the history also holds the transactions of the account, which are not internal transfers
+	srv.AddTransaction(user.String(), &trongrid.Transaction{TxID: "11aa", BlockTimestamp: 1_700_000_001_000})
+	for _, itx := range []trongrid.InternalTransaction{
+		{
+			Hash:              "a1",
+			CallerAddress:     multisend,
+			TransferToAddress: user.Hex(),
+			CallValueInfo:     []trongrid.CallValueInfo{{CallValue: 3_000_000}},
+			Note:              "63616c6c",
+		},
+		{
+			Hash:              "a2",
+			CallerAddress:     multisend,
+			TransferToAddress: user.Hex(),
+			CallValueInfo:     []trongrid.CallValueInfo{{CallValue: 5_000_000}},
+			Note:              "63616c6c",
+			Rejected:          true,
+		},
+		{
+			Hash:              "a3",
+			CallerAddress:     multisend,
+			TransferToAddress: other.Hex(),
+			CallValueInfo:     []trongrid.CallValueInfo{{CallValue: 1_000_000}},
+			Note:              "63616c6c",
+		},
+	} {
+		srv.AddInternalTransfer(trongrid.InternalTransfer{
+			InternalTransaction: itx,
+			TransactionID:       "7c1e",
+			BlockTimestamp:      1_700_000_000_000,
+		})
+	}
+
+	resp, err := srv.Client().ListInternalTransactions(context.Background(), &trongrid.ListTransactionsRequest{
+		Address: user.String(),
+		OnlyTo:  true,
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, "7c1e", resp.Data[0].TransactionID)
+	assert.Equal(t, int64(1_700_000_000_000), resp.Data[0].BlockTimestamp)
+	assert.Equal(t, "a1", resp.Data[0].InternalTransaction.Hash)
+	assert.Equal(t, user.Hex(), resp.Data[0].InternalTransaction.TransferToAddress)
+	assert.Equal(t, int64(3_000_000), resp.Data[0].InternalTransaction.TRXValue())
+	assert.Equal(t, "call", resp.Data[0].InternalTransaction.NoteText())
+
+	// TronGrid would filter by the sender of the outer transaction
+	for _, r := range srv.Requests() {
+		assert.Empty(t, r.Query.Get("only_to"))
+		assert.Empty(t, r.Query.Get("only_from"))
+	}
+
+	resp, err = srv.Client().ListInternalTransactions(context.Background(), &trongrid.ListTransactionsRequest{
+		Address:  user.String(),
+		OnlyFrom: true,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, resp.Data)
+}
+
+func TestApi_ListInternalTransactions_Nested(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	user := trongrid.MustParseAddress("TPqG9VfqXycvNTaoBunqLWxUi69gnnQ6Fq")
+	tx := &trongrid.Transaction{TxID: "7c1e", BlockNumber: 42, BlockTimestamp: 1_700_000_000_000}
+	tx.InternalTransactions = []trongrid.InternalTransaction{{
+		Hash:              "a1",
+		CallerAddress:     trongrid.MustParseAddress("TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf").Hex(),
+		TransferToAddress: user.Hex(),
+		CallValueInfo:     []trongrid.CallValueInfo{{CallValue: 3_000_000}},
+	}}
+	srv.AddTransaction(user.String(), tx)
+
+	resp, err := srv.Client().ListInternalTransactions(context.Background(), &trongrid.ListTransactionsRequest{
+		Address: user.String(),
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 1)
+	assert.Equal(t, "7c1e", resp.Data[0].TransactionID)
+	assert.Equal(t, 42, resp.Data[0].BlockNumber)
+	assert.Equal(t, int64(3_000_000), resp.Data[0].InternalTransaction.TRXValue())
+}
+
+func TestInternalTransaction_UnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var wallet trongrid.InternalTransaction
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"hash": "a1",
+		"caller_address": "41f0cc5a2a84cd0f68ed1667070934542d673acbd8",
+		"transferTo_address": "4198927ffb9f554dc4a453c64b2e553a02d6df514b",
+		"callValueInfo": [{"callValue": 3000000}, {"tokenId": "1002000", "callValue": 5}],
+		"note": "63616c6c"
+	}`), &wallet))
+	assert.Equal(t, "a1", wallet.Hash)
+	assert.Equal(t, int64(3_000_000), wallet.TRXValue())
+
+	var v1 trongrid.InternalTransaction
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"internal_tx_id": "a1",
+		"from_address": "41f0cc5a2a84cd0f68ed1667070934542d673acbd8",
+		"to_address": "4198927ffb9f554dc4a453c64b2e553a02d6df514b",
+		"data": {"note": "63616c6c", "rejected": true, "call_value": {"_": 3000000}}
+	}`), &v1))
+	assert.Equal(t, wallet.Hash, v1.Hash)
+	assert.Equal(t, wallet.CallerAddress, v1.CallerAddress)
+	assert.Equal(t, wallet.TransferToAddress, v1.TransferToAddress)
+	assert.Equal(t, int64(3_000_000), v1.TRXValue())
+	assert.Equal(t, "call", v1.NoteText())
+	assert.True(t, v1.Rejected)
+}
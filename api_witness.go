@@ -0,0 +1,142 @@
+package trongrid
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// MaxVoteCount is the most witnesses an account can vote for in one VoteWitnessContract.
+const MaxVoteCount = 30
+
+// Witness is a Super Representative or candidate. VoteCount is in votes, one per staked TRX.
+type Witness struct {
+	Address        string `json:"address"`
+	VoteCount      int64  `json:"voteCount"`
+	URL            string `json:"url"`
+	TotalProduced  int64  `json:"totalProduced"`
+	TotalMissed    int64  `json:"totalMissed"`
+	LatestBlockNum int64  `json:"latestBlockNum"`
+	LatestSlotNum  int64  `json:"latestSlotNum"`
+	// IsJobs is set for the 27 Super Representatives producing blocks
+	IsJobs bool `json:"isJobs"`
+}
+
+// Vote is a number of votes for a witness.
+type Vote struct {
+	VoteAddress string `json:"vote_address"`
+	VoteCount   int64  `json:"vote_count"`
+}
+
+type VoteWitnessRequest struct {
+	OwnerAddress string `json:"owner_address"`
+	// Votes replace the previous votes of the account. Their total is limited by its TRON Power.
+	Votes        []Vote `json:"votes"`
+	PermissionID int32  `json:"Permission_id,omitempty"`
+	Visible      bool   `json:"visible"`
+}
+
+type WithdrawBalanceRequest struct {
+	OwnerAddress string `json:"owner_address"`
+	PermissionID int32  `json:"Permission_id,omitempty"`
+	Visible      bool   `json:"visible"`
+}
+
+type witnessList struct {
+	Witnesses []*Witness `json:"witnesses"`
+}
+
+// ListWitnesses returns the Super Representatives and candidates.
+// Docs: https://developers.tron.network/reference/listwitnesses
+func (api *api) ListWitnesses(ctx context.Context) (resp []*Witness, err error) {
+	var v witnessList
+	if err = api.do(ctx, &call{
+		endpoint:   "ListWitnesses",
+		method:     http.MethodPost,
+		path:       "/wallet/listwitnesses",
+		body:       &walletValueRequest{Visible: true},
+		idempotent: true,
+	}, &v); err != nil {
+		return nil, err
+	}
+
+	if len(v.Witnesses) == 0 {
+		return nil, ErrEmpty
+	}
+
+	return v.Witnesses, nil
+}
+
+// GetBrokerage returns the percentage of voting rewards a witness keeps; voters share the rest.
+// Docs: https://developers.tron.network/reference/getbrokerage
+func (api *api) GetBrokerage(ctx context.Context, address string) (int64, error) {
+	var v struct {
+		Brokerage int64 `json:"brokerage"`
+	}
+	if err := api.do(ctx, &call{
+		endpoint:   "GetBrokerage",
+		method:     http.MethodPost,
+		path:       "/wallet/getBrokerage",
+		body:       &walletValueRequest{Address: address, Visible: true},
+		idempotent: true,
+	}, &v); err != nil {
+		return 0, err
+	}
+
+	return v.Brokerage, nil
+}
+
+// GetReward returns the unclaimed voting rewards of an account, in sun.
+// Docs: https://developers.tron.network/reference/getreward
+func (api *api) GetReward(ctx context.Context, address string) (int64, error) {
+	var v struct {
+		Reward int64 `json:"reward"`
+	}
+	if err := api.do(ctx, &call{
+		endpoint:   "GetReward",
+		method:     http.MethodPost,
+		path:       "/wallet/getReward",
+		body:       &walletValueRequest{Address: address, Visible: true},
+		idempotent: true,
+	}, &v); err != nil {
+		return 0, err
+	}
+
+	return v.Reward, nil
+}
+
+// GetNextMaintenanceTime returns when the next maintenance period starts. Votes are counted
+// and rewards distributed then.
+// Docs: https://developers.tron.network/reference/getnextmaintenancetime
+func (api *api) GetNextMaintenanceTime(ctx context.Context) (time.Time, error) {
+	var v struct {
+		Num int64 `json:"num"`
+	}
+	if err := api.do(ctx, &call{
+		endpoint:   "GetNextMaintenanceTime",
+		method:     http.MethodPost,
+		path:       "/wallet/getnextmaintenancetime",
+		idempotent: true,
+	}, &v); err != nil {
+		return time.Time{}, err
+	}
+
+	if v.Num == 0 {
+		return time.Time{}, ErrEmpty
+	}
+
+	return time.UnixMilli(v.Num), nil
+}
+
+// VoteWitnessAccount builds an unsigned vote of the TRON Power of an account for witnesses.
+// Docs: https://developers.tron.network/reference/votewitnessaccount
+func (api *api) VoteWitnessAccount(ctx context.Context, req *VoteWitnessRequest) (resp *Transaction, err error) {
+	return api.build(ctx, "VoteWitnessAccount", "/wallet/votewitnessaccount", req)
+}
+
+// WithdrawBalance builds an unsigned claim of the voting rewards of an account.
+// Rewards can be claimed once every 24 hours.
+// Docs: https://developers.tron.network/reference/withdrawbalance
+func (api *api) WithdrawBalance(ctx context.Context, req *WithdrawBalanceRequest) (resp *Transaction, err error) {
+	return api.build(ctx, "WithdrawBalance", "/wallet/withdrawbalance", req)
+}
@@ -0,0 +1,93 @@
+package trongrid_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+func TestApi_ListWitnesses(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("/wallet/listwitnesses", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"witnesses": [{"address": "` + holderA + `", "voteCount": 3052374841,
+			"url": "https://example.com", "totalProduced": 1824617, "isJobs": true}]}`))
+	})
+	srv.Handle("/wallet/getnextmaintenancetime", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"num": 1700006400000}`))
+	})
+	api := srv.Client()
+
+	witnesses, err := api.ListWitnesses(context.Background())
+	require.NoError(t, err)
+	require.Len(t, witnesses, 1)
+	assert.Equal(t, int64(3_052_374_841), witnesses[0].VoteCount)
+	assert.True(t, witnesses[0].IsJobs)
+
+	next, err := api.GetNextMaintenanceTime(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, time.UnixMilli(1_700_006_400_000), next)
+}
+
+func TestApi_GetReward(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("/wallet/getReward", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"reward": 1234567}`))
+	})
+	srv.Handle("/wallet/getBrokerage", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"brokerage": 20}`))
+	})
+	api := srv.Client()
+
+	reward, err := api.GetReward(context.Background(), holderA)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_234_567), reward)
+
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(srv.RequestsTo("/wallet/getReward")[0].Body, &body))
+	assert.Equal(t, map[string]interface{}{"address": holderA, "visible": true}, body)
+
+	brokerage, err := api.GetBrokerage(context.Background(), holderB)
+	require.NoError(t, err)
+	assert.Equal(t, int64(20), brokerage)
+}
+
+func TestApi_VoteWitnessAccount(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	srv.Handle("/wallet/votewitnessaccount", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"txID": "a0b1", "raw_data": {"contract": [{"type": "VoteWitnessContract"}]}}`))
+	})
+
+	tx, err := srv.Client().VoteWitnessAccount(context.Background(), &trongrid.VoteWitnessRequest{
+		OwnerAddress: holderA,
+		Votes:        []trongrid.Vote{{VoteAddress: holderB, VoteCount: 100}},
+		Visible:      true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, trongrid.ContractTypeVote, tx.RawData.Contract[0].Type)
+
+	var body struct {
+		Votes []trongrid.Vote `json:"votes"`
+	}
+	require.NoError(t, json.Unmarshal(srv.RequestsTo("/wallet/votewitnessaccount")[0].Body, &body))
+	assert.Equal(t, []trongrid.Vote{{VoteAddress: holderB, VoteCount: 100}}, body.Votes)
+}
@@ -0,0 +1,102 @@
+package trongrid
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Cache stores raw response bodies of immutable or slow-changing data.
+// Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored under key, if present and not expired.
+	Get(key string) ([]byte, bool)
+	// Set stores value under key; a non-positive ttl never expires.
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+const (
+	// cacheTTLImmutable is used for confirmed transactions and solidified blocks
+	cacheTTLImmutable = 24 * time.Hour
+	// cacheTTLSlow is used for contract ABIs, token metadata and chain parameters
+	cacheTTLSlow = 10 * time.Minute
+)
+
+type memoryCache struct {
+	mu    sync.Mutex
+	size  int
+	ll    *list.List
+	items map[string]*list.Element
+	now   func() time.Time
+}
+
+type memoryCacheEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewMemoryCache returns an in-process LRU cache holding at most size entries.
+func NewMemoryCache(size int) Cache {
+	if size <= 0 {
+		size = 1
+	}
+
+	return &memoryCache{
+		size:  size,
+		ll:    list.New(),
+		items: make(map[string]*list.Element, size),
+		now:   time.Now,
+	}
+}
+
+func (c *memoryCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry, _ := el.Value.(*memoryCacheEntry)
+	if !entry.expires.IsZero() && c.now().After(entry.expires) {
+		c.ll.Remove(el)
+		delete(c.items, key)
+
+		return nil, false
+	}
+
+	c.ll.MoveToFront(el)
+
+	return entry.value, true
+}
+
+func (c *memoryCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var expires time.Time
+	if ttl > 0 {
+		expires = c.now().Add(ttl)
+	}
+
+	if el, ok := c.items[key]; ok {
+		entry, _ := el.Value.(*memoryCacheEntry)
+		entry.value = value
+		entry.expires = expires
+		c.ll.MoveToFront(el)
+
+		return
+	}
+
+	c.items[key] = c.ll.PushFront(&memoryCacheEntry{key: key, value: value, expires: expires})
+
+	for c.ll.Len() > c.size {
+		el := c.ll.Back()
+		c.ll.Remove(el)
+		if entry, ok := el.Value.(*memoryCacheEntry); ok {
+			delete(c.items, entry.key)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package trongrid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryCache(t *testing.T) {
+	now := time.Date(2024, 2, 24, 12, 0, 0, 0, time.UTC)
+	c, ok := NewMemoryCache(2).(*memoryCache)
+	require.True(t, ok)
+	c.now = func() time.Time { return now }
+
+	c.Set("a", []byte("1"), 0)
+	c.Set("b", []byte("2"), time.Minute)
+
+	v, ok := c.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, []byte("1"), v)
+
+	// "b" is the least recently used entry and gets evicted.
+	c.Set("c", []byte("3"), 0)
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+
+	c.Set("d", []byte("4"), time.Minute)
+	now = now.Add(2 * time.Minute)
+	_, ok = c.Get("d")
+	assert.False(t, ok)
+
+	_, ok = c.Get("c")
+	assert.True(t, ok)
+}
+
+func TestCacheOnlyConfirmed(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		_, _ = w.Write([]byte(`{"txID":"abc","raw_data":{}}`))
+	}))
+	defer srv.Close()
+
+	x := NewAPI(WithURI(srv.URL), WithRateLimiter(nil), WithCache(NewMemoryCache(10)))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		tx, err := x.GetTransactionByID(ctx, &GetTransactionRequest{ID: "abc"})
+		require.NoError(t, err)
+		assert.Equal(t, "abc", tx.TxID)
+	}
+	assert.EqualValues(t, 2, atomic.LoadInt32(&hits))
+
+	for i := 0; i < 2; i++ {
+		tx, err := x.GetTransactionByID(ctx, &GetTransactionRequest{ID: "abc", OnlyConfirmed: true})
+		require.NoError(t, err)
+		assert.Equal(t, "abc", tx.TxID)
+	}
+	assert.EqualValues(t, 3, atomic.LoadInt32(&hits))
+
+	// The same data on another network is a different entry.
+	other, ok := x.(*api)
+	require.True(t, ok)
+	c := &call{method: http.MethodPost, path: "/walletsolidity/gettransactionbyid", body: &GetTransactionRequest{ID: "abc"}}
+	key := other.cacheKey(c)
+	other.network = &Network{Name: Nile}
+	assert.NotEqual(t, key, other.cacheKey(c))
+}
@@ -0,0 +1,106 @@
+package trongrid
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// flightGroup coalesces identical in-flight requests.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+type flight struct {
+	done    chan struct{}
+	body    []byte
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// do calls fn once for all concurrent callers with the same key. fn runs with a context
+// that keeps the values of the first caller but is only canceled once every caller has
+// given up, so one caller going away does not abort the request for the others.
+//
+// Callers joining a flight inherit the context values of the first caller, including the
+// retry policy and rate limiter of ContextWithRetryPolicy and ContextWithRateLimiter, and fn
+// runs without their deadlines; a caller still stops waiting when its own context is done.
+func (g *flightGroup) do(
+	ctx context.Context,
+	key string,
+	fn func(ctx context.Context) ([]byte, error),
+) ([]byte, error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+
+	f, ok := g.flights[key]
+	if ok {
+		f.waiters++
+	} else {
+		shared, cancel := context.WithCancel(detach(ctx))
+		f = &flight{done: make(chan struct{}), waiters: 1, cancel: cancel}
+		g.flights[key] = f
+
+		go func() {
+			f.body, f.err = fn(shared)
+
+			g.mu.Lock()
+			// the flight may have been abandoned and replaced while fn was returning
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+			g.mu.Unlock()
+
+			cancel()
+			close(f.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-f.done:
+		return f.body, f.err
+	case <-ctx.Done():
+		g.mu.Lock()
+		f.waiters--
+		if f.waiters == 0 {
+			f.cancel()
+			// later callers must start a new flight rather than join a canceled one
+			if g.flights[key] == f {
+				delete(g.flights, key)
+			}
+		}
+		g.mu.Unlock()
+
+		return nil, ctx.Err()
+	}
+}
+
+// detachedContext keeps the values of its parent but not its deadline or cancellation.
+type detachedContext struct {
+	parent context.Context
+}
+
+func detach(ctx context.Context) context.Context {
+	return detachedContext{parent: ctx}
+}
+
+func (detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.parent.Value(key)
+}
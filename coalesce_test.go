@@ -0,0 +1,229 @@
+package trongrid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlightGroupShares(t *testing.T) {
+	var g flightGroup
+	var calls int32
+	release := make(chan struct{})
+
+	fn := func(ctx context.Context) ([]byte, error) {
+		atomic.AddInt32(&calls, 1)
+		<-release
+
+		return []byte("ok"), nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			body, err := g.do(context.Background(), "key", fn)
+			assert.NoError(t, err)
+			assert.Equal(t, []byte("ok"), body)
+		}()
+	}
+
+	require.Eventually(t, func() bool { return g.waiters("key") == 10 }, time.Second, time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, atomic.LoadInt32(&calls))
+}
+
+func TestFlightGroupCancellation(t *testing.T) {
+	var g flightGroup
+	started := make(chan struct{})
+	release := make(chan struct{})
+	sharedCtx := make(chan context.Context, 1)
+
+	fn := func(ctx context.Context) ([]byte, error) {
+		sharedCtx <- ctx
+		close(started)
+		select {
+		case <-release:
+			return []byte("ok"), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := g.do(first, "key", fn)
+		firstErr <- err
+	}()
+	<-started
+
+	second := make(chan []byte, 1)
+	go func() {
+		body, err := g.do(context.Background(), "key", fn)
+		assert.NoError(t, err)
+		second <- body
+	}()
+
+	require.Eventually(t, func() bool { return g.waiters("key") == 2 }, time.Second, time.Millisecond)
+
+	// The first caller giving up must not abort the request for the second one.
+	cancelFirst()
+	require.ErrorIs(t, <-firstErr, context.Canceled)
+	ctx := <-sharedCtx
+	require.NoError(t, ctx.Err())
+
+	close(release)
+	assert.Equal(t, []byte("ok"), <-second)
+}
+
+func TestFlightGroupAllCallersGone(t *testing.T) {
+	var g flightGroup
+	done := make(chan error, 1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		_, _ = g.do(ctx, "key", func(ctx context.Context) ([]byte, error) {
+			<-ctx.Done()
+			done <- ctx.Err()
+
+			return nil, ctx.Err()
+		})
+	}()
+
+	require.Eventually(t, func() bool { return g.waiters("key") == 1 }, time.Second, time.Millisecond)
+	cancel()
+
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestFlightGroupNewCallerAfterAllGone(t *testing.T) {
+	var g flightGroup
+	var calls int32
+	canceled := make(chan struct{})
+	releaseFirst := make(chan struct{})
+	releaseSecond := make(chan struct{})
+
+	fn := func(ctx context.Context) ([]byte, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// the abandoned flight is still returning when the next caller arrives
+			<-ctx.Done()
+			close(canceled)
+			<-releaseFirst
+
+			return nil, ctx.Err()
+		}
+		<-releaseSecond
+
+		return []byte("ok"), nil
+	}
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	go func() {
+		_, _ = g.do(first, "key", fn)
+	}()
+	require.Eventually(t, func() bool { return g.waiters("key") == 1 }, time.Second, time.Millisecond)
+	cancelFirst()
+	<-canceled
+
+	second := make(chan error, 1)
+	go func() {
+		body, err := g.do(context.Background(), "key", fn)
+		assert.Equal(t, []byte("ok"), body)
+		second <- err
+	}()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 2 }, time.Second, time.Millisecond)
+
+	// the first flight finishing must not forget the second one
+	close(releaseFirst)
+	require.Eventually(t, func() bool { return g.waiters("key") == 1 }, time.Second, time.Millisecond)
+
+	close(releaseSecond)
+	require.NoError(t, <-second)
+}
+
+func TestBuildersNotCoalesced(t *testing.T) {
+	builders := map[string]func(ctx context.Context, x API) error{
+		"/wallet/createtransaction": func(ctx context.Context, x API) error {
+			_, err := x.CreateTransaction(ctx, &CreateTransactionRequest{
+				OwnerAddress: "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8", ToAddress: "TPL66VK2gCXNCD7EJg9pgJRfqcRazjhUZY", Amount: 1,
+			})
+
+			return err
+		},
+		"/wallet/triggersmartcontract": func(ctx context.Context, x API) error {
+			_, err := x.TriggerSmartContract(ctx, &TriggerSmartContractRequest{
+				OwnerAddress: "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8", ContractAddress: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t",
+			})
+
+			return err
+		},
+		"/wallet/transferasset": func(ctx context.Context, x API) error {
+			_, err := x.TransferAsset(ctx, &TransferAssetRequest{
+				OwnerAddress: "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8", ToAddress: "TPL66VK2gCXNCD7EJg9pgJRfqcRazjhUZY",
+				AssetName: "1002000", Amount: 1,
+			})
+
+			return err
+		},
+		"/wallet/freezebalancev2": func(ctx context.Context, x API) error {
+			_, err := x.FreezeBalanceV2(ctx, &FreezeBalanceV2Request{
+				OwnerAddress: "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8", FrozenBalance: 1_000_000,
+			})
+
+			return err
+		},
+	}
+
+	for path, build := range builders {
+		// each request waits until the other arrived, so coalesced calls would time out
+		var hits int32
+		both := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, path, r.URL.Path)
+			if atomic.AddInt32(&hits, 1) == 2 {
+				close(both)
+			}
+			select {
+			case <-both:
+			case <-time.After(time.Second):
+			}
+			_, _ = w.Write([]byte(`{"txID":"abc","raw_data":{},"result":{"result":true},"transaction":{"txID":"abc"}}`))
+		}))
+
+		x := NewAPI(WithURI(srv.URL), WithRateLimiter(nil))
+		var wg sync.WaitGroup
+		for i := 0; i < 2; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, build(context.Background(), x))
+			}()
+		}
+		wg.Wait()
+		srv.Close()
+
+		assert.EqualValues(t, 2, atomic.LoadInt32(&hits), path)
+	}
+}
+
+func (g *flightGroup) waiters(key string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if f, ok := g.flights[key]; ok {
+		return f.waiters
+	}
+
+	return 0
+}
@@ -33,11 +33,31 @@ const (
 	// ContractTypeAccountUpdate represents account update contract
 	ContractTypeAccountUpdate = "AccountUpdateContract"
 	// ContractTypeFreeze represents TRX freeze contract
+	//
+	// Deprecated: Stake 1.0 was replaced by ContractTypeFreezeV2.
 	ContractTypeFreeze = "FreezeBalanceContract"
 	// ContractTypeUnfreeze represents TRX unfreeze contract
+	//
+	// Deprecated: Stake 1.0 was replaced by ContractTypeUnfreezeV2.
 	ContractTypeUnfreeze = "UnfreezeBalanceContract"
+	// ContractTypeFreezeV2 represents Stake 2.0 TRX freeze contract
+	ContractTypeFreezeV2 = "FreezeBalanceV2Contract"
+	// ContractTypeUnfreezeV2 represents Stake 2.0 TRX unfreeze contract
+	ContractTypeUnfreezeV2 = "UnfreezeBalanceV2Contract"
+	// ContractTypeWithdrawExpireUnfreeze represents withdrawal of unfrozen TRX after the waiting period
+	ContractTypeWithdrawExpireUnfreeze = "WithdrawExpireUnfreezeContract"
+	// ContractTypeCancelAllUnfreezeV2 represents cancellation of all pending unfreezes
+	ContractTypeCancelAllUnfreezeV2 = "CancelAllUnfreezeV2Contract"
+	// ContractTypeDelegateResource represents delegation of staked resources to another account
+	ContractTypeDelegateResource = "DelegateResourceContract"
+	// ContractTypeUndelegateResource represents reclaiming delegated resources
+	ContractTypeUndelegateResource = "UnDelegateResourceContract"
 	// ContractTypeVote represents vote contract
 	ContractTypeVote = "VoteWitnessContract"
+	// ContractTypeWithdrawBalance represents a claim of voting rewards
+	ContractTypeWithdrawBalance = "WithdrawBalanceContract"
+	// ContractTypeAccountPermissionUpdate represents an update of the permissions of an account
+	ContractTypeAccountPermissionUpdate = "AccountPermissionUpdateContract"
 )
 
 // Transaction status
@@ -92,6 +112,18 @@ const (
 	ResourceEnergy = "ENERGY"
 	// ResourceTron represents TRON resource
 	ResourceTron = "TRON"
+	// ResourceTronPower represents TRON power, i.e. votes
+	ResourceTronPower = "TRON_POWER"
+)
+
+// Stake 2.0 constants
+const (
+	// UnfreezeDelay is how long unfrozen TRX waits before it can be withdrawn
+	UnfreezeDelay = 14 * 24 * time.Hour
+	// MaxUnfreezingCount is the maximum number of pending unfreezes per account
+	MaxUnfreezingCount = 32
+	// BlocksPerLockDay is the number of 3 second blocks in a day, the unit of DelegateResource lock periods
+	BlocksPerLockDay = 28_800
 )
 
 const layout = "2006-01-02T15:04:05"
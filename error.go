@@ -14,6 +14,9 @@ var (
 	ErrUnauthorized      = errors.New("unauthorized API access")
 	ErrNetworkError      = errors.New("network communication error")
 	ErrServerError       = errors.New("trongrid server error")
+	ErrBroadcastFailed   = errors.New("transaction broadcast rejected")
+	ErrInvalidToken      = errors.New("invalid trc20 token")
+	ErrTokenNotAllowed   = errors.New("token is not allowed")
 
 	// Validation errors
 	ErrMissingAddress   = errors.New("address is required")
@@ -0,0 +1,203 @@
+package trongrid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// DefaultEnergyMargin is the headroom on estimated energy in a recommended fee limit.
+const DefaultEnergyMargin = 1.2
+
+// EstimatorBackend is what a FeeEstimator reads: a client, a Chain or a Mock.
+type EstimatorBackend interface {
+	AccountReader
+	ContractCaller
+	ChainReader
+}
+
+// FeeEstimatorOption configures a FeeEstimator.
+type FeeEstimatorOption func(*FeeEstimator)
+
+// WithEnergyMargin sets the factor applied to estimated energy for the recommended fee limit.
+func WithEnergyMargin(margin float64) FeeEstimatorOption {
+	return func(e *FeeEstimator) {
+		e.margin = margin
+	}
+}
+
+// WithSignatureCount sets the number of signatures transactions will carry, e.g. for multisig accounts.
+func WithSignatureCount(n int) FeeEstimatorOption {
+	return func(e *FeeEstimator) {
+		e.signatures = n
+	}
+}
+
+// FeeEstimator estimates the TRX an unsigned transaction will burn, taking the resources
+// of its sender, the current prices and the activation of new accounts into account.
+type FeeEstimator struct {
+	backend    EstimatorBackend
+	margin     float64
+	signatures int
+}
+
+// FeeEstimate is the expected cost of a transaction. Amounts are in sun.
+type FeeEstimate struct {
+	// Bandwidth is the size of the signed transaction, in bytes
+	Bandwidth int64
+	// Energy is the estimated energy of a smart contract call
+	Energy int64
+	// NewAccount is set when the transaction activates its recipient
+	NewAccount bool
+
+	BandwidthBurn int64
+	EnergyBurn    int64
+	// ActivationFee is charged when NewAccount is set
+	ActivationFee int64
+	// MultiSignFee is charged when the transaction carries more than one signature
+	MultiSignFee int64
+	// MemoFee is charged when the transaction has a memo in raw_data.data
+	MemoFee int64
+	// Burn is the total TRX the transaction is expected to burn
+	Burn int64
+	// FeeLimit is the recommended fee_limit of a smart contract call: the estimated energy
+	// with a margin, priced as if none of it was covered by staked energy
+	FeeLimit int64
+
+	EnergyPrice    int64
+	BandwidthPrice int64
+}
+
+// NewFeeEstimator returns an estimator reading from backend.
+func NewFeeEstimator(backend EstimatorBackend, opts ...FeeEstimatorOption) *FeeEstimator {
+	e := &FeeEstimator{
+		backend:    backend,
+		margin:     DefaultEnergyMargin,
+		signatures: 1,
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
+
+// Estimate returns the expected cost of the unsigned transaction tx. resources are those of its sender;
+// when nil they are fetched.
+func (e *FeeEstimator) Estimate(ctx context.Context, tx *Transaction, resources *ResourceView) (*FeeEstimate, error) {
+	if len(tx.RawData.Contract) == 0 {
+		return nil, fmt.Errorf("%w: transaction has no contract", ErrInvalidRequest)
+	}
+	value := &tx.RawData.Contract[0].Parameter.Value
+
+	params, err := e.backend.GetChainParameters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	energyPrice, bandwidthPrice := params.EnergyFee, params.TransactionFee
+
+	size, err := transactionSize(tx, e.signatures)
+	if err != nil {
+		return nil, err
+	}
+
+	if resources == nil {
+		if resources, err = e.resources(ctx, value.OwnerAddress); err != nil {
+			return nil, err
+		}
+	}
+
+	estimate := &FeeEstimate{Bandwidth: size, EnergyPrice: energyPrice, BandwidthPrice: bandwidthPrice}
+	switch tx.RawData.Contract[0].Type {
+	case ContractTypeTRX, ContractTypeTRC10:
+		if estimate.NewAccount, err = e.isNew(ctx, value.ToAddress); err != nil {
+			return nil, err
+		}
+	case ContractTypeTRC20:
+		if estimate.Energy, err = e.energy(ctx, value); err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case estimate.NewAccount:
+		// free bandwidth cannot pay for an activation
+		if resources.StakedBandwidth() < size {
+			estimate.BandwidthBurn = params.CreateAccountFee
+		}
+		estimate.ActivationFee = params.CreateNewAccountFeeInSystemContract
+	case !resources.CoversBandwidth(size):
+		estimate.BandwidthBurn = size * bandwidthPrice
+	}
+
+	if estimate.Energy > resources.Energy() {
+		estimate.EnergyBurn = (estimate.Energy - resources.Energy()) * energyPrice
+	}
+	if estimate.Energy > 0 {
+		estimate.FeeLimit = int64(math.Ceil(float64(estimate.Energy)*e.margin)) * energyPrice
+	}
+
+	if e.signatures > 1 {
+		estimate.MultiSignFee = params.MultiSignFee
+	}
+	if tx.RawData.Data != "" {
+		estimate.MemoFee = params.MemoFee
+	}
+
+	estimate.Burn = estimate.BandwidthBurn + estimate.EnergyBurn + estimate.ActivationFee +
+		estimate.MultiSignFee + estimate.MemoFee
+
+	return estimate, nil
+}
+
+// resources returns the resources of address now; an account that does not exist has none.
+func (e *FeeEstimator) resources(ctx context.Context, address string) (*ResourceView, error) {
+	res, err := e.backend.GetAccountResource(ctx, address)
+	if errors.Is(err, ErrEmpty) {
+		return &ResourceView{At: time.Now()}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return NewResourceView(res, nil, time.Now()), nil
+}
+
+func (e *FeeEstimator) isNew(ctx context.Context, address string) (bool, error) {
+	_, err := e.backend.GetAccount(ctx, &GetAccountRequest{Address: address})
+	if errors.Is(err, ErrEmpty) {
+		return true, nil
+	}
+
+	return false, err
+}
+
+// energy estimates a smart contract call with /wallet/estimateenergy, falling back to
+// triggerconstantcontract on nodes where it is disabled.
+func (e *FeeEstimator) energy(ctx context.Context, value *ContractValue) (int64, error) {
+	req := &TriggerSmartContractRequest{
+		OwnerAddress:    value.OwnerAddress,
+		ContractAddress: value.ContractAddress,
+		Data:            value.Data,
+		CallValue:       value.CallValue,
+		Visible:         strings.HasPrefix(value.OwnerAddress, "T"),
+	}
+
+	energy, err := e.backend.EstimateEnergy(ctx, req)
+	if err == nil {
+		return energy, nil
+	}
+	if !errors.Is(err, ErrInvalidRequest) && !errors.Is(err, ErrEmpty) {
+		return 0, err
+	}
+
+	resp, err := e.backend.TriggerConstantContract(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.EnergyUsed, nil
+}
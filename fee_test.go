@@ -0,0 +1,126 @@
+package trongrid_test
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+func TestFeeEstimator_TRX(t *testing.T) {
+	ctx := context.Background()
+	chain := trongridtest.NewChain()
+
+	sender, err := trongrid.GenerateKey()
+	require.NoError(t, err)
+	recipient, err := trongrid.GenerateKey()
+	require.NoError(t, err)
+	require.NoError(t, chain.Fund(sender.Address().String(), 100_000_000))
+
+	tx, err := chain.CreateTransaction(ctx, &trongrid.CreateTransactionRequest{
+		OwnerAddress: sender.Address().String(),
+		ToAddress:    recipient.Address().String(),
+		Amount:       1_000_000,
+		Visible:      true,
+	})
+	require.NoError(t, err)
+
+	estimator := trongrid.NewFeeEstimator(chain)
+
+	// activating the recipient burns the account creation fee instead of free bandwidth
+	estimate, err := estimator.Estimate(ctx, tx, nil)
+	require.NoError(t, err)
+	assert.True(t, estimate.NewAccount)
+	assert.Positive(t, estimate.Bandwidth)
+	assert.Equal(t, int64(100_000), estimate.BandwidthBurn)
+	assert.Equal(t, int64(1_000_000), estimate.ActivationFee)
+	assert.Equal(t, int64(1_100_000), estimate.Burn)
+	assert.Zero(t, estimate.FeeLimit)
+
+	// free bandwidth covers a transfer to an existing account
+	require.NoError(t, chain.Fund(recipient.Address().String(), 1))
+	estimate, err = estimator.Estimate(ctx, tx, nil)
+	require.NoError(t, err)
+	assert.False(t, estimate.NewAccount)
+	assert.Zero(t, estimate.Burn)
+
+	// without bandwidth every byte is burned
+	estimate, err = estimator.Estimate(ctx, tx, &trongrid.ResourceView{})
+	require.NoError(t, err)
+	assert.Equal(t, estimate.Bandwidth*1_000, estimate.Burn)
+
+	// every signature adds to the size
+	multisig, err := trongrid.NewFeeEstimator(chain, trongrid.WithSignatureCount(2)).Estimate(ctx, tx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, estimate.Bandwidth+67, multisig.Bandwidth)
+	assert.Equal(t, int64(1_000_000), multisig.MultiSignFee)
+	assert.Equal(t, int64(1_000_000), multisig.Burn)
+	assert.Zero(t, estimate.MultiSignFee)
+
+	// a memo is charged on top of its bandwidth
+	memo := *tx
+	memo.RawData.Data = hex.EncodeToString([]byte("invoice 42"))
+	estimate, err = estimator.Estimate(ctx, &memo, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_000_000), estimate.MemoFee)
+	assert.Equal(t, int64(1_000_000), estimate.Burn)
+}
+
+func TestFeeEstimator_TRC20(t *testing.T) {
+	ctx := context.Background()
+	chain := trongridtest.NewChain()
+
+	sender, err := trongrid.GenerateKey()
+	require.NoError(t, err)
+	recipient, err := trongrid.GenerateKey()
+	require.NoError(t, err)
+	require.NoError(t, chain.Fund(sender.Address().String(), 100_000_000))
+	usdt, err := chain.DeployTRC20(trongrid.Token{Symbol: "USDT", Decimals: 6})
+	require.NoError(t, err)
+	require.NoError(t, chain.Mint(usdt, sender.Address().String(), big.NewInt(5_000_000)))
+
+	parameter, err := trongrid.EncodeTRC20Transfer(recipient.Address().String(), big.NewInt(1_000_000))
+	require.NoError(t, err)
+	resp, err := chain.TriggerSmartContract(ctx, &trongrid.TriggerSmartContractRequest{
+		OwnerAddress:     sender.Address().String(),
+		ContractAddress:  usdt,
+		FunctionSelector: trongrid.TRC20TransferSelector,
+		Parameter:        parameter,
+		FeeLimit:         100_000_000,
+		Visible:          true,
+	})
+	require.NoError(t, err)
+
+	// the endpoint is disabled on the node: the estimator falls back to a constant call
+	mock := &trongridtest.Mock{
+		Fallback: chain,
+		EstimateEnergyFunc: trongridtest.Returns[*trongrid.TriggerSmartContractRequest](int64(0),
+			trongrid.NewAPIError(http.StatusOK, "this node does not support estimate energy", trongrid.ErrInvalidRequest)),
+	}
+
+	for name, backend := range map[string]trongrid.EstimatorBackend{"estimateenergy": chain, "fallback": mock} {
+		t.Run(name, func(t *testing.T) {
+			estimate, err := trongrid.NewFeeEstimator(backend).Estimate(ctx, resp.Transaction, nil)
+			require.NoError(t, err)
+			assert.Equal(t, int64(29_650), estimate.Energy)
+			assert.Equal(t, int64(29_650*420), estimate.EnergyBurn)
+			assert.Equal(t, int64(35_580*420), estimate.FeeLimit)
+			assert.Equal(t, estimate.EnergyBurn, estimate.Burn)
+		})
+	}
+	mock.AssertCalled(t, "TriggerConstantContract", 1)
+
+	// staked energy covers part of the call
+	estimate, err := trongrid.NewFeeEstimator(chain, trongrid.WithEnergyMargin(1)).
+		Estimate(ctx, resp.Transaction, &trongrid.ResourceView{EnergyLimit: 20_000, FreeBandwidthLimit: 600})
+	require.NoError(t, err)
+	assert.Equal(t, int64(9_650*420), estimate.Burn)
+	assert.Equal(t, int64(29_650*420), estimate.FeeLimit)
+}
@@ -3,10 +3,12 @@ module github.com/eliohn/go-trongrid
 go 1.20
 
 require (
+	github.com/decred/dcrd/dcrec/secp256k1/v4 v4.2.0
 	github.com/go-resty/resty/v2 v2.16.2
 	github.com/gorilla/schema v1.4.1
 	github.com/rs/zerolog v1.33.0
 	github.com/stretchr/testify v1.8.4
+	golang.org/x/crypto v0.25.0
 	golang.org/x/time v0.6.0
 )
 
@@ -0,0 +1,350 @@
+package trongrid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"golang.org/x/crypto/ripemd160" //nolint:staticcheck // BIP32 fingerprints are defined with RIPEMD-160
+)
+
+const (
+	// HardenedKeyStart is the first hardened child index, written with ' in derivation paths
+	HardenedKeyStart = 0x80000000
+	// TRONCoinType is the SLIP-44 coin type of TRON, the second level of TRON derivation paths
+	TRONCoinType = 195
+)
+
+// ErrInvalidExtendedKey is returned for malformed extended keys and derivation paths.
+var ErrInvalidExtendedKey = errors.New("invalid extended key")
+
+// errInvalidChild is returned for the rare child indexes without a valid key; BIP32 skips to the next index.
+var errInvalidChild = fmt.Errorf("%w: index does not produce a valid key, use the next one", ErrInvalidExtendedKey)
+
+var (
+	// xprvVersion and xpubVersion are the version bytes of serialized mainnet extended keys
+	xprvVersion = [4]byte{0x04, 0x88, 0xad, 0xe4}
+	xpubVersion = [4]byte{0x04, 0x88, 0xb2, 0x1e}
+)
+
+// serializedKeyLength is the length of a serialized extended key without its checksum.
+const serializedKeyLength = 78
+
+// ExtendedKey is a BIP32 hierarchical deterministic key. A private extended key derives the keys
+// of its descendants; a public one, e.g. an account xpub on a watch-only server, derives their addresses
+// through non-hardened indexes only.
+type ExtendedKey struct {
+	// key is a 32 byte private key or a 33 byte compressed public key
+	key       []byte
+	chainCode []byte
+	depth     uint8
+	parentFP  [4]byte
+	index     uint32
+}
+
+// NewMasterKey returns the master key of a 16 to 64 byte seed, e.g. from MnemonicToSeed.
+func NewMasterKey(seed []byte) (*ExtendedKey, error) {
+	if len(seed) < 16 || len(seed) > 64 {
+		return nil, fmt.Errorf("%w: seed must be 16 to 64 bytes", ErrInvalidExtendedKey)
+	}
+
+	mac := hmac.New(sha512.New, []byte("Bitcoin seed"))
+	_, _ = mac.Write(seed)
+	sum := mac.Sum(nil)
+	defer zero(sum)
+
+	var k secp256k1.ModNScalar
+	if overflow := k.SetByteSlice(sum[:32]); overflow || k.IsZero() {
+		return nil, fmt.Errorf("%w: seed does not produce a valid key", ErrInvalidExtendedKey)
+	}
+	k.Zero()
+
+	return &ExtendedKey{
+		key:       append([]byte(nil), sum[:32]...),
+		chainCode: append([]byte(nil), sum[32:]...),
+	}, nil
+}
+
+// ParseExtendedKey parses a serialized mainnet xprv or xpub.
+func ParseExtendedKey(s string) (*ExtendedKey, error) {
+	b, err := decodeBase58Check(s)
+	if err != nil || len(b) != serializedKeyLength {
+		return nil, fmt.Errorf("%w: not a serialized extended key", ErrInvalidExtendedKey)
+	}
+
+	k := &ExtendedKey{
+		chainCode: append([]byte(nil), b[13:45]...),
+		depth:     b[4],
+		index:     binary.BigEndian.Uint32(b[9:13]),
+	}
+	copy(k.parentFP[:], b[5:9])
+
+	switch version := [4]byte(b[:4]); {
+	case version == xprvVersion && b[45] == 0:
+		var scalar secp256k1.ModNScalar
+		if overflow := scalar.SetByteSlice(b[46:]); overflow || scalar.IsZero() {
+			return nil, fmt.Errorf("%w: private key out of range", ErrInvalidExtendedKey)
+		}
+		k.key = append([]byte(nil), b[46:]...)
+	case version == xpubVersion:
+		if _, err = secp256k1.ParsePubKey(b[45:]); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidExtendedKey, err)
+		}
+		k.key = append([]byte(nil), b[45:]...)
+	default:
+		return nil, fmt.Errorf("%w: unsupported version", ErrInvalidExtendedKey)
+	}
+	zero(b)
+
+	return k, nil
+}
+
+// String returns the key serialized as an xprv or an xpub.
+func (k *ExtendedKey) String() string {
+	b := make([]byte, 0, serializedKeyLength)
+	if k.IsPrivate() {
+		b = append(b, xprvVersion[:]...)
+	} else {
+		b = append(b, xpubVersion[:]...)
+	}
+	b = append(b, k.depth)
+	b = append(b, k.parentFP[:]...)
+	b = binary.BigEndian.AppendUint32(b, k.index)
+	b = append(b, k.chainCode...)
+	if k.IsPrivate() {
+		b = append(b, 0)
+	}
+	b = append(b, k.key...)
+	defer zero(b)
+
+	return encodeBase58Check(b)
+}
+
+// IsPrivate reports whether k can derive private keys.
+func (k *ExtendedKey) IsPrivate() bool {
+	return len(k.key) == 32
+}
+
+// Depth returns the number of derivations from the master key.
+func (k *ExtendedKey) Depth() uint8 {
+	return k.depth
+}
+
+// Child returns the child key at index; indexes from HardenedKeyStart are hardened
+// and need a private key.
+func (k *ExtendedKey) Child(index uint32) (*ExtendedKey, error) {
+	hardened := index >= HardenedKeyStart
+	switch {
+	case hardened && !k.IsPrivate():
+		return nil, fmt.Errorf("%w: hardened derivation needs a private key", ErrInvalidExtendedKey)
+	case k.depth == 255:
+		return nil, fmt.Errorf("%w: maximum depth reached", ErrInvalidExtendedKey)
+	}
+
+	pub := k.publicKeyBytes()
+	data := make([]byte, 0, 37)
+	if hardened {
+		data = append(append(data, 0), k.key...)
+	} else {
+		data = append(data, pub...)
+	}
+	data = binary.BigEndian.AppendUint32(data, index)
+	defer zero(data)
+
+	mac := hmac.New(sha512.New, k.chainCode)
+	_, _ = mac.Write(data)
+	sum := mac.Sum(nil)
+	defer zero(sum)
+
+	var il secp256k1.ModNScalar
+	if overflow := il.SetByteSlice(sum[:32]); overflow {
+		return nil, errInvalidChild
+	}
+	defer il.Zero()
+
+	child := &ExtendedKey{
+		chainCode: append([]byte(nil), sum[32:]...),
+		depth:     k.depth + 1,
+		index:     index,
+	}
+	fp := hash160(pub)
+	copy(child.parentFP[:], fp[:4])
+
+	if k.IsPrivate() {
+		var parent secp256k1.ModNScalar
+		parent.SetByteSlice(k.key)
+		parent.Add(&il)
+		defer parent.Zero()
+		if parent.IsZero() {
+			return nil, errInvalidChild
+		}
+		key := parent.Bytes()
+		child.key = append([]byte(nil), key[:]...)
+		zero(key[:])
+
+		return child, nil
+	}
+
+	parent, err := secp256k1.ParsePubKey(k.key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidExtendedKey, err)
+	}
+	var point, parentPoint, sumPoint secp256k1.JacobianPoint
+	secp256k1.ScalarBaseMultNonConst(&il, &point)
+	parent.AsJacobian(&parentPoint)
+	secp256k1.AddNonConst(&point, &parentPoint, &sumPoint)
+	if (sumPoint.X.IsZero() && sumPoint.Y.IsZero()) || sumPoint.Z.IsZero() {
+		return nil, errInvalidChild
+	}
+	sumPoint.ToAffine()
+	child.key = secp256k1.NewPublicKey(&sumPoint.X, &sumPoint.Y).SerializeCompressed()
+
+	return child, nil
+}
+
+// Derive follows path from k, e.g. "m/44'/195'/0'/0/3" from a master key or "0/3" from an account key.
+// Hardened indexes are marked with ' or h.
+func (k *ExtendedKey) Derive(path string) (*ExtendedKey, error) {
+	segments := strings.Split(strings.TrimSpace(path), "/")
+	if segments[0] == "m" {
+		if k.depth != 0 {
+			return nil, fmt.Errorf("%w: path %s starts at the master key", ErrInvalidExtendedKey, path)
+		}
+		segments = segments[1:]
+	}
+
+	key := k
+	for _, s := range segments {
+		var offset uint32
+		if n := len(s); n > 0 && strings.ContainsRune("'hH", rune(s[n-1])) {
+			offset, s = HardenedKeyStart, s[:n-1]
+		}
+		index, err := strconv.ParseUint(s, 10, 32)
+		if err != nil || index >= HardenedKeyStart {
+			return nil, fmt.Errorf("%w: path %s", ErrInvalidExtendedKey, path)
+		}
+
+		child, err := key.Child(uint32(index) + offset)
+		if key != k {
+			key.Zero()
+		}
+		if err != nil {
+			return nil, err
+		}
+		key = child
+	}
+
+	if key == k {
+		return &ExtendedKey{
+			key:       append([]byte(nil), k.key...),
+			chainCode: append([]byte(nil), k.chainCode...),
+			depth:     k.depth,
+			parentFP:  k.parentFP,
+			index:     k.index,
+		}, nil
+	}
+
+	return key, nil
+}
+
+// Neuter returns the public extended key of k, which derives addresses but not private keys.
+func (k *ExtendedKey) Neuter() *ExtendedKey {
+	return &ExtendedKey{
+		key:       k.publicKeyBytes(),
+		chainCode: append([]byte(nil), k.chainCode...),
+		depth:     k.depth,
+		parentFP:  k.parentFP,
+		index:     k.index,
+	}
+}
+
+// PrivateKey returns the private key of k.
+func (k *ExtendedKey) PrivateKey() (*PrivateKey, error) {
+	if !k.IsPrivate() {
+		return nil, fmt.Errorf("%w: public extended key", ErrInvalidExtendedKey)
+	}
+
+	return PrivateKeyFromBytes(k.key)
+}
+
+// PublicKey returns the public key of k.
+func (k *ExtendedKey) PublicKey() *PublicKey {
+	key, _ := secp256k1.ParsePubKey(k.publicKeyBytes())
+
+	return &PublicKey{key: key}
+}
+
+// Address returns the TRON address of k.
+func (k *ExtendedKey) Address() Address {
+	return k.PublicKey().Address()
+}
+
+// Zero overwrites the key material. The key must not be used afterwards.
+func (k *ExtendedKey) Zero() {
+	zero(k.key)
+	zero(k.chainCode)
+}
+
+// publicKeyBytes returns the 33 byte compressed public key of k.
+func (k *ExtendedKey) publicKeyBytes() []byte {
+	if !k.IsPrivate() {
+		return append([]byte(nil), k.key...)
+	}
+
+	key := secp256k1.PrivKeyFromBytes(k.key)
+	defer key.Zero()
+
+	return key.PubKey().SerializeCompressed()
+}
+
+// TRONPath returns the BIP44 path of a TRON address: m/44'/195'/account'/0/index.
+func TRONPath(account, index uint32) string {
+	return fmt.Sprintf("m/44'/%d'/%d'/0/%d", TRONCoinType, account, index)
+}
+
+// TRONAccount returns the account key m/44'/195'/account' of a master key. Its Neuter is the xpub
+// a watch-only server derives deposit addresses from with TRONAddress.
+func (k *ExtendedKey) TRONAccount(account uint32) (*ExtendedKey, error) {
+	if account >= HardenedKeyStart {
+		return nil, fmt.Errorf("%w: account %d", ErrInvalidExtendedKey, account)
+	}
+
+	return k.Derive(fmt.Sprintf("m/44'/%d'/%d'", TRONCoinType, account))
+}
+
+// TRONAddress returns the address at index of an account key from TRONAccount, private or public.
+func (k *ExtendedKey) TRONAddress(index uint32) (Address, error) {
+	child, err := k.Derive("0/" + strconv.FormatUint(uint64(index), 10))
+	if err != nil {
+		return Address{}, err
+	}
+	defer child.Zero()
+
+	return child.Address(), nil
+}
+
+// TRONKey returns the private key at index of a private account key from TRONAccount.
+func (k *ExtendedKey) TRONKey(index uint32) (*PrivateKey, error) {
+	child, err := k.Derive("0/" + strconv.FormatUint(uint64(index), 10))
+	if err != nil {
+		return nil, err
+	}
+	defer child.Zero()
+
+	return child.PrivateKey()
+}
+
+// hash160 returns RIPEMD-160 of SHA-256 of b.
+func hash160(b []byte) []byte {
+	sum := sha256.Sum256(b)
+	h := ripemd160.New()
+	_, _ = h.Write(sum[:])
+
+	return h.Sum(nil)
+}
@@ -0,0 +1,117 @@
+package trongrid_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+func TestExtendedKey_Derive(t *testing.T) {
+	// test vector 1 from BIP32
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+	master, err := trongrid.NewMasterKey(seed)
+	require.NoError(t, err)
+
+	tests := []struct {
+		path string
+		xprv string
+		xpub string
+	}{
+		{
+			path: "m",
+			xprv: "xprv9s21ZrQH143K3QTDL4LXw2F7HEK3wJUD2nW2nRk4stbPy6cq3jPPqjiChkVvvNKmPGJxWUtg6LnF5kejMRNNU3TGtRBeJgk33yuGBxrMPHi",
+			xpub: "xpub661MyMwAqRbcFtXgS5sYJABqqG9YLmC4Q1Rdap9gSE8NqtwybGhePY2gZ29ESFjqJoCu1Rupje8YtGqsefD265TMg7usUDFdp6W1EGMcet8",
+		},
+		{
+			path: "m/0'",
+			xprv: "xprv9uHRZZhk6KAJC1avXpDAp4MDc3sQKNxDiPvvkX8Br5ngLNv1TxvUxt4cV1rGL5hj6KCesnDYUhd7oWgT11eZG7XnxHrnYeSvkzY7d2bhkJ7",
+			xpub: "xpub68Gmy5EdvgibQVfPdqkBBCHxA5htiqg55crXYuXoQRKfDBFA1WEjWgP6LHhwBZeNK1VTsfTFUHCdrfp1bgwQ9xv5ski8PX9rL2dZXvgGDnw",
+		},
+		{
+			path: "m/0h/1",
+			xprv: "xprv9wTYmMFdV23N2TdNG573QoEsfRrWKQgWeibmLntzniatZvR9BmLnvSxqu53Kw1UmYPxLgboyZQaXwTCg8MSY3H2EU4pWcQDnRnrVA1xe8fs",
+			xpub: "xpub6ASuArnXKPbfEwhqN6e3mwBcDTgzisQN1wXN9BJcM47sSikHjJf3UFHKkNAWbWMiGj7Wf5uMash7SyYq527Hqck2AxYysAA7xmALppuCkwQ",
+		},
+	}
+	for _, tt := range tests {
+		key, err := master.Derive(tt.path)
+		require.NoError(t, err, tt.path)
+		assert.Equal(t, tt.xprv, key.String(), tt.path)
+		assert.Equal(t, tt.xpub, key.Neuter().String(), tt.path)
+
+		parsed, err := trongrid.ParseExtendedKey(tt.xpub)
+		require.NoError(t, err)
+		assert.False(t, parsed.IsPrivate())
+		assert.Equal(t, tt.xpub, parsed.String())
+	}
+
+	// public derivation matches private derivation for non-hardened indexes
+	account, err := master.Derive("m/0'")
+	require.NoError(t, err)
+	public, err := trongrid.ParseExtendedKey(account.Neuter().String())
+	require.NoError(t, err)
+	child, err := public.Derive("1/7")
+	require.NoError(t, err)
+	private, err := account.Derive("1/7")
+	require.NoError(t, err)
+	assert.Equal(t, private.Address(), child.Address())
+
+	_, err = public.Derive("1'")
+	require.ErrorIs(t, err, trongrid.ErrInvalidExtendedKey)
+	_, err = account.Derive("m/1")
+	require.ErrorIs(t, err, trongrid.ErrInvalidExtendedKey)
+	_, err = master.Derive("m/x")
+	require.ErrorIs(t, err, trongrid.ErrInvalidExtendedKey)
+	_, err = public.PrivateKey()
+	require.ErrorIs(t, err, trongrid.ErrInvalidExtendedKey)
+	_, err = trongrid.ParseExtendedKey("TPqG9VfqXycvNTaoBunqLWxUi69gnnQ6Fq")
+	require.ErrorIs(t, err, trongrid.ErrInvalidExtendedKey)
+}
+
+func TestExtendedKey_TRONAccount(t *testing.T) {
+	seed, err := trongrid.MnemonicToSeed(
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "")
+	require.NoError(t, err)
+	master, err := trongrid.NewMasterKey(seed)
+	require.NoError(t, err)
+
+	account, err := master.TRONAccount(0)
+	require.NoError(t, err)
+	key, err := account.TRONKey(0)
+	require.NoError(t, err)
+	assert.Equal(t, "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH", key.Address().String())
+
+	byPath, err := master.Derive(trongrid.TRONPath(0, 0))
+	require.NoError(t, err)
+	assert.Equal(t, key.Address(), byPath.Address())
+
+	// the watch-only side derives the same deposit addresses from the account xpub
+	watchOnly, err := trongrid.ParseExtendedKey(account.Neuter().String())
+	require.NoError(t, err)
+	for index := uint32(0); index < 3; index++ {
+		key, err := account.TRONKey(index)
+		require.NoError(t, err)
+		address, err := watchOnly.TRONAddress(index)
+		require.NoError(t, err)
+		assert.Equal(t, key.Address(), address)
+	}
+	_, err = watchOnly.TRONKey(0)
+	require.ErrorIs(t, err, trongrid.ErrInvalidExtendedKey)
+
+	// a passphrase gives another wallet
+	seed, err = trongrid.MnemonicToSeed(
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "secret")
+	require.NoError(t, err)
+	other, err := trongrid.NewMasterKey(seed)
+	require.NoError(t, err)
+	otherAccount, err := other.TRONAccount(0)
+	require.NoError(t, err)
+	address, err := otherAccount.TRONAddress(0)
+	require.NoError(t, err)
+	assert.NotEqual(t, key.Address(), address)
+}
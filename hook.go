@@ -0,0 +1,38 @@
+package trongrid
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RequestInfo describes an outgoing request attempt.
+type RequestInfo struct {
+	// Endpoint is the name of the API method, e.g. "ListTransactions"
+	Endpoint   string
+	Method     string
+	Path       string
+	PathParams map[string]string
+	Params     url.Values
+	Body       interface{}
+	// Header is sent with the request, so hooks may add custom headers
+	Header http.Header
+	// Attempt starts at 1 and grows with every retry
+	Attempt int
+}
+
+// ResponseInfo describes the outcome of a request attempt.
+type ResponseInfo struct {
+	*RequestInfo
+	// Status is 0 when no response was received
+	Status  int
+	Latency time.Duration
+	Err     error
+}
+
+// BeforeRequestHook is called before every attempt. Returning an error aborts the call.
+type BeforeRequestHook func(ctx context.Context, req *RequestInfo) error
+
+// AfterResponseHook is called after every attempt, including failed ones.
+type AfterResponseHook func(ctx context.Context, resp *ResponseInfo)
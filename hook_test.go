@@ -0,0 +1,178 @@
+package trongrid_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestHooks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/accounts/TJRabPrwbZy45sbavfcjinPJC18kjpRTv8/transactions", r.URL.Path)
+		assert.Equal(t, "audit", r.Header.Get("X-Request-Source"))
+		assert.Equal(t, "proxied", r.Header.Get("X-Transport"))
+		assert.Equal(t, "wallet/1.0", r.Header.Get("User-Agent"))
+		_, _ = w.Write([]byte(`{"success":true,"data":[]}`))
+	}))
+	defer srv.Close()
+
+	var responses []*trongrid.ResponseInfo
+	api := trongrid.NewAPI(
+		trongrid.WithURI(srv.URL),
+		trongrid.WithRateLimiter(nil),
+		trongrid.WithUserAgent("wallet/1.0"),
+		trongrid.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			r.Header.Set("X-Transport", "proxied")
+
+			return new(http.Transport).RoundTrip(r)
+		})),
+		trongrid.WithBeforeRequest(func(ctx context.Context, req *trongrid.RequestInfo) error {
+			req.Header.Set("X-Request-Source", "audit")
+
+			return nil
+		}),
+		trongrid.WithAfterResponse(func(ctx context.Context, resp *trongrid.ResponseInfo) {
+			responses = append(responses, resp)
+		}),
+	)
+
+	resp, err := api.ListTransactions(context.Background(), &trongrid.ListTransactionsRequest{
+		Address: "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8",
+		Limit:   20,
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+
+	require.Len(t, responses, 1)
+	assert.Equal(t, "ListTransactions", responses[0].Endpoint)
+	assert.Equal(t, http.StatusOK, responses[0].Status)
+	assert.Equal(t, "20", responses[0].Params.Get("limit"))
+	assert.Positive(t, responses[0].Latency)
+}
+
+func TestWithHTTPClient_Shared(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "shared", r.Header.Get("X-Transport"))
+		_, _ = w.Write([]byte(`{"success":true,"data":[]}`))
+	}))
+	defer srv.Close()
+
+	sharedTransport := new(http.Transport)
+	shared := &http.Client{Transport: sharedTransport, Timeout: time.Minute}
+	api := trongrid.NewAPI(
+		trongrid.WithURI(srv.URL),
+		trongrid.WithRateLimiter(nil),
+		trongrid.WithHTTPClient(shared),
+		trongrid.WithTimeout(time.Second),
+		trongrid.WithTransport(roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			r.Header.Set("X-Transport", "shared")
+
+			return sharedTransport.RoundTrip(r)
+		})),
+	)
+
+	_, err := api.ListTransactions(context.Background(), &trongrid.ListTransactionsRequest{
+		Address: "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8",
+	})
+	require.NoError(t, err)
+
+	// the client is used by others and must keep its settings
+	assert.Equal(t, time.Minute, shared.Timeout)
+	assert.Nil(t, shared.CheckRedirect)
+	assert.Same(t, sharedTransport, shared.Transport)
+}
+
+type recorder struct {
+	trongrid.Instrumentation
+	endpoints []string
+	statuses  []int
+	retries   int
+	waits     int
+	cacheHits []string
+}
+
+func (r *recorder) RecordRequest(_ context.Context, endpoint string, status int, _ time.Duration, _ error) {
+	r.endpoints = append(r.endpoints, endpoint)
+	r.statuses = append(r.statuses, status)
+}
+
+func (r *recorder) RecordRetry(context.Context, string, int) {
+	r.retries++
+}
+
+func (r *recorder) RecordRateLimiterWait(context.Context, string, time.Duration) {
+	r.waits++
+}
+
+func (r *recorder) RecordCacheHit(_ context.Context, endpoint string) {
+	r.cacheHits = append(r.cacheHits, endpoint)
+}
+
+func TestInstrumentation(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		if hits == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true,"data":[]}`))
+	}))
+	defer srv.Close()
+
+	rec := &recorder{Instrumentation: trongrid.NoopInstrumentation()}
+	api := trongrid.NewAPI(
+		trongrid.WithURI(srv.URL),
+		trongrid.WithRateLimit(1000, 10),
+		trongrid.WithRetryPolicy(trongrid.RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond}),
+		trongrid.WithInstrumentation(rec),
+	)
+
+	_, err := api.ListTransactionsTrc20(context.Background(), &trongrid.ListTransactionsRequest{
+		Address: "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"ListTransactionsTrc20", "ListTransactionsTrc20"}, rec.endpoints)
+	assert.Equal(t, []int{http.StatusBadGateway, http.StatusOK}, rec.statuses)
+	assert.Equal(t, 1, rec.retries)
+	assert.Equal(t, 2, rec.waits)
+}
+
+func TestInstrumentation_CacheHit(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"txID":"abc","raw_data":{}}`))
+	}))
+	defer srv.Close()
+
+	rec := &recorder{Instrumentation: trongrid.NoopInstrumentation()}
+	api := trongrid.NewAPI(
+		trongrid.WithURI(srv.URL),
+		trongrid.WithRateLimiter(nil),
+		trongrid.WithCache(trongrid.NewMemoryCache(10)),
+		trongrid.WithInstrumentation(rec),
+	)
+
+	req := &trongrid.GetTransactionRequest{ID: "abc", OnlyConfirmed: true}
+	for i := 0; i < 2; i++ {
+		_, err := api.GetTransactionByID(context.Background(), req)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{"GetTransactionByID"}, rec.endpoints)
+	assert.Equal(t, []string{"GetTransactionByID"}, rec.cacheHits)
+}
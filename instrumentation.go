@@ -0,0 +1,71 @@
+package trongrid
+
+import (
+	"context"
+	"time"
+)
+
+// Attribute is a key/value pair attached to a span.
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+// Span traces a single API call, including all of its attempts.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Instrumentation receives traces and metrics from the client.
+// See the trongridotel package for an OpenTelemetry implementation.
+type Instrumentation interface {
+	// StartSpan starts the span of an API call.
+	StartSpan(ctx context.Context, endpoint string, attrs ...Attribute) (context.Context, Span)
+	// RecordRequest records a single HTTP attempt; status is 0 when no response was received.
+	RecordRequest(ctx context.Context, endpoint string, status int, latency time.Duration, err error)
+	// RecordRetry records that attempt (starting at 2) is about to be sent.
+	RecordRetry(ctx context.Context, endpoint string, attempt int)
+	// RecordRateLimiterWait records the time spent waiting on the rate limiter.
+	RecordRateLimiterWait(ctx context.Context, endpoint string, wait time.Duration)
+	// RecordCacheHit records a call answered from the cache, which sends no request and starts no span.
+	RecordCacheHit(ctx context.Context, endpoint string)
+}
+
+// Span attribute keys set by the client.
+const (
+	AttributeEndpoint = "trongrid.endpoint"
+	AttributeNetwork  = "trongrid.network"
+	AttributeStatus   = "http.status_code"
+	AttributeAttempts = "trongrid.attempts"
+	// AttributePathPrefix prefixes path parameters such as the account address
+	AttributePathPrefix = "trongrid."
+)
+
+type noopInstrumentation struct{}
+
+type noopSpan struct{}
+
+// NoopInstrumentation returns an Instrumentation that discards everything.
+func NoopInstrumentation() Instrumentation {
+	return noopInstrumentation{}
+}
+
+func (noopInstrumentation) StartSpan(ctx context.Context, _ string, _ ...Attribute) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+func (noopInstrumentation) RecordRequest(context.Context, string, int, time.Duration, error) {}
+
+func (noopInstrumentation) RecordRetry(context.Context, string, int) {}
+
+func (noopInstrumentation) RecordRateLimiterWait(context.Context, string, time.Duration) {}
+
+func (noopInstrumentation) RecordCacheHit(context.Context, string) {}
+
+func (noopSpan) SetAttributes(...Attribute) {}
+
+func (noopSpan) RecordError(error) {}
+
+func (noopSpan) End() {}
@@ -0,0 +1,101 @@
+package trongrid
+
+import "encoding/json"
+
+// InternalTransaction is a call or value transfer made by a contract while executing a transaction,
+// e.g. TRX forwarded to users by a multisend contract.
+type InternalTransaction struct {
+	Hash string `json:"hash"`
+	// CallerAddress is the contract making the call, in hex
+	CallerAddress string `json:"caller_address"`
+	// TransferToAddress is the recipient, in hex
+	TransferToAddress string          `json:"transferTo_address"`
+	CallValueInfo     []CallValueInfo `json:"callValueInfo"`
+	// Note is the hex encoded kind of call, e.g. "63616c6c" for "call"
+	Note     string `json:"note"`
+	Rejected bool   `json:"rejected"`
+}
+
+// CallValueInfo is a value sent by an internal transaction: TRX in sun when TokenID is empty,
+// otherwise an amount of the TRC10 asset TokenID.
+type CallValueInfo struct {
+	TokenID   string `json:"tokenId,omitempty"`
+	CallValue int64  `json:"callValue,omitempty"`
+}
+
+// v1InternalTransaction is the form used by the v1 transaction lists.
+type v1InternalTransaction struct {
+	InternalTxID string `json:"internal_tx_id"`
+	FromAddress  string `json:"from_address"`
+	ToAddress    string `json:"to_address"`
+	Data         struct {
+		Note      string           `json:"note"`
+		Rejected  bool             `json:"rejected"`
+		CallValue map[string]int64 `json:"call_value"`
+	} `json:"data"`
+}
+
+// UnmarshalJSON accepts both the wallet API form and the v1 form of internal transactions.
+func (t *InternalTransaction) UnmarshalJSON(b []byte) error {
+	type wallet InternalTransaction
+
+	var v struct {
+		wallet
+		v1InternalTransaction
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+
+	*t = InternalTransaction(v.wallet)
+	if v.InternalTxID == "" {
+		return nil
+	}
+
+	t.Hash = v.InternalTxID
+	t.CallerAddress = v.FromAddress
+	t.TransferToAddress = v.ToAddress
+	t.Note = v.Data.Note
+	t.Rejected = v.Data.Rejected
+	for token, value := range v.Data.CallValue {
+		// "_" is TRX in the v1 form
+		if token == "_" {
+			token = ""
+		}
+		t.CallValueInfo = append(t.CallValueInfo, CallValueInfo{TokenID: token, CallValue: value})
+	}
+
+	return nil
+}
+
+// TRXValue returns the TRX sent by the internal transaction, in sun.
+func (t *InternalTransaction) TRXValue() int64 {
+	var sum int64
+	for _, v := range t.CallValueInfo {
+		if v.TokenID == "" || v.TokenID == "_" {
+			sum += v.CallValue
+		}
+	}
+
+	return sum
+}
+
+// NoteText returns the decoded note, e.g. "call".
+func (t *InternalTransaction) NoteText() string {
+	return decodeMessage(t.Note)
+}
+
+// InternalTransfer is an internal transaction together with the transaction that caused it.
+type InternalTransfer struct {
+	InternalTransaction InternalTransaction `json:"internal_transaction"`
+	TransactionID       string              `json:"transaction_id"`
+	// BlockNumber is 0 for internal transactions listed as rows of their own, which carry none
+	BlockNumber    int   `json:"block_number"`
+	BlockTimestamp int64 `json:"block_timestamp"`
+}
+
+type ListInternalTransactionsResponse struct {
+	Data    []InternalTransfer `json:"data"`
+	Success bool               `json:"success"`
+	Meta    *Meta              `json:"meta"`
+}
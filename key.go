@@ -0,0 +1,174 @@
+package trongrid
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	"golang.org/x/crypto/sha3"
+)
+
+// SignatureLength is the length of a TRON signature: r, s and the recovery byte v.
+const SignatureLength = 65
+
+var (
+	ErrInvalidPrivateKey = errors.New("invalid private key")
+	ErrInvalidSignature  = errors.New("invalid signature")
+)
+
+// PrivateKey is a secp256k1 key controlling a TRON account.
+type PrivateKey struct {
+	key *secp256k1.PrivateKey
+}
+
+// PublicKey is the public half of a PrivateKey.
+type PublicKey struct {
+	key *secp256k1.PublicKey
+}
+
+// GenerateKey returns a new random private key.
+func GenerateKey() (*PrivateKey, error) {
+	key, err := secp256k1.GeneratePrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &PrivateKey{key: key}, nil
+}
+
+// PrivateKeyFromBytes parses a 32 byte private key.
+func PrivateKeyFromBytes(b []byte) (*PrivateKey, error) {
+	if len(b) != 32 {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	var scalar secp256k1.ModNScalar
+	if overflow := scalar.SetByteSlice(b); overflow || scalar.IsZero() {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	return &PrivateKey{key: secp256k1.NewPrivateKey(&scalar)}, nil
+}
+
+// PrivateKeyFromHex parses a hex encoded private key as exported by TronLink or wallet-cli.
+func PrivateKeyFromHex(s string) (*PrivateKey, error) {
+	b, err := hex.DecodeString(trimHexPrefix(s))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPrivateKey, err)
+	}
+
+	return PrivateKeyFromBytes(b)
+}
+
+// Bytes returns the 32 byte private key.
+func (k *PrivateKey) Bytes() []byte {
+	return k.key.Serialize()
+}
+
+// Hex returns the hex encoded private key.
+func (k *PrivateKey) Hex() string {
+	return hex.EncodeToString(k.Bytes())
+}
+
+// PublicKey returns the public key.
+func (k *PrivateKey) PublicKey() *PublicKey {
+	return &PublicKey{key: k.key.PubKey()}
+}
+
+// Address returns the address of the account controlled by the key.
+func (k *PrivateKey) Address() Address {
+	return k.PublicKey().Address()
+}
+
+// Sign signs a 32 byte hash and returns r || s || v with v = 27 + recovery id.
+func (k *PrivateKey) Sign(hash []byte) ([]byte, error) {
+	if len(hash) != 32 {
+		return nil, fmt.Errorf("%w: hash must be 32 bytes", ErrInvalidSignature)
+	}
+
+	compact := ecdsa.SignCompact(k.key, hash, false)
+
+	sig := make([]byte, SignatureLength)
+	copy(sig, compact[1:])
+	sig[64] = compact[0]
+
+	return sig, nil
+}
+
+// Zero overwrites the key material. The key must not be used afterwards.
+func (k *PrivateKey) Zero() {
+	k.key.Zero()
+}
+
+// Address returns the address derived from the public key:
+// 0x41 followed by the last 20 bytes of keccak256 of the uncompressed key.
+func (p *PublicKey) Address() Address {
+	h := sha3.NewLegacyKeccak256()
+	_, _ = h.Write(p.key.SerializeUncompressed()[1:])
+	sum := h.Sum(nil)
+
+	var a Address
+	a[0] = AddressPrefix
+	copy(a[1:], sum[12:])
+
+	return a
+}
+
+// Bytes returns the 65 byte uncompressed public key.
+func (p *PublicKey) Bytes() []byte {
+	return p.key.SerializeUncompressed()
+}
+
+// RecoverPublicKey returns the key that produced sig over hash.
+// v may be given as the recovery id (0, 1) or as 27 + recovery id.
+func RecoverPublicKey(hash, sig []byte) (*PublicKey, error) {
+	if len(sig) != SignatureLength || len(hash) != 32 {
+		return nil, ErrInvalidSignature
+	}
+
+	v := sig[64]
+	if v < 27 {
+		v += 27
+	}
+
+	compact := make([]byte, SignatureLength)
+	compact[0] = v
+	copy(compact[1:], sig[:64])
+
+	key, _, err := ecdsa.RecoverCompact(compact, hash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	return &PublicKey{key: key}, nil
+}
+
+// RecoverAddress returns the address of the key that produced sig over hash.
+func RecoverAddress(hash, sig []byte) (Address, error) {
+	key, err := RecoverPublicKey(hash, sig)
+	if err != nil {
+		return Address{}, err
+	}
+
+	return key.Address(), nil
+}
+
+// Keccak256 returns the legacy Keccak-256 hash used by the TVM.
+func Keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, b := range data {
+		_, _ = h.Write(b)
+	}
+
+	return h.Sum(nil)
+}
+
+func trimHexPrefix(s string) string {
+	if len(s) >= 2 && s[0] == '0' && (s[1] == 'x' || s[1] == 'X') {
+		return s[2:]
+	}
+
+	return s
+}
@@ -0,0 +1,75 @@
+package trongrid_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+func TestPrivateKey_Address(t *testing.T) {
+	key, err := trongrid.PrivateKeyFromHex("0x0000000000000000000000000000000000000000000000000000000000000001")
+	require.NoError(t, err)
+	assert.Equal(t, "417e5f4552091a69125d5dfcb7b8c2659029395bdf", key.Address().Hex())
+
+	_, err = trongrid.PrivateKeyFromHex("00")
+	require.ErrorIs(t, err, trongrid.ErrInvalidPrivateKey)
+	_, err = trongrid.PrivateKeyFromHex("0000000000000000000000000000000000000000000000000000000000000000")
+	require.ErrorIs(t, err, trongrid.ErrInvalidPrivateKey)
+}
+
+func TestPrivateKey_Sign(t *testing.T) {
+	key, err := trongrid.GenerateKey()
+	require.NoError(t, err)
+
+	hash := sha256.Sum256([]byte("hello"))
+	sig, err := key.Sign(hash[:])
+	require.NoError(t, err)
+	require.Len(t, sig, trongrid.SignatureLength)
+	assert.Contains(t, []byte{27, 28}, sig[64])
+
+	signer, err := trongrid.RecoverAddress(hash[:], sig)
+	require.NoError(t, err)
+	assert.Equal(t, key.Address(), signer)
+
+	_, err = key.Sign([]byte("short"))
+	require.ErrorIs(t, err, trongrid.ErrInvalidSignature)
+}
+
+func TestSignTransaction(t *testing.T) {
+	key, err := trongrid.GenerateKey()
+	require.NoError(t, err)
+
+	tx := &trongrid.Transaction{}
+	tx.RawData = trongrid.TransactionRawData{
+		Contract: []trongrid.Contract{{
+			Type: trongrid.ContractTypeTRX,
+			Parameter: trongrid.ContractParameter{
+				Value: trongrid.ContractValue{
+					Amount:       1_000_000,
+					OwnerAddress: key.Address().Hex(),
+					ToAddress:    "41a614f803b6fd780986a42c78ec9c7f77e6ded13c",
+				},
+				TypeUrl: "type.googleapis.com/protocol.TransferContract",
+			},
+		}},
+		RefBlockBytes: "0a1b",
+		RefBlockHash:  "0102030405060708",
+		Expiration:    1700000060000,
+		Timestamp:     1700000000000,
+	}
+	require.NoError(t, trongrid.SealTransaction(tx))
+	require.Len(t, tx.TxID, 64)
+
+	require.NoError(t, trongrid.SignTransaction(tx, key))
+	signers, err := trongrid.TransactionSigners(tx)
+	require.NoError(t, err)
+	assert.Equal(t, []trongrid.Address{key.Address()}, signers)
+
+	tx.TxID = "00" + tx.TxID[2:]
+	_, err = trongrid.TransactionSigners(tx)
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+}
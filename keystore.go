@@ -0,0 +1,221 @@
+package trongrid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// StandardScryptN and StandardScryptP are the scrypt costs of TronLink and wallet-cli key files,
+	// about a second and 256MB of memory to unlock
+	StandardScryptN = 1 << 18
+	StandardScryptP = 1
+	// LightScryptN and LightScryptP unlock in milliseconds, e.g. for tests
+	LightScryptN = 1 << 12
+	LightScryptP = 6
+)
+
+const (
+	keyFileVersion = 3
+	scryptR        = 8
+	scryptDKLen    = 32
+)
+
+// ErrDecrypt is returned when a key file cannot be decrypted, usually because the password is wrong.
+var ErrDecrypt = errors.New("could not decrypt key with given password")
+
+// keyFileJSON is a key file in the Web3 Secret Storage v3 format.
+type keyFileJSON struct {
+	Address string        `json:"address"`
+	Crypto  keyFileCrypto `json:"crypto"`
+	ID      string        `json:"id"`
+	Version int           `json:"version"`
+}
+
+type keyFileCrypto struct {
+	Cipher       string                 `json:"cipher"`
+	CipherText   string                 `json:"ciphertext"`
+	CipherParams keyFileCipherParams    `json:"cipherparams"`
+	KDF          string                 `json:"kdf"`
+	KDFParams    map[string]interface{} `json:"kdfparams"`
+	MAC          string                 `json:"mac"`
+}
+
+type keyFileCipherParams struct {
+	IV string `json:"iv"`
+}
+
+// EncryptKey returns key encrypted with password in the Web3 Secret Storage v3 format, with scrypt
+// costs scryptN and scryptP, e.g. StandardScryptN and StandardScryptP.
+func EncryptKey(key *PrivateKey, password string, scryptN, scryptP int) ([]byte, error) {
+	salt := make([]byte, 32)
+	iv := make([]byte, aes.BlockSize)
+	id := make([]byte, 16)
+	for _, b := range [][]byte{salt, iv, id} {
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+	}
+
+	derived, err := scrypt.Key([]byte(password), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return nil, fmt.Errorf("%w: scrypt: %v", ErrInvalidRequest, err)
+	}
+	defer zero(derived)
+
+	plain := key.Bytes()
+	defer zero(plain)
+	ciphertext, err := aesCTR(derived[:16], iv, plain)
+	if err != nil {
+		return nil, err
+	}
+
+	// version 4 UUID
+	id[6] = id[6]&0x0f | 0x40
+	id[8] = id[8]&0x3f | 0x80
+
+	return json.Marshal(keyFileJSON{
+		Address: key.Address().String(),
+		Crypto: keyFileCrypto{
+			Cipher:       "aes-128-ctr",
+			CipherText:   hex.EncodeToString(ciphertext),
+			CipherParams: keyFileCipherParams{IV: hex.EncodeToString(iv)},
+			KDF:          "scrypt",
+			KDFParams: map[string]interface{}{
+				"n":     scryptN,
+				"r":     scryptR,
+				"p":     scryptP,
+				"dklen": scryptDKLen,
+				"salt":  hex.EncodeToString(salt),
+			},
+			MAC: hex.EncodeToString(Keccak256(derived[16:32], ciphertext)),
+		},
+		ID:      fmt.Sprintf("%x-%x-%x-%x-%x", id[0:4], id[4:6], id[6:8], id[8:10], id[10:]),
+		Version: keyFileVersion,
+	})
+}
+
+// DecryptKey decrypts a Web3 Secret Storage v3 key file encrypted with scrypt or pbkdf2,
+// e.g. a TronLink or wallet-cli export. It returns ErrDecrypt if the password is wrong.
+func DecryptKey(keyJSON []byte, password string) (*PrivateKey, error) {
+	var v keyFileJSON
+	if err := json.Unmarshal(keyJSON, &v); err != nil {
+		return nil, fmt.Errorf("%w: key file: %v", ErrInvalidRequest, err)
+	}
+	if v.Version != keyFileVersion {
+		return nil, fmt.Errorf("%w: key file version %d", ErrInvalidRequest, v.Version)
+	}
+	c := v.Crypto
+	if c.Cipher != "aes-128-ctr" {
+		return nil, fmt.Errorf("%w: cipher %s", ErrInvalidRequest, c.Cipher)
+	}
+
+	ciphertext, err := hex.DecodeString(c.CipherText)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ciphertext: %v", ErrInvalidRequest, err)
+	}
+	iv, err := hex.DecodeString(c.CipherParams.IV)
+	if err != nil || len(iv) != aes.BlockSize {
+		return nil, fmt.Errorf("%w: iv", ErrInvalidRequest)
+	}
+	mac, err := hex.DecodeString(c.MAC)
+	if err != nil {
+		return nil, fmt.Errorf("%w: mac: %v", ErrInvalidRequest, err)
+	}
+
+	derived, err := deriveKeyFileKey(&c, password)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(derived)
+	if !hmac.Equal(Keccak256(derived[16:32], ciphertext), mac) {
+		return nil, ErrDecrypt
+	}
+
+	plain, err := aesCTR(derived[:16], iv, ciphertext)
+	if err != nil {
+		return nil, err
+	}
+	defer zero(plain)
+	key, err := PrivateKeyFromBytes(plain)
+	if err != nil {
+		return nil, err
+	}
+
+	if v.Address != "" {
+		if a, err := parseKeyFileAddress(v.Address); err != nil || a != key.Address() {
+			key.Zero()
+			return nil, fmt.Errorf("%w: key file address does not match its key", ErrInvalidRequest)
+		}
+	}
+
+	return key, nil
+}
+
+// deriveKeyFileKey derives the 32 byte key of the kdf of c from password.
+func deriveKeyFileKey(c *keyFileCrypto, password string) ([]byte, error) {
+	param := func(name string) int {
+		f, _ := c.KDFParams[name].(float64)
+		return int(f)
+	}
+	salt, err := hex.DecodeString(fmt.Sprint(c.KDFParams["salt"]))
+	if err != nil || len(salt) == 0 {
+		return nil, fmt.Errorf("%w: kdf salt", ErrInvalidRequest)
+	}
+	if param("dklen") < 32 {
+		return nil, fmt.Errorf("%w: kdf dklen must be at least 32", ErrInvalidRequest)
+	}
+
+	switch c.KDF {
+	case "scrypt":
+		derived, err := scrypt.Key([]byte(password), salt, param("n"), param("r"), param("p"), param("dklen"))
+		if err != nil {
+			return nil, fmt.Errorf("%w: scrypt: %v", ErrInvalidRequest, err)
+		}
+
+		return derived, nil
+	case "pbkdf2":
+		if prf := c.KDFParams["prf"]; prf != "hmac-sha256" {
+			return nil, fmt.Errorf("%w: pbkdf2 prf %v", ErrInvalidRequest, prf)
+		}
+		if param("c") <= 0 {
+			return nil, fmt.Errorf("%w: pbkdf2 iteration count", ErrInvalidRequest)
+		}
+
+		return pbkdf2.Key([]byte(password), salt, param("c"), param("dklen"), sha256.New), nil
+	default:
+		return nil, fmt.Errorf("%w: kdf %s", ErrInvalidRequest, c.KDF)
+	}
+}
+
+// parseKeyFileAddress parses the address of a key file: a TRON address, or the 20 byte hex address
+// of key files written by Ethereum tools.
+func parseKeyFileAddress(s string) (Address, error) {
+	if len(s) == 2*(AddressLength-1) {
+		s = "0x" + s
+	}
+
+	return ParseAddress(s)
+}
+
+func aesCTR(key, iv, in []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, len(in))
+	cipher.NewCTR(block, iv).XORKeyStream(out, in)
+
+	return out, nil
+}
@@ -0,0 +1,320 @@
+package trongrid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrUnknownAccount is returned for addresses without a key file in the keystore
+	ErrUnknownAccount = errors.New("unknown account")
+	// ErrLocked is returned when signing with an account that is not unlocked
+	ErrLocked = errors.New("account is locked")
+)
+
+// KeyStoreOption configures a KeyStore.
+type KeyStoreOption func(*KeyStore)
+
+// WithScrypt sets the scrypt costs of the key files the keystore writes.
+func WithScrypt(n, p int) KeyStoreOption {
+	return func(ks *KeyStore) {
+		ks.scryptN, ks.scryptP = n, p
+	}
+}
+
+// KeyStore keeps private keys encrypted in a directory, one Web3 Secret Storage v3 file per account.
+// Keys are only decrypted in memory while an account is unlocked and are zeroed when it is locked.
+type KeyStore struct {
+	dir     string
+	scryptN int
+	scryptP int
+
+	mu       sync.Mutex
+	unlocked map[Address]*unlockedKey
+}
+
+type unlockedKey struct {
+	key   *PrivateKey
+	timer *time.Timer
+}
+
+// NewKeyStore returns a keystore of the key files in dir, created on the first write.
+func NewKeyStore(dir string, opts ...KeyStoreOption) *KeyStore {
+	ks := &KeyStore{
+		dir:      dir,
+		scryptN:  StandardScryptN,
+		scryptP:  StandardScryptP,
+		unlocked: make(map[Address]*unlockedKey),
+	}
+	for _, opt := range opts {
+		opt(ks)
+	}
+
+	return ks
+}
+
+// NewAccount generates a key and stores it encrypted with password.
+func (ks *KeyStore) NewAccount(password string) (Address, error) {
+	key, err := GenerateKey()
+	if err != nil {
+		return Address{}, err
+	}
+	defer key.Zero()
+
+	return ks.Import(key, password)
+}
+
+// Import stores key encrypted with password. The caller keeps ownership of key.
+func (ks *KeyStore) Import(key *PrivateKey, password string) (Address, error) {
+	a := key.Address()
+	if _, err := ks.find(a); err == nil {
+		return Address{}, fmt.Errorf("%w: account %s already exists", ErrInvalidRequest, a)
+	}
+
+	b, err := EncryptKey(key, password, ks.scryptN, ks.scryptP)
+	if err != nil {
+		return Address{}, err
+	}
+	if err = os.MkdirAll(ks.dir, 0o700); err != nil {
+		return Address{}, err
+	}
+
+	name := "UTC--" + time.Now().UTC().Format("2006-01-02T15-04-05.000000000Z") + "--" + a.String()
+	if err = os.WriteFile(filepath.Join(ks.dir, name), b, 0o600); err != nil {
+		return Address{}, err
+	}
+
+	return a, nil
+}
+
+// ImportKeyFile stores a key file exported by TronLink or wallet-cli, after checking that password
+// decrypts it. The file is re-encrypted with the scrypt costs of the keystore.
+func (ks *KeyStore) ImportKeyFile(keyJSON []byte, password string) (Address, error) {
+	key, err := DecryptKey(keyJSON, password)
+	if err != nil {
+		return Address{}, err
+	}
+	defer key.Zero()
+
+	return ks.Import(key, password)
+}
+
+// Accounts returns the addresses of the key files in the keystore, sorted. Files that are not
+// key files are skipped.
+func (ks *KeyStore) Accounts() ([]Address, error) {
+	files, err := ks.files()
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]Address, 0, len(files))
+	for a := range files {
+		accounts = append(accounts, a)
+	}
+	sort.Slice(accounts, func(i, j int) bool { return accounts[i].String() < accounts[j].String() })
+
+	return accounts, nil
+}
+
+// Unlock decrypts the key of address and keeps it in memory for timeout, or until Lock if timeout is 0.
+// Unlocking an unlocked account replaces its timeout.
+func (ks *KeyStore) Unlock(address Address, password string, timeout time.Duration) error {
+	path, err := ks.find(address)
+	if err != nil {
+		return err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	key, err := DecryptKey(b, password)
+	if err != nil {
+		return err
+	}
+
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	ks.lock(address)
+	u := &unlockedKey{key: key}
+	if timeout > 0 {
+		u.timer = time.AfterFunc(timeout, func() {
+			ks.mu.Lock()
+			defer ks.mu.Unlock()
+
+			// the account may have been unlocked again since
+			if ks.unlocked[address] == u {
+				ks.lock(address)
+			}
+		})
+	}
+	ks.unlocked[address] = u
+
+	return nil
+}
+
+// Lock zeroes the key of address. Locking a locked account does nothing.
+func (ks *KeyStore) Lock(address Address) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	ks.lock(address)
+}
+
+// Close locks every account.
+func (ks *KeyStore) Close() {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	for a := range ks.unlocked {
+		ks.lock(a)
+	}
+}
+
+// Unlocked reports whether address is unlocked.
+func (ks *KeyStore) Unlocked(address Address) bool {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	_, ok := ks.unlocked[address]
+
+	return ok
+}
+
+// SignTransaction appends the signature of the unlocked key of address to tx.
+func (ks *KeyStore) SignTransaction(address Address, tx *Transaction) error {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	u, ok := ks.unlocked[address]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrLocked, address)
+	}
+
+	return SignTransaction(tx, u.key)
+}
+
+// SignHash signs a 32 byte hash with the unlocked key of address.
+func (ks *KeyStore) SignHash(address Address, hash []byte) ([]byte, error) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	u, ok := ks.unlocked[address]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrLocked, address)
+	}
+
+	return u.key.Sign(hash)
+}
+
+// SignTransactionWithPassphrase signs tx with the key of address without unlocking it:
+// the key is decrypted, used once and zeroed.
+func (ks *KeyStore) SignTransactionWithPassphrase(address Address, password string, tx *Transaction) error {
+	path, err := ks.find(address)
+	if err != nil {
+		return err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	key, err := DecryptKey(b, password)
+	if err != nil {
+		return err
+	}
+	defer key.Zero()
+
+	return SignTransaction(tx, key)
+}
+
+// Delete removes the key file of address after checking password, locking the account.
+func (ks *KeyStore) Delete(address Address, password string) error {
+	path, err := ks.find(address)
+	if err != nil {
+		return err
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	key, err := DecryptKey(b, password)
+	if err != nil {
+		return err
+	}
+	key.Zero()
+
+	ks.Lock(address)
+
+	return os.Remove(path)
+}
+
+// lock zeroes and forgets the key of address. ks.mu must be held.
+func (ks *KeyStore) lock(address Address) {
+	u, ok := ks.unlocked[address]
+	if !ok {
+		return
+	}
+	if u.timer != nil {
+		u.timer.Stop()
+	}
+	u.key.Zero()
+	delete(ks.unlocked, address)
+}
+
+// find returns the path of the key file of address.
+func (ks *KeyStore) find(address Address) (string, error) {
+	files, err := ks.files()
+	if err != nil {
+		return "", err
+	}
+
+	path, ok := files[address]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownAccount, address)
+	}
+
+	return path, nil
+}
+
+// files returns the key files of the keystore by address. The directory is read on every call,
+// so files added or removed by other processes are seen.
+func (ks *KeyStore) files() (map[Address]string, error) {
+	entries, err := os.ReadDir(ks.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[Address]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[Address]string, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(ks.dir, e.Name())
+		b, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var v struct {
+			Address string `json:"address"`
+			Version int    `json:"version"`
+		}
+		if json.Unmarshal(b, &v) != nil || v.Version != keyFileVersion {
+			continue
+		}
+		if a, err := parseKeyFileAddress(v.Address); err == nil {
+			files[a] = path
+		}
+	}
+
+	return files, nil
+}
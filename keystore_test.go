@@ -0,0 +1,203 @@
+package trongrid_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+// keyFileVectors are the test vectors of the Web3 Secret Storage definition.
+var keyFileVectors = map[string]string{
+	"pbkdf2": `{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "6087dab2f9fdbbfaddc31a909735c1e6"},
+			"ciphertext": "5318b4d5bcd28de64ee5559e671353e16f075ecae9f99c7a79a38af5f869aa46",
+			"kdf": "pbkdf2",
+			"kdfparams": {
+				"c": 262144,
+				"dklen": 32,
+				"prf": "hmac-sha256",
+				"salt": "ae3cd4e7013836a3df6bd7241b12db061dbe2c6785853cce422d148a624ce0bd"
+			},
+			"mac": "517ead924a9d0dc3124507e3393d175ce3ff7c1e96529c6c555ce9e51205e9b2"
+		},
+		"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version": 3
+	}`,
+	"scrypt": `{
+		"crypto": {
+			"cipher": "aes-128-ctr",
+			"cipherparams": {"iv": "83dbcc02d8ccb40e466191a123791e0e"},
+			"ciphertext": "d172bf743a674da9cdad04534d56926ef8358534d458fffccd4e6ad2fbde479c",
+			"kdf": "scrypt",
+			"kdfparams": {
+				"dklen": 32,
+				"n": 262144,
+				"r": 1,
+				"p": 8,
+				"salt": "ab0c7876052600dd703518d6fc3fe8984592145b591fc8fb5c6d43190334ba19"
+			},
+			"mac": "2103ac29920d71da29f15d75b4a16dbe95cfd7ff8faea1056c33131d846e3097"
+		},
+		"id": "3198bc9c-6672-5ab3-d995-4942343ae5b6",
+		"version": 3
+	}`,
+}
+
+func TestDecryptKey(t *testing.T) {
+	for kdf, keyJSON := range keyFileVectors {
+		key, err := trongrid.DecryptKey([]byte(keyJSON), "testpassword")
+		require.NoError(t, err, kdf)
+		assert.Equal(t, "7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d", key.Hex(), kdf)
+
+		_, err = trongrid.DecryptKey([]byte(keyJSON), "wrongpassword")
+		require.ErrorIs(t, err, trongrid.ErrDecrypt, kdf)
+	}
+
+	_, err := trongrid.DecryptKey([]byte(`{"version": 1}`), "testpassword")
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+}
+
+func TestEncryptKey(t *testing.T) {
+	key := mustGenerateKey(t)
+
+	keyJSON, err := trongrid.EncryptKey(key, "secret", trongrid.LightScryptN, trongrid.LightScryptP)
+	require.NoError(t, err)
+
+	var v struct {
+		Address string `json:"address"`
+		Version int    `json:"version"`
+	}
+	require.NoError(t, json.Unmarshal(keyJSON, &v))
+	assert.Equal(t, key.Address().String(), v.Address)
+	assert.Equal(t, 3, v.Version)
+
+	decrypted, err := trongrid.DecryptKey(keyJSON, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, key.Hex(), decrypted.Hex())
+
+	_, err = trongrid.DecryptKey(keyJSON, "")
+	require.ErrorIs(t, err, trongrid.ErrDecrypt)
+}
+
+func TestKeyStore(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keystore")
+	ks := trongrid.NewKeyStore(dir, trongrid.WithScrypt(trongrid.LightScryptN, trongrid.LightScryptP))
+	defer ks.Close()
+
+	accounts, err := ks.Accounts()
+	require.NoError(t, err)
+	assert.Empty(t, accounts)
+
+	a, err := ks.NewAccount("secret")
+	require.NoError(t, err)
+	key := mustGenerateKey(t)
+	b, err := ks.Import(key, "other")
+	require.NoError(t, err)
+	_, err = ks.Import(key, "other")
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+
+	// files that are not key files are skipped
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("hello"), 0o600))
+
+	accounts, err = ks.Accounts()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []trongrid.Address{a, b}, accounts)
+
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	for _, f := range files {
+		if f.Name() == "notes.txt" {
+			continue
+		}
+		info, err := f.Info()
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+
+	tx := newOfflineTransfer(t, b)
+
+	require.ErrorIs(t, ks.SignTransaction(b, tx), trongrid.ErrLocked)
+	require.ErrorIs(t, ks.Unlock(b, "secret", 0), trongrid.ErrDecrypt)
+	require.ErrorIs(t, ks.Unlock(mustGenerateKey(t).Address(), "other", 0), trongrid.ErrUnknownAccount)
+	_, err = ks.SignHash(a, make([]byte, 32))
+	require.ErrorIs(t, err, trongrid.ErrLocked)
+
+	require.NoError(t, ks.Unlock(b, "other", 0))
+	assert.True(t, ks.Unlocked(b))
+	require.NoError(t, ks.SignTransaction(b, tx))
+	signers, err := trongrid.TransactionSigners(tx)
+	require.NoError(t, err)
+	assert.Equal(t, []trongrid.Address{b}, signers)
+
+	ks.Lock(b)
+	assert.False(t, ks.Unlocked(b))
+	require.ErrorIs(t, ks.SignTransaction(b, tx), trongrid.ErrLocked)
+
+	// signing with the password does not unlock the account
+	tx = newOfflineTransfer(t, a)
+	require.ErrorIs(t, ks.SignTransactionWithPassphrase(a, "other", tx), trongrid.ErrDecrypt)
+	require.NoError(t, ks.SignTransactionWithPassphrase(a, "secret", tx))
+	assert.False(t, ks.Unlocked(a))
+	signers, err = trongrid.TransactionSigners(tx)
+	require.NoError(t, err)
+	assert.Equal(t, []trongrid.Address{a}, signers)
+
+	// a key file exported by another keystore imports into this one
+	other := trongrid.NewKeyStore(t.TempDir(), trongrid.WithScrypt(trongrid.LightScryptN, trongrid.LightScryptP))
+	keyJSON, err := trongrid.EncryptKey(key, "other", trongrid.LightScryptN, trongrid.LightScryptP)
+	require.NoError(t, err)
+	imported, err := other.ImportKeyFile(keyJSON, "other")
+	require.NoError(t, err)
+	assert.Equal(t, b, imported)
+
+	require.ErrorIs(t, ks.Delete(a, "other"), trongrid.ErrDecrypt)
+	require.NoError(t, ks.Delete(a, "secret"))
+	accounts, err = ks.Accounts()
+	require.NoError(t, err)
+	assert.Equal(t, []trongrid.Address{b}, accounts)
+}
+
+func TestKeyStore_UnlockTimeout(t *testing.T) {
+	ks := trongrid.NewKeyStore(t.TempDir(), trongrid.WithScrypt(trongrid.LightScryptN, trongrid.LightScryptP))
+	defer ks.Close()
+
+	a, err := ks.NewAccount("secret")
+	require.NoError(t, err)
+
+	require.NoError(t, ks.Unlock(a, "secret", 50*time.Millisecond))
+	assert.True(t, ks.Unlocked(a))
+	assert.Eventually(t, func() bool { return !ks.Unlocked(a) }, time.Second, 10*time.Millisecond)
+
+	// unlocking again replaces the timeout
+	require.NoError(t, ks.Unlock(a, "secret", 50*time.Millisecond))
+	require.NoError(t, ks.Unlock(a, "secret", 0))
+	assert.Never(t, func() bool { return !ks.Unlocked(a) }, 200*time.Millisecond, 10*time.Millisecond)
+
+	ks.Close()
+	assert.False(t, ks.Unlocked(a))
+}
+
+// newOfflineTransfer returns an unsigned transfer from owner built without a node.
+func newOfflineTransfer(t *testing.T, owner trongrid.Address) *trongrid.Transaction {
+	t.Helper()
+
+	contract, err := trongrid.NewTransferContract(owner.String(), mustGenerateKey(t).Address().String(), 1_000)
+	require.NoError(t, err)
+	tx, err := trongrid.BuildTransaction(&trongrid.OfflineTransactionRequest{
+		RefBlock:   trongrid.RefBlock{Bytes: "a2b3", Hash: "c4d5e6f708192a3b"},
+		Contract:   contract,
+		Expiration: time.Now().Add(time.Minute),
+	})
+	require.NoError(t, err)
+
+	return tx
+}
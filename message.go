@@ -0,0 +1,94 @@
+package trongrid
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+)
+
+// MessagePrefix is prepended to signed messages (TIP-191), so that a signed message is never a valid
+// transaction signature.
+const MessagePrefix = "\x19TRON Signed Message:\n"
+
+// MessageHash returns the hash SignMessage signs: keccak256 of MessagePrefix, the decimal length of message
+// and message. It matches tronWeb.trx.signMessageV2.
+func MessageHash(message []byte) []byte {
+	return Keccak256([]byte(MessagePrefix+strconv.Itoa(len(message))), message)
+}
+
+// MessageHashV1 returns the hash SignMessageV1 signs, which matches tronWeb.trx.sign of a hex string:
+// MessagePrefix is always followed by "32", the length of the hash the message usually is.
+func MessageHashV1(message []byte) []byte {
+	return Keccak256([]byte(MessagePrefix+"32"), message)
+}
+
+// SignMessage signs message with key and returns the 0x prefixed hex signature TronWeb returns,
+// r || s || v with v 27 or 28. Use it to prove ownership of an address, e.g. by signing a login challenge.
+func SignMessage(message []byte, key *PrivateKey) (string, error) {
+	return signHash(MessageHash(message), key)
+}
+
+// SignMessageV1 signs message with the v1 convention of TronWeb. message is the decoded hex string
+// TronWeb signs, usually a 32 byte hash.
+func SignMessageV1(message []byte, key *PrivateKey) (string, error) {
+	return signHash(MessageHashV1(message), key)
+}
+
+// VerifyMessage checks that signature, from SignMessage or tronWeb.trx.signMessageV2, was made by address
+// over message. It returns ErrInvalidSignature otherwise.
+func VerifyMessage(message []byte, signature string, address Address) error {
+	return verifyHash(MessageHash(message), signature, address)
+}
+
+// VerifyMessageV1 checks that signature, from SignMessageV1 or tronWeb.trx.sign, was made by address
+// over message.
+func VerifyMessageV1(message []byte, signature string, address Address) error {
+	return verifyHash(MessageHashV1(message), signature, address)
+}
+
+// RecoverMessageSigner returns the address that signed message with SignMessage, as
+// tronWeb.trx.verifyMessageV2.
+func RecoverMessageSigner(message []byte, signature string) (Address, error) {
+	sig, err := decodeSignature(signature)
+	if err != nil {
+		return Address{}, err
+	}
+
+	return RecoverAddress(MessageHash(message), sig)
+}
+
+func signHash(hash []byte, key *PrivateKey) (string, error) {
+	sig, err := key.Sign(hash)
+	if err != nil {
+		return "", err
+	}
+
+	return "0x" + hex.EncodeToString(sig), nil
+}
+
+func verifyHash(hash []byte, signature string, address Address) error {
+	sig, err := decodeSignature(signature)
+	if err != nil {
+		return err
+	}
+
+	signer, err := RecoverAddress(hash, sig)
+	if err != nil {
+		return err
+	}
+	if signer != address {
+		return fmt.Errorf("%w: signed by %s, not %s", ErrInvalidSignature, signer, address)
+	}
+
+	return nil
+}
+
+// decodeSignature decodes a hex signature, with or without the 0x prefix.
+func decodeSignature(signature string) ([]byte, error) {
+	sig, err := hex.DecodeString(trimHexPrefix(signature))
+	if err != nil || len(sig) != SignatureLength {
+		return nil, fmt.Errorf("%w: must be %d hex encoded bytes", ErrInvalidSignature, SignatureLength)
+	}
+
+	return sig, nil
+}
@@ -0,0 +1,62 @@
+package trongrid_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+func TestMessageHash(t *testing.T) {
+	assert.Equal(t,
+		trongrid.Keccak256([]byte("\x19TRON Signed Message:\n5hello")),
+		trongrid.MessageHash([]byte("hello")))
+
+	// v1 always claims a 32 byte message
+	assert.Equal(t,
+		trongrid.Keccak256([]byte("\x19TRON Signed Message:\n32hello")),
+		trongrid.MessageHashV1([]byte("hello")))
+}
+
+func TestSignMessage(t *testing.T) {
+	key := mustGenerateKey(t)
+	other := mustGenerateKey(t)
+	challenge := []byte("Sign in to example.com\nnonce: 4f1c2a")
+
+	sig, err := trongrid.SignMessage(challenge, key)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(sig, "0x"))
+	assert.Len(t, sig, 2+2*trongrid.SignatureLength)
+
+	require.NoError(t, trongrid.VerifyMessage(challenge, sig, key.Address()))
+	require.NoError(t, trongrid.VerifyMessage(challenge, strings.TrimPrefix(sig, "0x"), key.Address()))
+	require.ErrorIs(t, trongrid.VerifyMessage(challenge, sig, other.Address()), trongrid.ErrInvalidSignature)
+	require.ErrorIs(t, trongrid.VerifyMessage([]byte("another"), sig, key.Address()), trongrid.ErrInvalidSignature)
+	require.ErrorIs(t, trongrid.VerifyMessage(challenge, "0x1234", key.Address()), trongrid.ErrInvalidSignature)
+	require.ErrorIs(t, trongrid.VerifyMessageV1(challenge, sig, key.Address()), trongrid.ErrInvalidSignature)
+
+	signer, err := trongrid.RecoverMessageSigner(challenge, sig)
+	require.NoError(t, err)
+	assert.Equal(t, key.Address(), signer)
+}
+
+func TestSignMessageV1(t *testing.T) {
+	key := mustGenerateKey(t)
+	message, err := hex.DecodeString("e1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f90")
+	require.NoError(t, err)
+
+	sig, err := trongrid.SignMessageV1(message, key)
+	require.NoError(t, err)
+	require.NoError(t, trongrid.VerifyMessageV1(message, sig, key.Address()))
+	// both conventions agree on 32 byte messages only
+	require.NoError(t, trongrid.VerifyMessage(message, sig, key.Address()))
+
+	sig, err = trongrid.SignMessageV1(message[:20], key)
+	require.NoError(t, err)
+	require.NoError(t, trongrid.VerifyMessageV1(message[:20], sig, key.Address()))
+	require.ErrorIs(t, trongrid.VerifyMessage(message[:20], sig, key.Address()), trongrid.ErrInvalidSignature)
+}
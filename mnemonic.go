@@ -0,0 +1,147 @@
+package trongrid
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// ErrInvalidMnemonic is returned for mnemonics with unknown words, a wrong length or a bad checksum.
+var ErrInvalidMnemonic = errors.New("invalid mnemonic")
+
+const (
+	// mnemonicSeedIterations is the PBKDF2 iteration count of BIP39 seeds
+	mnemonicSeedIterations = 2048
+	// mnemonicSeedLength is the length of a BIP39 seed, in bytes
+	mnemonicSeedLength = 64
+)
+
+// bip39English is the BIP39 English wordlist, one word per line.
+//
+//go:embed bip39_english.txt
+var bip39English string
+
+var (
+	mnemonicWords   = strings.Fields(bip39English)
+	mnemonicIndexes = func() map[string]int {
+		indexes := make(map[string]int, len(mnemonicWords))
+		for i, w := range mnemonicWords {
+			indexes[w] = i
+		}
+
+		return indexes
+	}()
+)
+
+// NewMnemonic returns a random English BIP39 mnemonic encoding bits of entropy: 128 bits give
+// 12 words and 256 bits 24 words.
+func NewMnemonic(bits int) (string, error) {
+	if bits < 128 || bits > 256 || bits%32 != 0 {
+		return "", fmt.Errorf("%w: entropy must be 128 to 256 bits, a multiple of 32", ErrInvalidMnemonic)
+	}
+
+	entropy := make([]byte, bits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", err
+	}
+	defer zero(entropy)
+
+	return MnemonicFromEntropy(entropy)
+}
+
+// MnemonicFromEntropy returns the English BIP39 mnemonic of 16 to 32 bytes of entropy.
+func MnemonicFromEntropy(entropy []byte) (string, error) {
+	n := len(entropy)
+	if n < 16 || n > 32 || n%4 != 0 {
+		return "", fmt.Errorf("%w: entropy must be 16 to 32 bytes, a multiple of 4", ErrInvalidMnemonic)
+	}
+
+	// the checksum is the first n*8/32 bits of the hash, appended to the entropy
+	sum := sha256.Sum256(entropy)
+	b := append(append(make([]byte, 0, n+1), entropy...), sum[0])
+	defer zero(b)
+
+	words := make([]string, (n*8+n/4)/11)
+	for i := range words {
+		var index int
+		for bit := i * 11; bit < (i+1)*11; bit++ {
+			index = index<<1 | int(b[bit/8]>>(7-bit%8)&1)
+		}
+		words[i] = mnemonicWords[index]
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicToEntropy returns the entropy encoded by mnemonic, checking its checksum.
+func MnemonicToEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	if len(words) < 12 || len(words) > 24 || len(words)%3 != 0 {
+		return nil, fmt.Errorf("%w: %d words", ErrInvalidMnemonic, len(words))
+	}
+
+	bits := len(words) * 11
+	b := make([]byte, (bits+7)/8)
+	defer zero(b)
+	for i, w := range words {
+		index, ok := mnemonicIndexes[strings.ToLower(w)]
+		if !ok {
+			return nil, fmt.Errorf("%w: unknown word %d", ErrInvalidMnemonic, i+1)
+		}
+		for j := 0; j < 11; j++ {
+			if index&(1<<(10-j)) != 0 {
+				bit := i*11 + j
+				b[bit/8] |= 1 << (7 - bit%8)
+			}
+		}
+	}
+
+	n := bits * 32 / 33 / 8
+	entropy := append([]byte(nil), b[:n]...)
+	sum := sha256.Sum256(entropy)
+	checksumBits := n / 4
+	if b[n]>>(8-checksumBits) != sum[0]>>(8-checksumBits) {
+		zero(entropy)
+		return nil, fmt.Errorf("%w: bad checksum", ErrInvalidMnemonic)
+	}
+
+	return entropy, nil
+}
+
+// ValidateMnemonic checks the words and the checksum of mnemonic.
+func ValidateMnemonic(mnemonic string) error {
+	entropy, err := MnemonicToEntropy(mnemonic)
+	if err != nil {
+		return err
+	}
+	zero(entropy)
+
+	return nil
+}
+
+// MnemonicToSeed validates mnemonic and returns its 64 byte BIP39 seed, the input of NewMasterKey.
+// The passphrase is optional and any passphrase gives a valid, different wallet. It is used as given:
+// normalize non-ASCII passphrases to NFKD first, e.g. with golang.org/x/text/unicode/norm.
+func MnemonicToSeed(mnemonic, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+
+	normalized := strings.ToLower(strings.Join(strings.Fields(mnemonic), " "))
+
+	return pbkdf2.Key([]byte(normalized), []byte("mnemonic"+passphrase), mnemonicSeedIterations,
+		mnemonicSeedLength, sha512.New), nil
+}
+
+// zero overwrites b, e.g. entropy or key material that is no longer needed.
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
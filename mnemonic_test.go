@@ -0,0 +1,89 @@
+package trongrid_test
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+func TestMnemonicFromEntropy(t *testing.T) {
+	// test vectors from https://github.com/trezor/python-mnemonic/blob/master/vectors.json
+	tests := []struct {
+		entropy  string
+		mnemonic string
+		seed     string
+	}{
+		{
+			entropy:  "00000000000000000000000000000000",
+			mnemonic: "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			seed: "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141" +
+				"630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			entropy:  "7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			mnemonic: "legal winner thank year wave sausage worth useful legal winner thank yellow",
+			seed: "2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069b" +
+				"e3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			entropy:  "ffffffffffffffffffffffffffffffff",
+			mnemonic: "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo wrong",
+			seed: "ac27495480225222079d7be181583751e86f571027b0497b5b5d11218e0a8a13332572917f0f8e5a589620c6f15b11c6" +
+				"1dee327651a14c34e18231052e48c069",
+		},
+		{
+			entropy:  strings.Repeat("00", 32),
+			mnemonic: strings.Repeat("abandon ", 23) + "art",
+			seed: "bda85446c68413707090a52022edd26a1c9462295029f2e60cd7c4f2bbd3097170af7a4d73245cafa9c3cca8d561a7c3" +
+				"de6f5d4a10be8ed2a5e608d68f92fcc8",
+		},
+	}
+	for _, tt := range tests {
+		entropy, err := hex.DecodeString(tt.entropy)
+		require.NoError(t, err)
+
+		mnemonic, err := trongrid.MnemonicFromEntropy(entropy)
+		require.NoError(t, err)
+		assert.Equal(t, tt.mnemonic, mnemonic)
+
+		decoded, err := trongrid.MnemonicToEntropy(mnemonic)
+		require.NoError(t, err)
+		assert.Equal(t, entropy, decoded)
+
+		seed, err := trongrid.MnemonicToSeed(mnemonic, "TREZOR")
+		require.NoError(t, err)
+		assert.Equal(t, tt.seed, hex.EncodeToString(seed))
+	}
+}
+
+func TestNewMnemonic(t *testing.T) {
+	mnemonic, err := trongrid.NewMnemonic(256)
+	require.NoError(t, err)
+	assert.Len(t, strings.Fields(mnemonic), 24)
+	require.NoError(t, trongrid.ValidateMnemonic(mnemonic))
+
+	other, err := trongrid.NewMnemonic(128)
+	require.NoError(t, err)
+	assert.Len(t, strings.Fields(other), 12)
+	assert.NotEqual(t, mnemonic, other)
+
+	_, err = trongrid.NewMnemonic(100)
+	require.ErrorIs(t, err, trongrid.ErrInvalidMnemonic)
+}
+
+func TestValidateMnemonic(t *testing.T) {
+	require.NoError(t, trongrid.ValidateMnemonic("  Legal winner thank year wave sausage\nworth useful legal winner thank yellow"))
+
+	// wrong checksum word
+	require.ErrorIs(t, trongrid.ValidateMnemonic(strings.Repeat("abandon ", 12)), trongrid.ErrInvalidMnemonic)
+	require.ErrorIs(t, trongrid.ValidateMnemonic(strings.Repeat("abandon ", 11)+"tron"), trongrid.ErrInvalidMnemonic)
+	require.ErrorIs(t, trongrid.ValidateMnemonic("abandon about"), trongrid.ErrInvalidMnemonic)
+
+	_, err := trongrid.MnemonicToSeed(strings.Repeat("zoo ", 12), "")
+	require.ErrorIs(t, err, trongrid.ErrInvalidMnemonic)
+}
@@ -0,0 +1,126 @@
+package trongrid
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Network describes a TRON network known to the client.
+type Network struct {
+	// Name is one of Mainnet, Testnet or Nile
+	Name string
+	// URI is the TronGrid base URI
+	URI string
+	// ChainID is the EVM-compatible chain id used for TIP-712 signing
+	ChainID uint64
+	// ExplorerURI is the Tronscan base URI
+	ExplorerURI string
+	// Tokens are the well-known TRC20 tokens keyed by symbol
+	Tokens map[string]Token
+}
+
+var networks = map[string]*Network{
+	Mainnet: {
+		Name:        Mainnet,
+		URI:         MainnetURI,
+		ChainID:     0x2b6653dc,
+		ExplorerURI: "https://tronscan.org",
+		Tokens: map[string]Token{
+			"USDT": {Address: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", Name: "Tether USD", Symbol: "USDT", Decimals: 6},
+			"USDC": {Address: "TEkxiTehnzSmSe2XqrBj4w32RUN966rdz8", Name: "USD Coin", Symbol: "USDC", Decimals: 6},
+			"WTRX": {Address: "TNUC9Qb1rRpS5CbWLmNMxXBjyFoydXjWFR", Name: "Wrapped TRX", Symbol: "WTRX", Decimals: 6},
+			"BTT":  {Address: "TAFjULxiVgT4qWk6UZwjqwZXTSaGaqnVp4", Name: "BitTorrent", Symbol: "BTT", Decimals: 18},
+			"JST":  {Address: "TCFLL5dx5ZJdKnWuesXxi1VPwjLVmWZZy9", Name: "JUST", Symbol: "JST", Decimals: 18},
+			"SUN":  {Address: "TSSMHYeV2uE9qYH95DqyoCuNCzEL1NvU3S", Name: "SUN", Symbol: "SUN", Decimals: 18},
+		},
+	},
+	Testnet: {
+		Name:        Testnet,
+		URI:         TestnetURI,
+		ChainID:     0x94a9059e,
+		ExplorerURI: "https://shasta.tronscan.org",
+		Tokens: map[string]Token{
+			"USDT": {Address: "TG3XXyExBkPp9nzdajDZsozEu4BkaSJozs", Name: "Tether USD", Symbol: "USDT", Decimals: 6},
+		},
+	},
+	Nile: {
+		Name:        Nile,
+		URI:         NileURI,
+		ChainID:     0xcd8690dc,
+		ExplorerURI: "https://nile.tronscan.org",
+		Tokens: map[string]Token{
+			"USDT": {Address: "TXYZopYRdj2D9XRtbG411XZZ3kM5VkAeBf", Name: "Tether USD", Symbol: "USDT", Decimals: 6},
+		},
+	},
+}
+
+// GetNetwork returns the preset for the given network name (case-insensitive).
+func GetNetwork(name string) (*Network, error) {
+	n, ok := networks[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown network %q", ErrInvalidRequest, name)
+	}
+
+	return n.clone(), nil
+}
+
+func (n *Network) clone() *Network {
+	c := *n
+	c.Tokens = make(map[string]Token, len(n.Tokens))
+	for k, v := range n.Tokens {
+		c.Tokens[k] = v
+	}
+
+	return &c
+}
+
+// networkByURI returns the preset whose URI matches uri, or a custom network
+// without a name, chain id or known tokens.
+func networkByURI(uri string) *Network {
+	uri = strings.TrimRight(uri, "/")
+	for _, n := range networks {
+		if n.URI == uri {
+			return n.clone()
+		}
+	}
+
+	return &Network{URI: uri, Tokens: map[string]Token{}}
+}
+
+// Token returns the well-known token with the given symbol.
+func (n *Network) Token(symbol string) (Token, bool) {
+	t, ok := n.Tokens[strings.ToUpper(symbol)]
+
+	return t, ok
+}
+
+// TokenByAddress returns the well-known token deployed at address.
+func (n *Network) TokenByAddress(address string) (Token, bool) {
+	for _, t := range n.Tokens {
+		if t.Address == address {
+			return t, true
+		}
+	}
+
+	return Token{}, false
+}
+
+// TransactionURL returns the explorer link of a transaction.
+func (n *Network) TransactionURL(txID string) string {
+	return n.ExplorerURI + "/#/transaction/" + txID
+}
+
+// AddressURL returns the explorer link of an account or contract.
+func (n *Network) AddressURL(address string) string {
+	return n.ExplorerURI + "/#/address/" + address
+}
+
+// BlockURL returns the explorer link of a block.
+func (n *Network) BlockURL(number int64) string {
+	return fmt.Sprintf("%s/#/block/%d", n.ExplorerURI, number)
+}
+
+// TokenURL returns the explorer link of a TRC20 token.
+func (n *Network) TokenURL(address string) string {
+	return n.ExplorerURI + "/#/token20/" + address
+}
@@ -0,0 +1,49 @@
+package trongrid_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+func TestGetNetwork(t *testing.T) {
+	n, err := trongrid.GetNetwork("Nile")
+	require.NoError(t, err)
+	assert.Equal(t, trongrid.NileURI, n.URI)
+	assert.Equal(t, "https://nile.tronscan.org/#/transaction/abc", n.TransactionURL("abc"))
+
+	usdt, ok := n.Token("usdt")
+	require.True(t, ok)
+	assert.EqualValues(t, 6, usdt.Decimals)
+
+	_, err = trongrid.GetNetwork("devnet")
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+}
+
+func TestWithNetwork(t *testing.T) {
+	api := trongrid.NewAPI(trongrid.WithNetwork(trongrid.Testnet))
+	assert.Equal(t, trongrid.Testnet, api.Network().Name)
+	assert.Equal(t, trongrid.TestnetURI, api.Network().URI)
+
+	api = trongrid.NewAPI()
+	assert.Equal(t, trongrid.Mainnet, api.Network().Name)
+
+	api = trongrid.NewAPI(trongrid.WithURI("http://localhost:8090"))
+	assert.Empty(t, api.Network().Name)
+}
+
+func TestWithNetwork_Unknown(t *testing.T) {
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	// a misspelled network must not fall back to mainnet
+	api := srv.Client(trongrid.WithNetwork("nlie"))
+	_, err := api.GetNowBlock(context.Background(), false)
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+	assert.Empty(t, srv.Requests())
+}
@@ -0,0 +1,180 @@
+package trongrid
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// RefBlock is the recent block a transaction references. The network only accepts transactions
+// referencing one of its last 65536 blocks, about two days.
+type RefBlock struct {
+	// Bytes is ref_block_bytes: bytes 6 and 7 of the big endian block number, hex encoded
+	Bytes string `json:"ref_block_bytes"`
+	// Hash is ref_block_hash: bytes 8 to 15 of the block ID, hex encoded
+	Hash string `json:"ref_block_hash"`
+}
+
+// NewRefBlock returns the reference to b, e.g. the result of GetNowBlock fetched on an online machine.
+func NewRefBlock(b *Block) (RefBlock, error) {
+	id, err := hex.DecodeString(b.BlockID)
+	if err != nil || len(id) != 32 {
+		return RefBlock{}, fmt.Errorf("%w: block ID must be 32 hex encoded bytes", ErrInvalidRequest)
+	}
+
+	var num [8]byte
+	binary.BigEndian.PutUint64(num[:], uint64(b.BlockHeader.RawData.Number))
+
+	return RefBlock{Bytes: hex.EncodeToString(num[6:8]), Hash: hex.EncodeToString(id[8:16])}, nil
+}
+
+type OfflineTransactionRequest struct {
+	RefBlock RefBlock
+	Contract Contract
+	// Expiration is required, at most MaxTransactionLifetime hours after Timestamp
+	Expiration time.Time
+	// Timestamp is the creation time of the transaction, now if zero
+	Timestamp time.Time
+	// FeeLimit is the most a smart contract call may burn, in sun
+	FeeLimit int64
+	// Memo is stored in raw_data.data and burns ChainParameters.MemoFee
+	Memo string
+}
+
+// BuildTransaction builds an unsigned transaction without calling a node: raw_data, raw_data_hex
+// and the txID are encoded locally, so it can run on an air-gapped machine. The contract types
+// SealTransaction supports can be built.
+func BuildTransaction(req *OfflineTransactionRequest) (*Transaction, error) {
+	if b, err := hex.DecodeString(req.RefBlock.Bytes); err != nil || len(b) != 2 {
+		return nil, fmt.Errorf("%w: ref_block_bytes must be 2 hex encoded bytes", ErrInvalidRequest)
+	}
+	if b, err := hex.DecodeString(req.RefBlock.Hash); err != nil || len(b) != 8 {
+		return nil, fmt.Errorf("%w: ref_block_hash must be 8 hex encoded bytes", ErrInvalidRequest)
+	}
+
+	timestamp := req.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	switch lifetime := req.Expiration.Sub(timestamp); {
+	case lifetime <= 0:
+		return nil, fmt.Errorf("%w: expiration must be after the timestamp", ErrInvalidRequest)
+	case lifetime > MaxTransactionLifetime*time.Hour:
+		return nil, fmt.Errorf("%w: expiration more than %d hours after the timestamp",
+			ErrInvalidRequest, MaxTransactionLifetime)
+	}
+
+	tx := &Transaction{
+		RawData: TransactionRawData{
+			Contract:      []Contract{req.Contract},
+			RefBlockBytes: req.RefBlock.Bytes,
+			RefBlockHash:  req.RefBlock.Hash,
+			Expiration:    req.Expiration.UnixMilli(),
+			Timestamp:     timestamp.UnixMilli(),
+			FeeLimit:      req.FeeLimit,
+			Data:          hex.EncodeToString([]byte(req.Memo)),
+		},
+	}
+	if err := SealTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// NewTransferContract returns the contract of a TRX transfer of amount sun.
+func NewTransferContract(owner, to string, amount int64) (Contract, error) {
+	if _, err := ParseAddress(owner); err != nil {
+		return Contract{}, err
+	}
+	if _, err := ParseAddress(to); err != nil {
+		return Contract{}, err
+	}
+	if amount <= 0 {
+		return Contract{}, fmt.Errorf("%w: amount must be positive", ErrInvalidRequest)
+	}
+
+	return Contract{
+		Type: ContractTypeTRX,
+		Parameter: ContractParameter{
+			Value: ContractValue{Amount: int(amount), OwnerAddress: owner, ToAddress: to},
+		},
+	}, nil
+}
+
+// NewTRC20TransferContract returns the contract of a transfer of amount base units of token.
+// BuildTransaction needs a FeeLimit for it.
+func NewTRC20TransferContract(owner, token, to string, amount *big.Int) (Contract, error) {
+	if _, err := ParseAddress(owner); err != nil {
+		return Contract{}, err
+	}
+	if _, err := ParseAddress(token); err != nil {
+		return Contract{}, err
+	}
+	parameter, err := EncodeTRC20Transfer(to, amount)
+	if err != nil {
+		return Contract{}, err
+	}
+
+	return Contract{
+		Type: ContractTypeTRC20,
+		Parameter: ContractParameter{
+			Value: ContractValue{
+				OwnerAddress:    owner,
+				ContractAddress: token,
+				Data:            hex.EncodeToString(Keccak256([]byte(TRC20TransferSelector))[:4]) + parameter,
+			},
+		},
+	}, nil
+}
+
+// offlineJSON is the exported form of a transaction: raw_data_hex is left out and encoded again on import.
+type offlineJSON struct {
+	TxID      string             `json:"txID"`
+	RawData   TransactionRawData `json:"raw_data"`
+	Signature []string           `json:"signature,omitempty"`
+}
+
+// EncodeTransaction exports tx as unpadded URL-safe base64 to carry to or from a cold signer,
+// e.g. in a QR code.
+func EncodeTransaction(tx *Transaction) (string, error) {
+	if _, err := TransactionHash(tx); err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(offlineJSON{TxID: tx.TxID, RawData: tx.RawData, Signature: tx.Signature})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeTransaction imports a transaction exported with EncodeTransaction. raw_data is encoded again,
+// so the signer signs exactly the transaction it decoded; a txID that does not match is rejected.
+func DecodeTransaction(s string) (*Transaction, error) {
+	b, err := base64.RawURLEncoding.DecodeString(strings.TrimSpace(s))
+	if err != nil {
+		return nil, fmt.Errorf("%w: transaction: %v", ErrInvalidRequest, err)
+	}
+
+	var v offlineJSON
+	if err = json.Unmarshal(b, &v); err != nil {
+		return nil, fmt.Errorf("%w: transaction: %v", ErrInvalidRequest, err)
+	}
+
+	tx := &Transaction{RawData: v.RawData, Signature: v.Signature}
+	if err = SealTransaction(tx); err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(tx.TxID, v.TxID) {
+		return nil, fmt.Errorf("%w: txID does not match raw_data", ErrInvalidRequest)
+	}
+
+	return tx, nil
+}
@@ -0,0 +1,116 @@
+package trongrid_test
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+func TestNewRefBlock(t *testing.T) {
+	block := &trongrid.Block{BlockID: "0000000003f1a2b3" + "c4d5e6f708192a3b" + strings.Repeat("00", 16)}
+	block.BlockHeader.RawData.Number = 0x03f1a2b3
+
+	ref, err := trongrid.NewRefBlock(block)
+	require.NoError(t, err)
+	assert.Equal(t, trongrid.RefBlock{Bytes: "a2b3", Hash: "c4d5e6f708192a3b"}, ref)
+
+	_, err = trongrid.NewRefBlock(&trongrid.Block{BlockID: "00"})
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+}
+
+func TestBuildTransaction(t *testing.T) {
+	ctx := context.Background()
+	chain := trongridtest.NewChain()
+
+	owner := mustGenerateKey(t)
+	to := mustGenerateKey(t)
+	require.NoError(t, chain.Fund(owner.Address().String(), 5_000_000))
+
+	// online: fetch the reference block
+	block, err := chain.GetNowBlock(ctx, false)
+	require.NoError(t, err)
+	ref, err := trongrid.NewRefBlock(block)
+	require.NoError(t, err)
+
+	// offline: build and sign
+	contract, err := trongrid.NewTransferContract(owner.Address().String(), to.Address().String(), 1_000_000)
+	require.NoError(t, err)
+	now := time.Now()
+	tx, err := trongrid.BuildTransaction(&trongrid.OfflineTransactionRequest{
+		RefBlock:   ref,
+		Contract:   contract,
+		Expiration: now.Add(10 * time.Minute),
+		Timestamp:  now,
+		Memo:       "invoice 42",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, tx.RawDataHex)
+	assert.Len(t, tx.TxID, 64)
+
+	exported, err := trongrid.EncodeTransaction(tx)
+	require.NoError(t, err)
+	unsigned, err := trongrid.DecodeTransaction(exported)
+	require.NoError(t, err)
+	assert.Equal(t, tx.RawDataHex, unsigned.RawDataHex)
+	require.NoError(t, trongrid.SignTransaction(unsigned, owner))
+	exported, err = trongrid.EncodeTransaction(unsigned)
+	require.NoError(t, err)
+
+	// online: broadcast
+	signed, err := trongrid.DecodeTransaction(exported)
+	require.NoError(t, err)
+	require.Len(t, signed.Signature, 1)
+	resp, err := chain.BroadcastTransaction(ctx, signed)
+	require.NoError(t, err)
+	assert.True(t, resp.Result)
+	chain.Produce()
+	assert.Equal(t, int64(1_000_000), chain.Balance(to.Address().String()))
+}
+
+func TestBuildTransaction_Invalid(t *testing.T) {
+	owner := mustGenerateKey(t)
+	contract, err := trongrid.NewTransferContract(owner.Address().String(), holderA, 1)
+	require.NoError(t, err)
+	ref := trongrid.RefBlock{Bytes: "a2b3", Hash: "c4d5e6f708192a3b"}
+	now := time.Now()
+
+	_, err = trongrid.BuildTransaction(&trongrid.OfflineTransactionRequest{RefBlock: ref, Contract: contract})
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+	_, err = trongrid.BuildTransaction(&trongrid.OfflineTransactionRequest{
+		RefBlock:   ref,
+		Contract:   contract,
+		Expiration: now.Add(25 * time.Hour),
+		Timestamp:  now,
+	})
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+	_, err = trongrid.BuildTransaction(&trongrid.OfflineTransactionRequest{
+		RefBlock:   trongrid.RefBlock{Bytes: "a2", Hash: ref.Hash},
+		Contract:   contract,
+		Expiration: now.Add(time.Minute),
+	})
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+
+	// a transaction changed after it was exported no longer matches its txID
+	tx, err := trongrid.BuildTransaction(&trongrid.OfflineTransactionRequest{
+		RefBlock:   ref,
+		Contract:   contract,
+		Expiration: now.Add(time.Minute),
+	})
+	require.NoError(t, err)
+	exported, err := trongrid.EncodeTransaction(tx)
+	require.NoError(t, err)
+	b, err := base64.RawURLEncoding.DecodeString(exported)
+	require.NoError(t, err)
+	tampered := strings.Replace(string(b), `"amount":1,`, `"amount":1000000,`, 1)
+	require.NotEqual(t, string(b), tampered)
+	_, err = trongrid.DecodeTransaction(base64.RawURLEncoding.EncodeToString([]byte(tampered)))
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+}
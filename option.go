@@ -1,6 +1,9 @@
 package trongrid
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/rs/zerolog"
 )
 
@@ -29,3 +32,115 @@ func WithURI(uri string) Option {
 		api.uri = uri
 	}
 }
+
+// WithRateLimiter sets the limiter every request waits on. Pass the same limiter
+// to several clients to share one API key quota; nil disables rate limiting.
+func WithRateLimiter(limiter RateLimiter) Option {
+	return func(api *api) {
+		api.limiter = limiter
+	}
+}
+
+// WithRateLimit allows rps requests per second with the given burst.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(api *api) {
+		api.limiter = NewRateLimiter(rps, burst)
+	}
+}
+
+// WithRetryPolicy sets how failed idempotent reads are retried.
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(api *api) {
+		api.retry = policy
+	}
+}
+
+// WithTimeout sets the timeout of a single HTTP attempt.
+func WithTimeout(timeout time.Duration) Option {
+	return func(api *api) {
+		api.timeout = timeout
+	}
+}
+
+// WithNetwork selects one of the Mainnet, Testnet or Nile presets. The preset URI is used
+// unless WithURI is also given, e.g. for a private node on that network.
+// An unknown name makes every call fail with ErrInvalidRequest rather than silently
+// using mainnet.
+func WithNetwork(name string) Option {
+	return func(api *api) {
+		n, err := GetNetwork(name)
+		if err != nil {
+			api.err = err
+			return
+		}
+		api.network = n
+	}
+}
+
+// WithHTTPClient sends requests through the given client, e.g. one configured with
+// a proxy or client certificates. A copy of the client is used, with the timeout of WithTimeout
+// and redirects disabled; client itself is not changed.
+func WithHTTPClient(client *http.Client) Option {
+	return func(api *api) {
+		api.httpClient = client
+	}
+}
+
+// WithTransport sends requests through the given round tripper.
+func WithTransport(transport http.RoundTripper) Option {
+	return func(api *api) {
+		api.transport = transport
+	}
+}
+
+// WithUserAgent sets the User-Agent header of every request.
+func WithUserAgent(userAgent string) Option {
+	return func(api *api) {
+		api.userAgent = userAgent
+	}
+}
+
+// WithBeforeRequest adds a hook called before every request attempt.
+func WithBeforeRequest(hook BeforeRequestHook) Option {
+	return func(api *api) {
+		api.beforeRequest = append(api.beforeRequest, hook)
+	}
+}
+
+// WithAfterResponse adds a hook called after every request attempt.
+func WithAfterResponse(hook AfterResponseHook) Option {
+	return func(api *api) {
+		api.afterResponse = append(api.afterResponse, hook)
+	}
+}
+
+// WithInstrumentation reports spans and metrics of every call to instrumentation.
+func WithInstrumentation(instrumentation Instrumentation) Option {
+	return func(api *api) {
+		api.instrumentation = instrumentation
+	}
+}
+
+// WithCache caches confirmed transactions, solidified blocks, contract ABIs and other
+// immutable or slow-changing data in cache. Unconfirmed data is never cached.
+func WithCache(cache Cache) Option {
+	return func(api *api) {
+		api.cache = cache
+	}
+}
+
+// WithoutCoalescing sends every read separately instead of sharing identical
+// in-flight requests between concurrent callers.
+func WithoutCoalescing() Option {
+	return func(api *api) {
+		api.coalesce = false
+	}
+}
+
+// WithTokenRegistry configures the registry resolving the decimals of TRC20 balances,
+// e.g. with overrides or a denylist of spoofed tokens.
+func WithTokenRegistry(opts ...TokenRegistryOption) Option {
+	return func(api *api) {
+		api.tokenOptions = append(api.tokenOptions, opts...)
+	}
+}
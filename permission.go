@@ -0,0 +1,276 @@
+package trongrid
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+)
+
+// Permission types.
+const (
+	PermissionOwner   = "Owner"
+	PermissionWitness = "Witness"
+	PermissionActive  = "Active"
+)
+
+const (
+	// OwnerPermissionID is the Permission_id of the owner permission, the default of every transaction
+	OwnerPermissionID = 0
+	// WitnessPermissionID is the Permission_id of the witness permission, used to produce blocks
+	WitnessPermissionID = 1
+	// MaxPermissionKeys is the most keys a permission can have
+	MaxPermissionKeys = 5
+	// MaxActivePermissions is the most active permissions an account can have
+	MaxActivePermissions = 8
+)
+
+// operationsSize is the length of the operations bitmask of an active permission, in bytes.
+const operationsSize = 32
+
+// operationIDs maps the names of every contract type to protocol.Transaction.Contract.ContractType,
+// the bit of the contract in the operations of a permission. Unlike contractTypeIDs,
+// it includes contracts that cannot be encoded locally.
+var operationIDs = map[string]uint64{
+	ContractTypeAccountCreate:       0,
+	ContractTypeTRX:                 1,
+	ContractTypeTRC10:               2,
+	"VoteAssetContract":             3,
+	ContractTypeVote:                4,
+	"WitnessCreateContract":         5,
+	"AssetIssueContract":            6,
+	"WitnessUpdateContract":         8,
+	"ParticipateAssetIssueContract": 9,
+	ContractTypeAccountUpdate:       10,
+	ContractTypeFreeze:              11,
+	ContractTypeUnfreeze:            12,
+	ContractTypeWithdrawBalance:     13,
+	"UnfreezeAssetContract":         14,
+	"UpdateAssetContract":           15,
+	"ProposalCreateContract":        16,
+	"ProposalApproveContract":       17,
+	"ProposalDeleteContract":        18,
+	"SetAccountIdContract":          19,
+	"CustomContract":                20,
+	"CreateSmartContract":           30,
+	ContractTypeTRC20:               31,
+	"GetContract":                   32,
+	"UpdateSettingContract":         33,
+	"ExchangeCreateContract":        41,
+	"ExchangeInjectContract":        42,
+	"ExchangeWithdrawContract":      43,
+	"ExchangeTransactionContract":   44,
+	"UpdateEnergyLimitContract":     45,
+
+	ContractTypeAccountPermissionUpdate: 46,
+	"ClearABIContract":                  48,
+	"UpdateBrokerageContract":           49,
+	"ShieldedTransferContract":          51,
+	"MarketSellAssetContract":           52,
+	"MarketCancelOrderContract":         53,
+	ContractTypeFreezeV2:                54,
+	ContractTypeUnfreezeV2:              55,
+	ContractTypeWithdrawExpireUnfreeze:  56,
+	ContractTypeDelegateResource:        57,
+	ContractTypeUndelegateResource:      58,
+	ContractTypeCancelAllUnfreezeV2:     59,
+}
+
+// permissionTypes maps permission types to protocol.Permission.PermissionType.
+var permissionTypes = map[string]uint64{
+	"":                0,
+	PermissionOwner:   0,
+	PermissionWitness: 1,
+	PermissionActive:  2,
+}
+
+// Permission is a set of keys allowed to sign transactions for an account once their weights reach Threshold.
+// The owner permission can sign every transaction and change permissions; an active permission
+// only signs the contract types in Operations.
+type Permission struct {
+	// Type is PermissionOwner, PermissionWitness or PermissionActive; empty is the owner
+	Type string `json:"type,omitempty"`
+	// ID is the Permission_id signing transactions use: 0 for the owner, 1 for the witness, 2 and up for actives
+	ID        int32           `json:"id,omitempty"`
+	Name      string          `json:"permission_name"`
+	Threshold int64           `json:"threshold"`
+	ParentID  int32           `json:"parent_id,omitempty"`
+	Keys      []PermissionKey `json:"keys"`
+	// Operations is the hex encoded bitmask of the contract types an active permission allows,
+	// see EncodeOperations
+	Operations string `json:"operations,omitempty"`
+}
+
+// PermissionKey is an address allowed to sign for a permission, with the weight of its signature.
+type PermissionKey struct {
+	Address string `json:"address"`
+	Weight  int64  `json:"weight"`
+}
+
+// EncodeOperations returns the operations bitmask allowing contractTypes, e.g. ContractTypeTRX.
+func EncodeOperations(contractTypes ...string) (string, error) {
+	b := make([]byte, operationsSize)
+	for _, typ := range contractTypes {
+		id, ok := operationIDs[typ]
+		if !ok {
+			return "", fmt.Errorf("%w: unknown contract type %s", ErrInvalidRequest, typ)
+		}
+		b[id/8] |= 1 << (id % 8)
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+// DecodeOperations returns the contract types an operations bitmask allows, sorted by ID.
+// Bits of unknown contract types are ignored.
+func DecodeOperations(operations string) ([]string, error) {
+	b, err := hex.DecodeString(operations)
+	if err != nil || len(b) != operationsSize {
+		return nil, fmt.Errorf("%w: operations must be %d hex encoded bytes", ErrInvalidRequest, operationsSize)
+	}
+
+	var types []string
+	for typ, id := range operationIDs {
+		if b[id/8]&(1<<(id%8)) != 0 {
+			types = append(types, typ)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool { return operationIDs[types[i]] < operationIDs[types[j]] })
+
+	return types, nil
+}
+
+// SetOperations allows contractTypes for an active permission, replacing its operations.
+func (p *Permission) SetOperations(contractTypes ...string) error {
+	operations, err := EncodeOperations(contractTypes...)
+	if err != nil {
+		return err
+	}
+	p.Operations = operations
+
+	return nil
+}
+
+// Allows reports whether the permission can sign a contract of type contractType.
+func (p *Permission) Allows(contractType string) bool {
+	if p.Type != PermissionActive {
+		// the owner signs everything and the witness only produces blocks
+		return p.Type != PermissionWitness
+	}
+
+	id, ok := operationIDs[contractType]
+	if !ok {
+		return false
+	}
+	b, err := hex.DecodeString(p.Operations)
+	if err != nil || len(b) != operationsSize {
+		return false
+	}
+
+	return b[id/8]&(1<<(id%8)) != 0
+}
+
+// Weight returns the weight of the signature of address, 0 if it is not a key of the permission.
+func (p *Permission) Weight(address Address) int64 {
+	for _, k := range p.Keys {
+		if a, err := ParseAddress(k.Address); err == nil && a == address {
+			return k.Weight
+		}
+	}
+
+	return 0
+}
+
+// Validate checks the permission against the rules of AccountPermissionUpdate.
+func (p *Permission) Validate() error {
+	if _, ok := permissionTypes[p.Type]; !ok {
+		return fmt.Errorf("%w: permission type %s", ErrInvalidRequest, p.Type)
+	}
+	if len(p.Keys) == 0 || len(p.Keys) > MaxPermissionKeys {
+		return fmt.Errorf("%w: permission %q must have 1 to %d keys", ErrInvalidRequest, p.Name, MaxPermissionKeys)
+	}
+
+	seen := make(map[Address]bool, len(p.Keys))
+	var sum int64
+	for _, k := range p.Keys {
+		a, err := ParseAddress(k.Address)
+		if err != nil {
+			return err
+		}
+		if seen[a] {
+			return fmt.Errorf("%w: permission %q has duplicate key %s", ErrInvalidRequest, p.Name, a)
+		}
+		seen[a] = true
+		if k.Weight <= 0 {
+			return fmt.Errorf("%w: permission %q: key weight must be positive", ErrInvalidRequest, p.Name)
+		}
+		sum += k.Weight
+	}
+
+	switch {
+	case p.Threshold <= 0:
+		return fmt.Errorf("%w: permission %q: threshold must be positive", ErrInvalidRequest, p.Name)
+	case sum < p.Threshold:
+		return fmt.Errorf("%w: permission %q: key weights are below the threshold", ErrInvalidRequest, p.Name)
+	case p.Type == PermissionActive:
+		if _, err := DecodeOperations(p.Operations); err != nil {
+			return err
+		}
+	case p.Operations != "":
+		return fmt.Errorf("%w: only active permissions have operations", ErrInvalidRequest)
+	}
+
+	return nil
+}
+
+// encodePermissionUpdate appends the permissions of an AccountPermissionUpdateContract to p.
+func encodePermissionUpdate(p *protoBuffer, v *ContractValue) error {
+	if err := encodePermission(p, 2, v.Owner); err != nil {
+		return err
+	}
+	if err := encodePermission(p, 3, v.Witness); err != nil {
+		return err
+	}
+	for _, active := range v.Actives {
+		if err := encodePermission(p, 4, active); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// encodePermission appends perm to p as a protocol.Permission, unless it is nil.
+func encodePermission(p *protoBuffer, field int, perm *Permission) error {
+	if perm == nil {
+		return nil
+	}
+	typ, ok := permissionTypes[perm.Type]
+	if !ok {
+		return fmt.Errorf("%w: permission type %s", ErrInvalidRequest, perm.Type)
+	}
+	operations, err := hex.DecodeString(perm.Operations)
+	if err != nil {
+		return fmt.Errorf("%w: operations: %v", ErrInvalidRequest, err)
+	}
+
+	var b protoBuffer
+	b.varint(1, typ)
+	b.int64(2, int64(perm.ID))
+	b.string(3, perm.Name)
+	b.int64(4, perm.Threshold)
+	b.int64(5, int64(perm.ParentID))
+	b.bytes(6, operations)
+	for _, k := range perm.Keys {
+		a, err := ParseAddress(k.Address)
+		if err != nil {
+			return err
+		}
+		var key protoBuffer
+		key.bytes(1, a[:])
+		key.int64(2, k.Weight)
+		b.bytes(7, key.b)
+	}
+	p.bytes(field, b.b)
+
+	return nil
+}
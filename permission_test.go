@@ -0,0 +1,63 @@
+package trongrid_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+// defaultActiveOperations are the operations of the active permission of new mainnet accounts.
+const defaultActiveOperations = "7fff1fc0033e0000000000000000000000000000000000000000000000000000"
+
+func TestOperations(t *testing.T) {
+	types, err := trongrid.DecodeOperations(defaultActiveOperations)
+	require.NoError(t, err)
+	assert.Len(t, types, 29)
+	assert.Equal(t, trongrid.ContractTypeAccountCreate, types[0])
+	assert.Contains(t, types, trongrid.ContractTypeTRC20)
+	assert.NotContains(t, types, trongrid.ContractTypeAccountPermissionUpdate)
+
+	operations, err := trongrid.EncodeOperations(types...)
+	require.NoError(t, err)
+	assert.Equal(t, defaultActiveOperations, operations)
+
+	_, err = trongrid.EncodeOperations("NoSuchContract")
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+	_, err = trongrid.DecodeOperations("7fff")
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+}
+
+func TestPermission(t *testing.T) {
+	p := &trongrid.Permission{
+		Type:      trongrid.PermissionActive,
+		Name:      "payments",
+		Threshold: 2,
+		Keys: []trongrid.PermissionKey{
+			{Address: holderA, Weight: 1},
+			{Address: holderB, Weight: 1},
+		},
+	}
+	require.ErrorIs(t, p.Validate(), trongrid.ErrInvalidRequest, "active permissions need operations")
+
+	require.NoError(t, p.SetOperations(trongrid.ContractTypeTRX, trongrid.ContractTypeTRC20))
+	require.NoError(t, p.Validate())
+	assert.True(t, p.Allows(trongrid.ContractTypeTRX))
+	assert.False(t, p.Allows(trongrid.ContractTypeFreezeV2))
+
+	a, err := trongrid.ParseAddress(holderB)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), p.Weight(a))
+
+	p.Threshold = 3
+	require.ErrorIs(t, p.Validate(), trongrid.ErrInvalidRequest)
+	p.Threshold = 1
+	p.Keys = append(p.Keys, trongrid.PermissionKey{Address: holderA, Weight: 1})
+	require.ErrorIs(t, p.Validate(), trongrid.ErrInvalidRequest)
+
+	owner := &trongrid.Permission{Name: "owner", Threshold: 1, Keys: p.Keys[:1]}
+	require.NoError(t, owner.Validate())
+	assert.True(t, owner.Allows(trongrid.ContractTypeAccountPermissionUpdate))
+}
@@ -0,0 +1,311 @@
+package trongrid
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Protobuf field numbers of protocol.Transaction.raw and the contracts it carries.
+// See https://github.com/tronprotocol/protocol/blob/master/core/Tron.proto
+const (
+	rawRefBlockBytes = 1
+	rawRefBlockHash  = 4
+	rawExpiration    = 8
+	rawData          = 10
+	rawContract      = 11
+	rawTimestamp     = 14
+	rawFeeLimit      = 18
+
+	contractType         = 1
+	contractParameter    = 2
+	contractPermissionID = 5
+
+	anyTypeURL = 1
+	anyValue   = 2
+
+	transactionRaw       = 1
+	transactionSignature = 2
+)
+
+// contractTypeIDs maps contract names to protocol.Transaction.Contract.ContractType.
+var contractTypeIDs = map[string]uint64{
+	ContractTypeTRX:             1,
+	ContractTypeTRC10:           2,
+	ContractTypeVote:            4,
+	ContractTypeWithdrawBalance: 13,
+	ContractTypeTRC20:           31,
+
+	ContractTypeAccountPermissionUpdate: 46,
+
+	ContractTypeFreezeV2:               54,
+	ContractTypeUnfreezeV2:             55,
+	ContractTypeWithdrawExpireUnfreeze: 56,
+	ContractTypeDelegateResource:       57,
+	ContractTypeUndelegateResource:     58,
+	ContractTypeCancelAllUnfreezeV2:    59,
+}
+
+// resourceCodes maps resource names to protocol.ResourceCode.
+var resourceCodes = map[string]uint64{
+	"":                0,
+	ResourceBandwidth: 0,
+	ResourceEnergy:    1,
+	ResourceTronPower: 2,
+}
+
+// protoBuffer is a minimal protobuf writer; it only supports the wire types TRON transactions use.
+type protoBuffer struct {
+	b []byte
+}
+
+func (p *protoBuffer) tag(field int, wireType byte) {
+	p.b = binary.AppendUvarint(p.b, uint64(field)<<3|uint64(wireType))
+}
+
+func (p *protoBuffer) varint(field int, v uint64) {
+	if v == 0 {
+		return
+	}
+	p.tag(field, 0)
+	p.b = binary.AppendUvarint(p.b, v)
+}
+
+func (p *protoBuffer) int64(field int, v int64) {
+	p.varint(field, uint64(v))
+}
+
+func (p *protoBuffer) bytes(field int, v []byte) {
+	if len(v) == 0 {
+		return
+	}
+	p.tag(field, 2)
+	p.b = binary.AppendUvarint(p.b, uint64(len(v)))
+	p.b = append(p.b, v...)
+}
+
+func (p *protoBuffer) string(field int, v string) {
+	p.bytes(field, []byte(v))
+}
+
+// encodeRawData serializes raw into its protobuf form, whose sha256 is the transaction ID.
+func encodeRawData(raw *TransactionRawData) ([]byte, error) {
+	var p protoBuffer
+
+	refBlockBytes, err := hex.DecodeString(raw.RefBlockBytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ref_block_bytes: %v", ErrInvalidRequest, err)
+	}
+	refBlockHash, err := hex.DecodeString(raw.RefBlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: ref_block_hash: %v", ErrInvalidRequest, err)
+	}
+	data, err := hex.DecodeString(raw.Data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: data: %v", ErrInvalidRequest, err)
+	}
+
+	p.bytes(rawRefBlockBytes, refBlockBytes)
+	p.bytes(rawRefBlockHash, refBlockHash)
+	p.int64(rawExpiration, raw.Expiration)
+	p.bytes(rawData, data)
+
+	for i := range raw.Contract {
+		c, err := encodeContract(&raw.Contract[i])
+		if err != nil {
+			return nil, err
+		}
+		p.bytes(rawContract, c)
+	}
+
+	p.int64(rawTimestamp, raw.Timestamp)
+	p.int64(rawFeeLimit, raw.FeeLimit)
+
+	return p.b, nil
+}
+
+func encodeContract(c *Contract) ([]byte, error) {
+	id, ok := contractTypeIDs[c.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: unsupported contract type %s", ErrInvalidRequest, c.Type)
+	}
+
+	value, err := encodeContractValue(c.Type, &c.Parameter.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	typeURL := c.Parameter.TypeUrl
+	if typeURL == "" {
+		typeURL = "type.googleapis.com/protocol." + c.Type
+	}
+
+	var param protoBuffer
+	param.string(anyTypeURL, typeURL)
+	param.bytes(anyValue, value)
+
+	var p protoBuffer
+	p.varint(contractType, id)
+	p.bytes(contractParameter, param.b)
+	p.varint(contractPermissionID, uint64(c.PermissionID))
+
+	return p.b, nil
+}
+
+func encodeContractValue(typ string, v *ContractValue) ([]byte, error) {
+	var p protoBuffer
+
+	owner, err := ParseAddress(v.OwnerAddress)
+	if err != nil {
+		return nil, err
+	}
+	p.bytes(1, owner[:])
+
+	switch typ {
+	case ContractTypeTRX:
+		to, err := ParseAddress(v.ToAddress)
+		if err != nil {
+			return nil, err
+		}
+		p.bytes(2, to[:])
+		p.int64(3, int64(v.Amount))
+	case ContractTypeTRC10:
+		name, err := assetName(v)
+		if err != nil {
+			return nil, err
+		}
+		to, err := ParseAddress(v.ToAddress)
+		if err != nil {
+			return nil, err
+		}
+		// asset_name precedes owner_address in TransferAssetContract
+		p = protoBuffer{}
+		p.bytes(1, name)
+		p.bytes(2, owner[:])
+		p.bytes(3, to[:])
+		p.int64(4, int64(v.Amount))
+	case ContractTypeTRC20:
+		contract, err := ParseAddress(v.ContractAddress)
+		if err != nil {
+			return nil, err
+		}
+		data, err := hex.DecodeString(v.Data)
+		if err != nil {
+			return nil, fmt.Errorf("%w: data: %v", ErrInvalidRequest, err)
+		}
+		p.bytes(2, contract[:])
+		p.int64(3, v.CallValue)
+		p.bytes(4, data)
+	case ContractTypeFreezeV2, ContractTypeUnfreezeV2:
+		resource, err := resourceCode(v.Resource)
+		if err != nil {
+			return nil, err
+		}
+		if typ == ContractTypeFreezeV2 {
+			p.int64(2, v.FrozenBalance)
+		} else {
+			p.int64(2, v.UnfreezeBalance)
+		}
+		p.varint(3, resource)
+	case ContractTypeDelegateResource, ContractTypeUndelegateResource:
+		resource, err := resourceCode(v.Resource)
+		if err != nil {
+			return nil, err
+		}
+		receiver, err := ParseAddress(v.ReceiverAddress)
+		if err != nil {
+			return nil, err
+		}
+		p.varint(2, resource)
+		p.int64(3, v.Balance)
+		p.bytes(4, receiver[:])
+		if v.Lock {
+			p.varint(5, 1)
+		}
+		p.int64(6, v.LockPeriod)
+	case ContractTypeVote:
+		for _, vote := range v.Votes {
+			witness, err := ParseAddress(vote.VoteAddress)
+			if err != nil {
+				return nil, err
+			}
+			var pv protoBuffer
+			pv.bytes(1, witness[:])
+			pv.int64(2, vote.VoteCount)
+			p.bytes(2, pv.b)
+		}
+	case ContractTypeAccountPermissionUpdate:
+		if err := encodePermissionUpdate(&p, v); err != nil {
+			return nil, err
+		}
+	}
+
+	return p.b, nil
+}
+
+func resourceCode(resource string) (uint64, error) {
+	code, ok := resourceCodes[resource]
+	if !ok {
+		return 0, fmt.Errorf("%w: resource %s", ErrInvalidRequest, resource)
+	}
+
+	return code, nil
+}
+
+// assetName returns the asset ID of a TransferAssetContract. Like addresses, it is hex encoded
+// unless the transaction is visible.
+func assetName(v *ContractValue) ([]byte, error) {
+	if strings.HasPrefix(v.OwnerAddress, "T") {
+		return []byte(v.AssetName), nil
+	}
+
+	name, err := hex.DecodeString(v.AssetName)
+	if err != nil {
+		return nil, fmt.Errorf("%w: asset_name: %v", ErrInvalidRequest, err)
+	}
+
+	return name, nil
+}
+
+// encodeTransaction serializes a signed transaction for /wallet/broadcasthex.
+func encodeTransaction(tx *Transaction) ([]byte, error) {
+	raw, err := hex.DecodeString(tx.RawDataHex)
+	if err != nil || len(raw) == 0 {
+		return nil, fmt.Errorf("%w: raw_data_hex", ErrInvalidRequest)
+	}
+
+	var p protoBuffer
+	p.bytes(transactionRaw, raw)
+	for _, s := range tx.Signature {
+		sig, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+		}
+		p.bytes(transactionSignature, sig)
+	}
+
+	return p.b, nil
+}
+
+// Sizes used for bandwidth, see java-tron's BandwidthProcessor.
+const (
+	// signatureSize is a 65 byte signature with its field tag and length
+	signatureSize = 67
+	// maxResultSize is added per contract for the result the node attaches
+	maxResultSize = 64
+)
+
+// transactionSize returns the bandwidth tx uses once signed by signatures keys: its serialized size
+// without results, plus the result allowance.
+func transactionSize(tx *Transaction, signatures int) (int64, error) {
+	raw, err := encodeRawData(&tx.RawData)
+	if err != nil {
+		return 0, err
+	}
+
+	var p protoBuffer
+	p.bytes(transactionRaw, raw)
+
+	return int64(len(p.b) + signatures*signatureSize + len(tx.RawData.Contract)*maxResultSize), nil
+}
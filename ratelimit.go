@@ -0,0 +1,45 @@
+package trongrid
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimiter blocks until a request may be sent.
+// *rate.Limiter satisfies it, so a single limiter can be shared between clients
+// that use the same API key.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// NewRateLimiter returns a limiter allowing rps requests per second with the given burst.
+func NewRateLimiter(rps float64, burst int) RateLimiter {
+	return rate.NewLimiter(rate.Limit(rps), burst)
+}
+
+// defaultRateLimiter matches the free TronGrid tier.
+func defaultRateLimiter() RateLimiter {
+	return rate.NewLimiter(rate.Every(time.Second), 1)
+}
+
+type rateLimiterKey struct{}
+
+// ContextWithRateLimiter returns a context that overrides the client rate limiter
+// for the calls made with it. A nil limiter disables rate limiting.
+func ContextWithRateLimiter(ctx context.Context, limiter RateLimiter) context.Context {
+	return context.WithValue(ctx, rateLimiterKey{}, rateLimiterOverride{limiter})
+}
+
+type rateLimiterOverride struct {
+	limiter RateLimiter
+}
+
+func rateLimiterFromContext(ctx context.Context, fallback RateLimiter) RateLimiter {
+	if v, ok := ctx.Value(rateLimiterKey{}).(rateLimiterOverride); ok {
+		return v.limiter
+	}
+
+	return fallback
+}
@@ -0,0 +1,258 @@
+package trongrid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// call describes a single TronGrid request.
+type call struct {
+	// endpoint is the logical name of the call, e.g. "ListTransactions"
+	endpoint   string
+	method     string
+	path       string
+	pathParams map[string]string
+	query      url.Values
+	body       interface{}
+	// idempotent calls are retried; broadcasts must never set it
+	idempotent bool
+	// unique calls are never coalesced, e.g. transaction builders: two identical payouts
+	// must get two transactions, not one txID broadcast twice
+	unique bool
+	// cache returns how long the decoded result may be cached, 0 if it must not be
+	cache func() time.Duration
+}
+
+// do executes c, retrying according to the retry policy, and decodes the JSON body into result.
+func (api *api) do(ctx context.Context, c *call, result interface{}) error {
+	if api.err != nil {
+		return api.err
+	}
+
+	var key string
+	if c.cache != nil && api.cache != nil {
+		key = api.cacheKey(c)
+		if body, ok := api.cache.Get(key); ok && json.Unmarshal(body, result) == nil {
+			api.instrumentation.RecordCacheHit(ctx, c.endpoint)
+
+			return nil
+		}
+	}
+
+	var body []byte
+	var err error
+	if c.idempotent && !c.unique && api.coalesce {
+		body, err = api.flights.do(ctx, api.cacheKey(c), func(ctx context.Context) ([]byte, error) {
+			return api.execute(ctx, c)
+		})
+	} else {
+		body, err = api.execute(ctx, c)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err = json.Unmarshal(body, result); err != nil {
+		err = fmt.Errorf("%w: %s: %v", ErrEmpty, c.endpoint, err)
+		api.logger.Error().Err(err).Send()
+
+		return err
+	}
+
+	// Wallet endpoints report failures with a 200 status and an "Error" field.
+	var v Error
+	if json.Unmarshal(body, &v) == nil && v.Error != "" {
+		err = NewAPIError(http.StatusOK, v.Error, ErrInvalidRequest)
+		api.logger.Error().Err(err).Str("endpoint", c.endpoint).Send()
+
+		return err
+	}
+
+	if key != "" {
+		if ttl := c.cache(); ttl > 0 {
+			api.cache.Set(key, body, ttl)
+		}
+	}
+
+	return nil
+}
+
+// cacheKey identifies c on the client network.
+func (api *api) cacheKey(c *call) string {
+	network := api.network.Name
+	if network == "" {
+		network = api.network.URI
+	}
+
+	path := c.path
+	for k, v := range c.pathParams {
+		path = strings.ReplaceAll(path, "{"+k+"}", url.PathEscape(v))
+	}
+
+	key := network + " " + c.method + " " + path + "?" + c.query.Encode()
+	if c.body != nil {
+		if b, err := json.Marshal(c.body); err == nil {
+			key += " " + string(b)
+		}
+	}
+
+	return key
+}
+
+// execute sends c and returns the raw body of a successful response.
+func (api *api) execute(ctx context.Context, c *call) ([]byte, error) {
+	attrs := []Attribute{
+		{Key: AttributeEndpoint, Value: c.endpoint},
+		{Key: AttributeNetwork, Value: api.network.Name},
+	}
+	for k, v := range c.pathParams {
+		attrs = append(attrs, Attribute{Key: AttributePathPrefix + k, Value: v})
+	}
+
+	ctx, span := api.instrumentation.StartSpan(ctx, c.endpoint, attrs...)
+	defer span.End()
+
+	body, status, attempts, err := api.attempt(ctx, c)
+	span.SetAttributes(
+		Attribute{Key: AttributeStatus, Value: status},
+		Attribute{Key: AttributeAttempts, Value: attempts},
+	)
+	if err != nil {
+		span.RecordError(err)
+	}
+
+	return body, err
+}
+
+// attempt sends c until it succeeds or the retry policy gives up.
+func (api *api) attempt(ctx context.Context, c *call) (body []byte, status, attempts int, err error) {
+	policy := retryPolicyFromContext(ctx, api.retry)
+	limiter := rateLimiterFromContext(ctx, api.limiter)
+
+	for attempts = 1; ; attempts++ {
+		if attempts > 1 {
+			api.instrumentation.RecordRetry(ctx, c.endpoint, attempts)
+		}
+
+		if limiter != nil {
+			start := time.Now()
+			if err = limiter.Wait(ctx); err != nil {
+				return nil, 0, attempts, err
+			}
+			api.instrumentation.RecordRateLimiterWait(ctx, c.endpoint, time.Since(start))
+		}
+
+		info := &RequestInfo{
+			Endpoint:   c.endpoint,
+			Method:     c.method,
+			Path:       c.path,
+			PathParams: c.pathParams,
+			Params:     c.query,
+			Body:       c.body,
+			Header:     http.Header{},
+			Attempt:    attempts,
+		}
+		for _, hook := range api.beforeRequest {
+			if err = hook(ctx, info); err != nil {
+				return nil, 0, attempts, err
+			}
+		}
+
+		start := time.Now()
+		resp, sendErr := api.send(ctx, info)
+		latency := time.Since(start)
+
+		status = 0
+		if resp != nil {
+			status = resp.StatusCode()
+		}
+
+		api.instrumentation.RecordRequest(ctx, c.endpoint, status, latency, sendErr)
+		for _, hook := range api.afterResponse {
+			hook(ctx, &ResponseInfo{RequestInfo: info, Status: status, Latency: latency, Err: sendErr})
+		}
+
+		if sendErr == nil && status < http.StatusBadRequest {
+			return resp.Body(), status, attempts, nil
+		}
+
+		if ctx.Err() != nil || !c.idempotent || attempts > policy.MaxRetries || !shouldRetry(status, sendErr) {
+			return nil, status, attempts, api.failure(c, resp, sendErr)
+		}
+
+		delay := policy.Backoff(attempts)
+		if status == http.StatusTooManyRequests {
+			if d, ok := retryAfter(resp.Header(), resp.ReceivedAt()); ok {
+				delay = d
+			}
+		}
+
+		api.logger.Warn().
+			Str("endpoint", c.endpoint).
+			Int("status", status).
+			Int("attempt", attempts).
+			Dur("delay", delay).
+			Err(sendErr).
+			Msg("retrying request")
+
+		if err = sleep(ctx, delay); err != nil {
+			return nil, status, attempts, err
+		}
+	}
+}
+
+func (api *api) send(ctx context.Context, info *RequestInfo) (*resty.Response, error) {
+	r := api.cl.R().
+		SetContext(ctx).
+		SetHeader("Accept", "application/json").
+		SetHeaderMultiValues(info.Header).
+		SetPathParams(info.PathParams).
+		SetQueryParamsFromValues(info.Params)
+	if info.Body != nil {
+		r.SetHeader("Content-Type", "application/json").SetBody(info.Body)
+	}
+
+	return r.Execute(info.Method, strings.TrimRight(api.uri, "/")+info.Path)
+}
+
+// failure converts a failed response into an error.
+func (api *api) failure(c *call, resp *resty.Response, err error) error {
+	if err != nil {
+		err = fmt.Errorf("%w: %s: %w", ErrNetworkError, c.endpoint, err)
+		api.logger.Error().Err(err).Send()
+
+		return err
+	}
+
+	status := resp.StatusCode()
+	message := http.StatusText(status)
+
+	var v Error
+	if json.Unmarshal(resp.Body(), &v) == nil && v.Error != "" {
+		message = v.Error
+	}
+
+	var kind error
+	switch {
+	case status == http.StatusTooManyRequests:
+		kind = ErrRateLimitExceeded
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		kind = ErrUnauthorized
+	case status >= http.StatusInternalServerError:
+		kind = ErrServerError
+	default:
+		kind = ErrEmpty
+	}
+
+	err = NewAPIError(status, message, kind)
+	api.logger.Error().Err(err).Str("endpoint", c.endpoint).Send()
+
+	return err
+}
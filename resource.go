@@ -0,0 +1,166 @@
+package trongrid
+
+import (
+	"math"
+	"time"
+)
+
+// ResourceWindow is the period over which used bandwidth and energy recover linearly.
+const ResourceWindow = 24 * time.Hour
+
+// ResourceView is a computed view of the resources of an account at a point in time.
+// Bandwidth is in bandwidth points, energy in energy units, TRON Power in votes
+// and delegated balances in sun of staked TRX.
+type ResourceView struct {
+	At time.Time
+
+	FreeBandwidthUsed  int64
+	FreeBandwidthLimit int64
+	// StakedBandwidth is obtained by staking TRX, including bandwidth delegated to the account
+	StakedBandwidthUsed  int64
+	StakedBandwidthLimit int64
+
+	EnergyUsed  int64
+	EnergyLimit int64
+
+	TronPowerUsed  int64
+	TronPowerLimit int64
+
+	DelegatedBandwidthOut int64
+	DelegatedBandwidthIn  int64
+	DelegatedEnergyOut    int64
+	DelegatedEnergyIn     int64
+}
+
+// NewResourceView combines the resources of an account with the delegations in account,
+// which may be nil. at is the time res was fetched.
+func NewResourceView(res *AccountResourceMessage, account *Account, at time.Time) *ResourceView {
+	v := &ResourceView{
+		At:                   at,
+		FreeBandwidthUsed:    res.FreeNetUsed,
+		FreeBandwidthLimit:   res.FreeNetLimit,
+		StakedBandwidthUsed:  res.NetUsed,
+		StakedBandwidthLimit: res.NetLimit,
+		EnergyUsed:           res.EnergyUsed,
+		EnergyLimit:          res.EnergyLimit,
+		TronPowerUsed:        res.TronPowerUsed,
+		TronPowerLimit:       res.TronPowerLimit,
+	}
+	if account != nil {
+		v.DelegatedBandwidthOut = account.DelegatedFrozenV2BalanceForBandwidth
+		v.DelegatedBandwidthIn = account.AcquiredDelegatedFrozenV2BalanceForBandwidth
+		if account.AccountResource != nil {
+			v.DelegatedEnergyOut = account.AccountResource.DelegatedFrozenV2BalanceForEnergy
+			v.DelegatedEnergyIn = account.AccountResource.AcquiredDelegatedFrozenV2BalanceForEnergy
+		}
+	}
+
+	return v
+}
+
+// FreeBandwidth returns the free daily bandwidth left.
+func (v *ResourceView) FreeBandwidth() int64 {
+	return remaining(v.FreeBandwidthLimit, v.FreeBandwidthUsed)
+}
+
+// StakedBandwidth returns the staked bandwidth left.
+func (v *ResourceView) StakedBandwidth() int64 {
+	return remaining(v.StakedBandwidthLimit, v.StakedBandwidthUsed)
+}
+
+// Bandwidth returns the bandwidth left. A transaction uses either staked or free bandwidth, not both,
+// so compare its size with StakedBandwidth and FreeBandwidth separately to know whether it burns TRX.
+func (v *ResourceView) Bandwidth() int64 {
+	return v.FreeBandwidth() + v.StakedBandwidth()
+}
+
+// Energy returns the energy left.
+func (v *ResourceView) Energy() int64 {
+	return remaining(v.EnergyLimit, v.EnergyUsed)
+}
+
+// TronPower returns the votes left.
+func (v *ResourceView) TronPower() int64 {
+	return remaining(v.TronPowerLimit, v.TronPowerUsed)
+}
+
+// CoversBandwidth reports whether a transaction of size bytes can be paid with bandwidth
+// instead of burning TRX.
+func (v *ResourceView) CoversBandwidth(size int64) bool {
+	return v.StakedBandwidth() >= size || v.FreeBandwidth() >= size
+}
+
+// CoversEnergy reports whether energy can be paid without burning TRX.
+func (v *ResourceView) CoversEnergy(energy int64) bool {
+	return v.Energy() >= energy
+}
+
+// Project returns the view at t, assuming no further usage. Usage recovers linearly and is fully
+// recovered ResourceWindow after v.At. Times before v.At return v unchanged.
+func (v *ResourceView) Project(t time.Time) *ResourceView {
+	p := *v
+	elapsed := t.Sub(v.At)
+	if elapsed <= 0 {
+		return &p
+	}
+
+	p.At = t
+	p.FreeBandwidthUsed = recovered(v.FreeBandwidthUsed, elapsed)
+	p.StakedBandwidthUsed = recovered(v.StakedBandwidthUsed, elapsed)
+	p.EnergyUsed = recovered(v.EnergyUsed, elapsed)
+
+	return &p
+}
+
+// EnergyAvailableAt returns when energy will be available, assuming no further usage:
+// v.At if it already is, the zero time if it never will be.
+func (v *ResourceView) EnergyAvailableAt(energy int64) time.Time {
+	return availableAt(v.At, v.EnergyLimit, v.EnergyUsed, energy)
+}
+
+// BandwidthAvailableAt returns when a transaction of size bytes will be covered by staked or free bandwidth,
+// assuming no further usage: v.At if it already is, the zero time if it never will be.
+func (v *ResourceView) BandwidthAvailableAt(size int64) time.Time {
+	staked := availableAt(v.At, v.StakedBandwidthLimit, v.StakedBandwidthUsed, size)
+	free := availableAt(v.At, v.FreeBandwidthLimit, v.FreeBandwidthUsed, size)
+	switch {
+	case staked.IsZero():
+		return free
+	case free.IsZero() || staked.Before(free):
+		return staked
+	}
+
+	return free
+}
+
+func remaining(limit, used int64) int64 {
+	if used >= limit {
+		return 0
+	}
+
+	return limit - used
+}
+
+// recovered returns the usage left elapsed after used was measured.
+func recovered(used int64, elapsed time.Duration) int64 {
+	if elapsed >= ResourceWindow {
+		return 0
+	}
+
+	return int64(float64(used) * float64(ResourceWindow-elapsed) / float64(ResourceWindow))
+}
+
+// availableAt returns when limit-used reaches amount as used recovers.
+func availableAt(at time.Time, limit, used, amount int64) time.Time {
+	switch {
+	case amount > limit:
+		return time.Time{}
+	case limit-used >= amount:
+		return at
+	}
+
+	// used * (1 - d/window) <= limit - amount
+	d := float64(ResourceWindow) * (1 - float64(limit-amount)/float64(used))
+
+	return at.Add(time.Duration(math.Ceil(d)))
+}
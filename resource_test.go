@@ -0,0 +1,63 @@
+package trongrid_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+func TestApi_GetAccountResource(t *testing.T) {
+	t.Parallel()
+
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	srv.AddAccountResource(holderA, &trongrid.AccountResourceMessage{
+		FreeNetUsed:      345,
+		FreeNetLimit:     600,
+		TotalNetLimit:    43_200_000_000,
+		TotalNetWeight:   30_000_000_000,
+		EnergyUsed:       50_000,
+		EnergyLimit:      65_000,
+		TotalEnergyLimit: 180_000_000_000,
+		TronPowerLimit:   7_000,
+	})
+	api := srv.Client()
+
+	res, err := api.GetAccountResource(context.Background(), holderA)
+	require.NoError(t, err)
+	assert.Equal(t, int64(65_000), res.EnergyLimit)
+
+	_, err = api.GetAccountResource(context.Background(), holderB)
+	require.ErrorIs(t, err, trongrid.ErrEmpty)
+
+	now := time.Now()
+	view := trongrid.NewResourceView(res, &trongrid.Account{
+		AccountResource: &trongrid.AccountResource{AcquiredDelegatedFrozenV2BalanceForEnergy: 7_000_000_000},
+	}, now)
+	assert.Equal(t, int64(255), view.FreeBandwidth())
+	assert.Equal(t, int64(15_000), view.Energy())
+	assert.Equal(t, int64(7_000), view.TronPower())
+	assert.Equal(t, int64(7_000_000_000), view.DelegatedEnergyIn)
+	assert.True(t, view.CoversBandwidth(250))
+	assert.False(t, view.CoversEnergy(29_650))
+
+	// half of the usage recovers in half of the window
+	later := view.Project(now.Add(trongrid.ResourceWindow / 2))
+	assert.Equal(t, int64(25_000), later.EnergyUsed)
+	assert.Equal(t, int64(40_000), later.Energy())
+	assert.Equal(t, int64(65_000), view.Project(now.Add(trongrid.ResourceWindow)).Energy())
+
+	// 29650 energy is available once usage drops to 35350
+	at := view.EnergyAvailableAt(29_650)
+	assert.WithinDuration(t, now.Add(time.Duration(float64(trongrid.ResourceWindow)*0.293)), at, time.Second)
+	assert.True(t, view.Project(at).CoversEnergy(29_650))
+	assert.Equal(t, now, view.EnergyAvailableAt(10_000))
+	assert.True(t, view.EnergyAvailableAt(100_000).IsZero())
+}
@@ -0,0 +1,125 @@
+package trongrid
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how failed requests are retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt
+	MaxRetries int
+	// BaseDelay is the delay before the first retry, doubled on every further retry
+	BaseDelay time.Duration
+	// MaxDelay caps the exponential delay
+	MaxDelay time.Duration
+	// Jitter is the fraction (0..1) of the delay that is randomized
+	Jitter float64
+}
+
+// DefaultRetryPolicy returns the policy used when none is configured:
+// 3 retries starting at 1 second and capped at 5 seconds.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 3,
+		BaseDelay:  time.Second,
+		MaxDelay:   5 * time.Second,
+		Jitter:     0.2,
+	}
+}
+
+// NoRetry returns a policy that never retries.
+func NoRetry() RetryPolicy {
+	return RetryPolicy{}
+}
+
+// Backoff returns the delay before the given retry (starting at 1).
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	if retry < 1 || p.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := float64(p.BaseDelay) * math.Pow(2, float64(retry-1))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1)
+		delay -= delay * jitter * rand.Float64() //nolint:gosec // jitter does not need a secure source
+	}
+
+	return time.Duration(delay)
+}
+
+// shouldRetry reports whether a response with the given status or error is worth retrying.
+func shouldRetry(status int, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// retryAfter parses the Retry-After header, given either in seconds or as an HTTP date.
+func retryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if t, err := http.ParseTime(v); err == nil {
+		if d := t.Sub(now); d > 0 {
+			return d, true
+		}
+
+		return 0, true
+	}
+
+	return 0, false
+}
+
+type retryPolicyKey struct{}
+
+// ContextWithRetryPolicy returns a context that overrides the client retry policy
+// for the calls made with it.
+func ContextWithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey{}, policy)
+}
+
+func retryPolicyFromContext(ctx context.Context, fallback RetryPolicy) RetryPolicy {
+	if v, ok := ctx.Value(retryPolicyKey{}).(RetryPolicy); ok {
+		return v
+	}
+
+	return fallback
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,111 @@
+package trongrid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		name     string
+		retry    int
+		expected time.Duration
+	}{
+		{"first attempt", 0, 0},
+		{"first retry", 1, 100 * time.Millisecond},
+		{"second retry", 2, 200 * time.Millisecond},
+		{"third retry", 3, 400 * time.Millisecond},
+		{"capped", 5, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, policy.Backoff(tt.retry))
+		})
+	}
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		d := policy.Backoff(2)
+		assert.GreaterOrEqual(t, d, 100*time.Millisecond)
+		assert.LessOrEqual(t, d, 200*time.Millisecond)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2024, 2, 24, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		value    string
+		expected time.Duration
+		ok       bool
+	}{
+		{"missing", "", 0, false},
+		{"seconds", "3", 3 * time.Second, true},
+		{"http date", now.Add(2 * time.Second).Format(http.TimeFormat), 2 * time.Second, true},
+		{"past date", now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"garbage", "soon", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.value != "" {
+				header.Set("Retry-After", tt.value)
+			}
+
+			d, ok := retryAfter(header, now)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, d)
+		})
+	}
+}
+
+func TestExecuteRetries(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+		_, _ = w.Write([]byte(`{"success":true}`))
+	}))
+	defer srv.Close()
+
+	x, ok := NewAPI(
+		WithURI(srv.URL),
+		WithRateLimiter(nil),
+		WithRetryPolicy(RetryPolicy{MaxRetries: 2, BaseDelay: time.Hour}),
+	).(*api)
+	require.True(t, ok)
+
+	ctx := context.Background()
+
+	var resp ListTransactionsResponse
+	require.NoError(t, x.do(ctx, &call{endpoint: "test", method: http.MethodGet, path: "/", idempotent: true}, &resp))
+	assert.True(t, resp.Success)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&hits))
+
+	atomic.StoreInt32(&hits, 0)
+	err := x.do(ctx, &call{endpoint: "test", method: http.MethodPost, path: "/"}, &resp)
+	require.ErrorIs(t, err, ErrRateLimitExceeded)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&hits))
+
+	atomic.StoreInt32(&hits, 0)
+	ctx = ContextWithRetryPolicy(ctx, NoRetry())
+	err = x.do(ctx, &call{endpoint: "test", method: http.MethodGet, path: "/", idempotent: true}, &resp)
+	require.ErrorIs(t, err, ErrRateLimitExceeded)
+	assert.EqualValues(t, 1, atomic.LoadInt32(&hits))
+}
@@ -0,0 +1,288 @@
+package trongrid
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrSignWeightNotEnough is returned when broadcasting a session whose signatures are below the threshold.
+var ErrSignWeightNotEnough = errors.New("signature weight below the permission threshold")
+
+// SigningSession collects the signatures of the keys of a multisig permission for one transaction.
+// It is exported with Encode or as JSON, passed between the parties, signed offline with Sign
+// and merged back with Merge; it is broadcast once the weight of its signers reaches the threshold.
+type SigningSession struct {
+	// Transaction is signed in place and must not be changed otherwise
+	Transaction *Transaction
+	// Permission is the permission of the owner of the transaction that signs it,
+	// e.g. from GetAccount; its ID is the Permission_id of the contract
+	Permission *Permission
+}
+
+// sessionJSON is the exported form of a SigningSession, without the fields of confirmed transactions.
+type sessionJSON struct {
+	TxID       string             `json:"txID"`
+	RawData    TransactionRawData `json:"raw_data"`
+	RawDataHex string             `json:"raw_data_hex"`
+	Signature  []string           `json:"signature,omitempty"`
+	Permission *Permission        `json:"permission"`
+}
+
+// NewSigningSession starts a session signing tx with permission. If the contract of tx does not use
+// the permission yet, its Permission_id is set, which requires tx to have no signatures.
+func NewSigningSession(tx *Transaction, permission *Permission) (*SigningSession, error) {
+	if permission == nil {
+		return nil, fmt.Errorf("%w: permission is required", ErrInvalidRequest)
+	}
+	if len(tx.RawData.Contract) != 1 {
+		return nil, fmt.Errorf("%w: transaction must have exactly one contract", ErrInvalidRequest)
+	}
+
+	s := &SigningSession{Transaction: tx, Permission: permission}
+	if tx.RawData.Contract[0].PermissionID != permission.ID {
+		if err := s.modify(func(raw *TransactionRawData) { raw.Contract[0].PermissionID = permission.ID }); err != nil {
+			return nil, err
+		}
+	}
+	if _, err := TransactionHash(tx); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// DecodeSigningSession decodes a session exported with Encode.
+func DecodeSigningSession(s string) (*SigningSession, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: signing session: %v", ErrInvalidRequest, err)
+	}
+
+	session := new(SigningSession)
+	if err = json.Unmarshal(b, session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+// Encode returns the session as unpadded URL-safe base64, compact enough for a QR code or a chat message.
+func (s *SigningSession) Encode() (string, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (s *SigningSession) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sessionJSON{
+		TxID:       s.Transaction.TxID,
+		RawData:    s.Transaction.RawData,
+		RawDataHex: s.Transaction.RawDataHex,
+		Signature:  s.Transaction.Signature,
+		Permission: s.Permission,
+	})
+}
+
+// UnmarshalJSON decodes a session. raw_data is encoded again and must match raw_data_hex and txID,
+// so that the parties sign the transaction they are shown; its contract must use the permission
+// and every key may have signed only once.
+func (s *SigningSession) UnmarshalJSON(b []byte) error {
+	var v sessionJSON
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if v.Permission == nil {
+		return fmt.Errorf("%w: signing session has no permission", ErrInvalidRequest)
+	}
+
+	tx := &Transaction{RawData: v.RawData, Signature: v.Signature}
+	if err := SealTransaction(tx); err != nil {
+		return err
+	}
+	if !strings.EqualFold(tx.RawDataHex, v.RawDataHex) || !strings.EqualFold(tx.TxID, v.TxID) {
+		return fmt.Errorf("%w: raw_data_hex and txID do not match raw_data", ErrInvalidRequest)
+	}
+	if err := checkPermission(tx, v.Permission); err != nil {
+		return err
+	}
+
+	signers, err := TransactionSigners(tx)
+	if err != nil {
+		return err
+	}
+	seen := make(map[Address]bool, len(signers))
+	for _, a := range signers {
+		if seen[a] {
+			return fmt.Errorf("%w: %s has signed more than once", ErrInvalidRequest, a)
+		}
+		seen[a] = true
+	}
+	*s = SigningSession{Transaction: tx, Permission: v.Permission}
+
+	return nil
+}
+
+// ExtendExpiration moves the expiration of the transaction by d. The txID changes, so it is only
+// possible before the first signature, and the expiration may not exceed MaxTransactionLifetime hours.
+// d must be positive.
+func (s *SigningSession) ExtendExpiration(d time.Duration) error {
+	if d <= 0 {
+		return fmt.Errorf("%w: expiration can only be extended, not by %s", ErrInvalidRequest, d)
+	}
+	raw := &s.Transaction.RawData
+	if raw.Expiration+d.Milliseconds()-raw.Timestamp > (MaxTransactionLifetime * time.Hour).Milliseconds() {
+		return fmt.Errorf("%w: expiration more than %d hours after the transaction was created",
+			ErrInvalidRequest, MaxTransactionLifetime)
+	}
+
+	return s.modify(func(raw *TransactionRawData) { raw.Expiration += d.Milliseconds() })
+}
+
+// Expiration returns when the transaction expires.
+func (s *SigningSession) Expiration() time.Time {
+	return time.UnixMilli(s.Transaction.RawData.Expiration)
+}
+
+// modify changes raw_data and reseals the transaction, which is only possible before the first signature.
+func (s *SigningSession) modify(f func(*TransactionRawData)) error {
+	if len(s.Transaction.Signature) != 0 {
+		return fmt.Errorf("%w: transaction is already signed", ErrInvalidRequest)
+	}
+
+	tx := *s.Transaction
+	tx.RawData.Contract = append([]Contract(nil), tx.RawData.Contract...)
+	f(&tx.RawData)
+	if err := SealTransaction(&tx); err != nil {
+		return err
+	}
+	*s.Transaction = tx
+
+	return nil
+}
+
+// Sign adds the signature of key. key must belong to the permission and not have signed yet.
+func (s *SigningSession) Sign(key *PrivateKey) error {
+	if err := checkPermission(s.Transaction, s.Permission); err != nil {
+		return err
+	}
+	a := key.Address()
+	if s.Permission.Weight(a) == 0 {
+		return fmt.Errorf("%w: %s is not a key of permission %q", ErrInvalidRequest, a, s.Permission.Name)
+	}
+	signers, err := TransactionSigners(s.Transaction)
+	if err != nil {
+		return err
+	}
+	for _, signer := range signers {
+		if signer == a {
+			return fmt.Errorf("%w: %s has already signed", ErrInvalidRequest, a)
+		}
+	}
+
+	return SignTransaction(s.Transaction, key)
+}
+
+// Merge adds the signatures of other, a copy of the session signed by other parties.
+// Signatures of keys that already signed are skipped.
+func (s *SigningSession) Merge(other *SigningSession) error {
+	if other.Transaction.TxID != s.Transaction.TxID || other.Transaction.RawDataHex != s.Transaction.RawDataHex {
+		return fmt.Errorf("%w: signing sessions are for different transactions", ErrInvalidRequest)
+	}
+	if err := checkPermission(s.Transaction, s.Permission); err != nil {
+		return err
+	}
+	if other.Permission == nil || other.Permission.ID != s.Permission.ID {
+		return fmt.Errorf("%w: signing sessions are for different permissions", ErrInvalidRequest)
+	}
+
+	signers, err := TransactionSigners(s.Transaction)
+	if err != nil {
+		return err
+	}
+	seen := make(map[Address]bool, len(signers))
+	for _, a := range signers {
+		seen[a] = true
+	}
+
+	added, err := TransactionSigners(other.Transaction)
+	if err != nil {
+		return err
+	}
+	for i, a := range added {
+		if seen[a] {
+			continue
+		}
+		if s.Permission.Weight(a) == 0 {
+			return fmt.Errorf("%w: %s is not a key of permission %q", ErrInvalidRequest, a, s.Permission.Name)
+		}
+		seen[a] = true
+		s.Transaction.Signature = append(s.Transaction.Signature, other.Transaction.Signature[i])
+	}
+
+	return nil
+}
+
+// checkPermission returns an error unless the single contract of tx uses permission, whose keys
+// would otherwise sign for a permission the node does not check them against.
+func checkPermission(tx *Transaction, permission *Permission) error {
+	if len(tx.RawData.Contract) != 1 {
+		return fmt.Errorf("%w: transaction must have exactly one contract", ErrInvalidRequest)
+	}
+	if id := tx.RawData.Contract[0].PermissionID; id != permission.ID {
+		return fmt.Errorf("%w: contract uses permission %d, not %q (%d)",
+			ErrInvalidRequest, id, permission.Name, permission.ID)
+	}
+
+	return nil
+}
+
+// Weight returns the weight of the signatures against the threshold of the permission, computed
+// locally like GetSignWeight. Every key counts once, however many times it signed. Its result code
+// is SignWeightEnough once the transaction can be broadcast.
+func (s *SigningSession) Weight() (*SignWeight, error) {
+	signers, err := TransactionSigners(s.Transaction)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &SignWeight{Permission: s.Permission}
+	seen := make(map[Address]bool, len(signers))
+	for _, a := range signers {
+		if seen[a] {
+			continue
+		}
+		seen[a] = true
+		w.CurrentWeight += s.Permission.Weight(a)
+		w.ApprovedList = append(w.ApprovedList, a.String())
+	}
+	if w.CurrentWeight < s.Permission.Threshold {
+		w.Result.Code = SignWeightNotEnough
+		w.Result.Message = fmt.Sprintf("Signature weight is %d, threshold is %d", w.CurrentWeight, s.Permission.Threshold)
+	} else {
+		w.Result.Code = SignWeightEnough
+	}
+
+	return w, nil
+}
+
+// Broadcast broadcasts the transaction once the weight of its signatures reaches the threshold,
+// returning ErrSignWeightNotEnough before.
+func (s *SigningSession) Broadcast(ctx context.Context, b Broadcaster) (*BroadcastResponse, error) {
+	w, err := s.Weight()
+	if err != nil {
+		return nil, err
+	}
+	if !w.Enough() {
+		return nil, fmt.Errorf("%w: %s", ErrSignWeightNotEnough, w.Result.Message)
+	}
+
+	return b.BroadcastTransaction(ctx, s.Transaction)
+}
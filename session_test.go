@@ -0,0 +1,170 @@
+package trongrid_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+func TestSigningSession(t *testing.T) {
+	ctx := context.Background()
+	chain := trongridtest.NewChain()
+
+	owner := mustGenerateKey(t)
+	signers := []*trongrid.PrivateKey{mustGenerateKey(t), mustGenerateKey(t), mustGenerateKey(t)}
+	to := mustGenerateKey(t)
+	require.NoError(t, chain.Fund(owner.Address().String(), 10_000_000))
+
+	keys := make([]trongrid.PermissionKey, len(signers))
+	for i, k := range signers {
+		keys[i] = trongrid.PermissionKey{Address: k.Address().String(), Weight: 1}
+	}
+	active := &trongrid.Permission{Type: trongrid.PermissionActive, Name: "payouts", Threshold: 2, Keys: keys}
+	require.NoError(t, active.SetOperations(trongrid.ContractTypeTRX))
+	update, err := chain.AccountPermissionUpdate(ctx, &trongrid.AccountPermissionUpdateRequest{
+		OwnerAddress: owner.Address().String(),
+		Owner:        &trongrid.Permission{Name: "owner", Threshold: 1, Keys: keys[:1]},
+		Actives:      []*trongrid.Permission{active},
+		Visible:      true,
+	})
+	require.NoError(t, err)
+	require.NoError(t, trongrid.SignTransaction(update, owner))
+	_, err = chain.BroadcastTransaction(ctx, update)
+	require.NoError(t, err)
+	chain.Produce()
+
+	account, err := chain.GetAccount(ctx, &trongrid.GetAccountRequest{Address: owner.Address().String()})
+	require.NoError(t, err)
+	tx, err := chain.CreateTransaction(ctx, &trongrid.CreateTransactionRequest{
+		OwnerAddress: owner.Address().String(),
+		ToAddress:    to.Address().String(),
+		Amount:       1_000_000,
+		Visible:      true,
+	})
+	require.NoError(t, err)
+
+	// the session switches the transaction to the active permission
+	session, err := trongrid.NewSigningSession(tx, account.ActivePermission[0])
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), session.Transaction.RawData.Contract[0].PermissionID)
+
+	expiration := session.Expiration()
+	txID := session.Transaction.TxID
+	require.NoError(t, session.ExtendExpiration(time.Hour))
+	assert.Equal(t, expiration.Add(time.Hour), session.Expiration())
+	assert.NotEqual(t, txID, session.Transaction.TxID)
+	require.ErrorIs(t, session.ExtendExpiration(trongrid.MaxTransactionLifetime*time.Hour), trongrid.ErrInvalidRequest)
+	require.ErrorIs(t, session.ExtendExpiration(0), trongrid.ErrInvalidRequest)
+	require.ErrorIs(t, session.ExtendExpiration(-time.Hour), trongrid.ErrInvalidRequest)
+
+	// two parties sign their own copies
+	exported, err := session.Encode()
+	require.NoError(t, err)
+	first, err := trongrid.DecodeSigningSession(exported)
+	require.NoError(t, err)
+	b, err := json.Marshal(session)
+	require.NoError(t, err)
+	second := new(trongrid.SigningSession)
+	require.NoError(t, json.Unmarshal(b, second))
+
+	// a session whose permission is not the one of the contract is rejected
+	var v map[string]interface{}
+	require.NoError(t, json.Unmarshal(b, &v))
+	v["permission"] = account.OwnerPermission
+	tampered, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.ErrorIs(t, json.Unmarshal(tampered, new(trongrid.SigningSession)), trongrid.ErrInvalidRequest)
+	owned := &trongrid.SigningSession{Transaction: first.Transaction, Permission: account.OwnerPermission}
+	require.ErrorIs(t, session.Merge(owned), trongrid.ErrInvalidRequest)
+	require.ErrorIs(t, owned.Sign(signers[0]), trongrid.ErrInvalidRequest)
+
+	require.ErrorIs(t, first.Sign(owner), trongrid.ErrInvalidRequest)
+	require.NoError(t, first.Sign(signers[0]))
+	require.ErrorIs(t, first.Sign(signers[0]), trongrid.ErrInvalidRequest)
+	require.ErrorIs(t, first.ExtendExpiration(time.Minute), trongrid.ErrInvalidRequest)
+	require.NoError(t, second.Sign(signers[2]))
+
+	// raw_data shown to the parties must be the transaction they sign
+	require.NoError(t, json.Unmarshal(b, &v))
+	v["raw_data"].(map[string]interface{})["expiration"] = json.Number("1")
+	tampered, err = json.Marshal(v)
+	require.NoError(t, err)
+	require.ErrorIs(t, json.Unmarshal(tampered, new(trongrid.SigningSession)), trongrid.ErrInvalidRequest)
+
+	// a key signing twice, even with a malleated copy of its signature, counts once
+	sig, err := hex.DecodeString(first.Transaction.Signature[0])
+	require.NoError(t, err)
+	n, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	highS := new(big.Int).Sub(n, new(big.Int).SetBytes(sig[32:64])).FillBytes(make([]byte, 32))
+	recovery := sig[64]
+	if recovery >= 27 {
+		recovery = 27 + ((recovery - 27) ^ 1)
+	} else {
+		recovery ^= 1
+	}
+	malleated := hex.EncodeToString(append(append(append([]byte(nil), sig[:32]...), highS...), recovery))
+	for _, dup := range []string{first.Transaction.Signature[0], malleated} {
+		twice := &trongrid.SigningSession{Transaction: &trongrid.Transaction{}, Permission: first.Permission}
+		*twice.Transaction = *first.Transaction
+		twice.Transaction.Signature = []string{first.Transaction.Signature[0], dup}
+
+		weight, err := twice.Weight()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), weight.CurrentWeight)
+		assert.False(t, weight.Enough())
+
+		exported, err := json.Marshal(twice)
+		require.NoError(t, err)
+		require.ErrorIs(t, json.Unmarshal(exported, new(trongrid.SigningSession)), trongrid.ErrInvalidRequest)
+	}
+
+	weight, err := first.Weight()
+	require.NoError(t, err)
+	assert.False(t, weight.Enough())
+	_, err = first.Broadcast(ctx, chain)
+	require.ErrorIs(t, err, trongrid.ErrSignWeightNotEnough)
+
+	require.NoError(t, session.Merge(first))
+	require.NoError(t, session.Merge(second))
+	require.NoError(t, session.Merge(first))
+	assert.Len(t, session.Transaction.Signature, 2)
+
+	weight, err = session.Weight()
+	require.NoError(t, err)
+	assert.True(t, weight.Enough())
+	assert.Equal(t, int64(2), weight.CurrentWeight)
+
+	resp, err := session.Broadcast(ctx, chain)
+	require.NoError(t, err)
+	assert.True(t, resp.Result)
+	chain.Produce()
+	assert.Equal(t, int64(1_000_000), chain.Balance(to.Address().String()))
+
+	other, err := chain.CreateTransaction(ctx, &trongrid.CreateTransactionRequest{
+		OwnerAddress: owner.Address().String(),
+		ToAddress:    to.Address().String(),
+		Amount:       1,
+	})
+	require.NoError(t, err)
+	otherSession, err := trongrid.NewSigningSession(other, account.ActivePermission[0])
+	require.NoError(t, err)
+	require.ErrorIs(t, session.Merge(otherSession), trongrid.ErrInvalidRequest)
+}
+
+func mustGenerateKey(t *testing.T) *trongrid.PrivateKey {
+	t.Helper()
+
+	key, err := trongrid.GenerateKey()
+	require.NoError(t, err)
+
+	return key
+}
@@ -0,0 +1,79 @@
+package trongrid
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// TransactionHash returns the hash signed by the owners of tx: sha256 of raw_data_hex, which is also the txID.
+func TransactionHash(tx *Transaction) ([]byte, error) {
+	raw, err := hex.DecodeString(tx.RawDataHex)
+	if err != nil || len(raw) == 0 {
+		return nil, fmt.Errorf("%w: raw_data_hex", ErrInvalidRequest)
+	}
+
+	sum := sha256.Sum256(raw)
+	if tx.TxID != "" && !strings.EqualFold(tx.TxID, hex.EncodeToString(sum[:])) {
+		return nil, fmt.Errorf("%w: txID does not match raw_data_hex", ErrInvalidRequest)
+	}
+
+	return sum[:], nil
+}
+
+// SealTransaction encodes raw_data into raw_data_hex and sets the txID.
+// Only the contract types the library builds are supported, e.g. transfers, staking and voting.
+func SealTransaction(tx *Transaction) error {
+	raw, err := encodeRawData(&tx.RawData)
+	if err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(raw)
+	tx.RawDataHex = hex.EncodeToString(raw)
+	tx.TxID = hex.EncodeToString(sum[:])
+
+	return nil
+}
+
+// SignTransaction appends the signature of key to tx.
+func SignTransaction(tx *Transaction, key *PrivateKey) error {
+	hash, err := TransactionHash(tx)
+	if err != nil {
+		return err
+	}
+
+	sig, err := key.Sign(hash)
+	if err != nil {
+		return err
+	}
+
+	tx.Signature = append(tx.Signature, hex.EncodeToString(sig))
+
+	return nil
+}
+
+// TransactionSigners returns the addresses that signed tx, in signature order.
+func TransactionSigners(tx *Transaction) ([]Address, error) {
+	hash, err := TransactionHash(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	signers := make([]Address, 0, len(tx.Signature))
+	for _, s := range tx.Signature {
+		sig, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+		}
+
+		a, err := RecoverAddress(hash, sig)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, a)
+	}
+
+	return signers, nil
+}
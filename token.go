@@ -0,0 +1,281 @@
+package trongrid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultUnresolvedTokenTTL is how long a TokenRegistry remembers a token whose metadata cannot be read.
+const DefaultUnresolvedTokenTTL = 10 * time.Minute
+
+// TokenRegistryOption configures a TokenRegistry.
+type TokenRegistryOption func(*TokenRegistry)
+
+// WithTokens overrides the metadata of the given tokens, e.g. for contracts returning
+// a misleading symbol. Overrides are trusted like the network's well-known tokens.
+func WithTokens(tokens ...Token) TokenRegistryOption {
+	return func(r *TokenRegistry) {
+		for _, t := range tokens {
+			if a, err := ParseAddress(t.Address); err == nil {
+				t.Address = a.String()
+				r.trusted[a] = t
+			}
+		}
+	}
+}
+
+// WithTokenAllowlist restricts resolution to the given contracts. Well-known tokens and
+// overrides are always allowed.
+func WithTokenAllowlist(addresses ...string) TokenRegistryOption {
+	return func(r *TokenRegistry) {
+		if r.allow == nil {
+			r.allow = make(map[Address]bool, len(addresses))
+		}
+		for _, s := range addresses {
+			if a, err := ParseAddress(s); err == nil {
+				r.allow[a] = true
+			}
+		}
+	}
+}
+
+// WithTokenDenylist rejects the given contracts, e.g. known lookalikes of popular tokens.
+func WithTokenDenylist(addresses ...string) TokenRegistryOption {
+	return func(r *TokenRegistry) {
+		for _, s := range addresses {
+			if a, err := ParseAddress(s); err == nil {
+				r.deny[a] = true
+			}
+		}
+	}
+}
+
+// WithUnresolvedTokenTTL sets how long a token whose metadata cannot be read, e.g. an airdropped
+// spam token, is remembered instead of being called again. Zero calls it on every lookup.
+func WithUnresolvedTokenTTL(ttl time.Duration) TokenRegistryOption {
+	return func(r *TokenRegistry) {
+		r.unresolvedTTL = ttl
+	}
+}
+
+// TokenRegistry resolves TRC20 token metadata. It is seeded with the network's well-known tokens
+// and resolves any other contract by calling name(), symbol() and decimals(), caching the result.
+// Contracts that do not answer are remembered for DefaultUnresolvedTokenTTL.
+//
+// Anyone can deploy a token calling itself "USDT". Only trusted tokens, the well-known ones and
+// overrides, are returned by Symbol; use the allowlist or denylist to control other contracts.
+type TokenRegistry struct {
+	caller ContractCaller
+
+	mu       sync.RWMutex
+	trusted  map[Address]Token
+	resolved map[Address]Token
+	allow    map[Address]bool
+	deny     map[Address]bool
+
+	unresolved    map[Address]unresolvedToken
+	unresolvedTTL time.Duration
+}
+
+// unresolvedToken is the error resolving a token, returned again until it expires.
+type unresolvedToken struct {
+	err     error
+	expires time.Time
+}
+
+// NewTokenRegistry returns a registry resolving tokens through caller, seeded with the
+// well-known tokens of network. network may be nil.
+func NewTokenRegistry(caller ContractCaller, network *Network, opts ...TokenRegistryOption) *TokenRegistry {
+	r := &TokenRegistry{
+		caller:   caller,
+		trusted:  make(map[Address]Token),
+		resolved: make(map[Address]Token),
+		deny:     make(map[Address]bool),
+
+		unresolved:    make(map[Address]unresolvedToken),
+		unresolvedTTL: DefaultUnresolvedTokenTTL,
+	}
+
+	if network != nil {
+		for _, t := range network.Tokens {
+			if a, err := ParseAddress(t.Address); err == nil {
+				r.trusted[a] = t
+			}
+		}
+	}
+
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	return r
+}
+
+// Register adds or replaces a trusted token.
+func (r *TokenRegistry) Register(token Token) error {
+	a, err := ParseAddress(token.Address)
+	if err != nil {
+		return err
+	}
+	token.Address = a.String()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.trusted[a] = token
+	delete(r.deny, a)
+
+	return nil
+}
+
+// Lookup returns the metadata of the token deployed at address, resolving it on chain if needed.
+// It fails with ErrTokenNotAllowed for denied contracts and, when an allowlist is set,
+// for contracts not on it. A contract whose metadata cannot be read fails with the same error,
+// without being called again, until WithUnresolvedTokenTTL has passed.
+func (r *TokenRegistry) Lookup(ctx context.Context, address string) (Token, error) {
+	a, err := ParseAddress(address)
+	if err != nil {
+		return Token{}, err
+	}
+
+	r.mu.RLock()
+	t, trusted := r.trusted[a]
+	cached, ok := r.resolved[a]
+	denied := r.deny[a]
+	allowed := r.allow == nil || r.allow[a]
+	unresolved, failed := r.unresolved[a]
+	r.mu.RUnlock()
+
+	switch {
+	case trusted:
+		return t, nil
+	case denied || !allowed:
+		return Token{}, fmt.Errorf("%w: %s", ErrTokenNotAllowed, a)
+	case ok:
+		return cached, nil
+	case failed && time.Now().Before(unresolved.expires):
+		return Token{}, unresolved.err
+	}
+
+	if t, err = r.resolve(ctx, a); err != nil {
+		if unresolvableToken(err) && r.unresolvedTTL > 0 {
+			r.mu.Lock()
+			r.unresolved[a] = unresolvedToken{err: err, expires: time.Now().Add(r.unresolvedTTL)}
+			r.mu.Unlock()
+		}
+
+		return Token{}, err
+	}
+
+	r.mu.Lock()
+	r.resolved[a] = t
+	delete(r.unresolved, a)
+	r.mu.Unlock()
+
+	return t, nil
+}
+
+// Trusted reports whether the token at address is well-known, overridden or allowlisted.
+func (r *TokenRegistry) Trusted(address string) bool {
+	a, err := ParseAddress(address)
+	if err != nil {
+		return false
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.trusted[a]
+
+	return ok || r.allow[a] && !r.deny[a]
+}
+
+// Symbol returns the trusted token with the given symbol (case-insensitive).
+// Resolved tokens are never returned, as their symbols can be spoofed.
+func (r *TokenRegistry) Symbol(symbol string) (Token, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, t := range r.trusted {
+		if strings.EqualFold(t.Symbol, symbol) {
+			return t, true
+		}
+	}
+
+	return Token{}, false
+}
+
+// Amount returns value, in base units of the token at address, with the token's decimals.
+func (r *TokenRegistry) Amount(ctx context.Context, address, value string) (Amount, error) {
+	t, err := r.Lookup(ctx, address)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	return AmountFromBaseUnits(value, t.Decimals)
+}
+
+// FormatAmount formats value, in base units of the token at address, in whole tokens.
+func (r *TokenRegistry) FormatAmount(ctx context.Context, address, value string) (string, error) {
+	a, err := r.Amount(ctx, address, value)
+	if err != nil {
+		return "", err
+	}
+
+	return a.String(), nil
+}
+
+// ParseAmount parses s, in whole tokens of the token at address, e.g. a withdrawal amount typed by a user.
+func (r *TokenRegistry) ParseAmount(ctx context.Context, address, s string) (Amount, error) {
+	t, err := r.Lookup(ctx, address)
+	if err != nil {
+		return Amount{}, err
+	}
+
+	return ParseAmount(s, t.Decimals)
+}
+
+// unresolvableToken reports whether err is a token that does not answer its metadata calls, as opposed
+// to a network or rate limit failure worth retrying the whole call for.
+func unresolvableToken(err error) bool {
+	return errors.Is(err, ErrInvalidToken) || errors.Is(err, ErrInvalidAddress) ||
+		errors.Is(err, ErrInvalidRequest) || errors.Is(err, ErrEmpty)
+}
+
+func (r *TokenRegistry) resolve(ctx context.Context, a Address) (Token, error) {
+	contract := a.String()
+	t := Token{Address: contract}
+
+	b, err := callConstant(ctx, r.caller, contract, "decimals()")
+	if err != nil {
+		return Token{}, err
+	}
+	decimals, err := decodeABIUint(b)
+	if err != nil {
+		return Token{}, err
+	}
+	if !decimals.IsInt64() || decimals.Int64() > 77 {
+		return Token{}, fmt.Errorf("%w: %s has %s decimals", ErrInvalidToken, contract, decimals)
+	}
+	t.Decimals = int32(decimals.Int64())
+
+	if b, err = callConstant(ctx, r.caller, contract, "symbol()"); err != nil {
+		return Token{}, err
+	}
+	if t.Symbol, err = decodeABIString(b); err != nil {
+		return Token{}, err
+	}
+
+	if b, err = callConstant(ctx, r.caller, contract, "name()"); err != nil {
+		return Token{}, err
+	}
+	if t.Name, err = decodeABIString(b); err != nil {
+		return Token{}, err
+	}
+
+	return t, nil
+}
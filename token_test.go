@@ -0,0 +1,112 @@
+package trongrid_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+func TestTokenRegistry(t *testing.T) {
+	ctx := context.Background()
+	chain := trongridtest.NewChain()
+	jst, err := chain.DeployTRC20(trongrid.Token{Symbol: "JST", Name: "JUST", Decimals: 18})
+	require.NoError(t, err)
+	fake, err := chain.DeployTRC20(trongrid.Token{Symbol: "USDT", Name: "Tether USD", Decimals: 6})
+	require.NoError(t, err)
+
+	mock := &trongridtest.Mock{Fallback: chain}
+	mainnet, err := trongrid.GetNetwork(trongrid.Mainnet)
+	require.NoError(t, err)
+	registry := trongrid.NewTokenRegistry(mock, mainnet, trongrid.WithTokenDenylist(fake))
+
+	usdt, err := registry.Lookup(ctx, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t")
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, usdt.Decimals)
+	mock.AssertCalled(t, "TriggerConstantContract", 0)
+
+	token, err := registry.Lookup(ctx, jst)
+	require.NoError(t, err)
+	assert.Equal(t, trongrid.Token{Address: jst, Name: "JUST", Symbol: "JST", Decimals: 18}, token)
+	_, err = registry.Lookup(ctx, jst)
+	require.NoError(t, err)
+	mock.AssertCalled(t, "TriggerConstantContract", 3)
+
+	_, err = registry.Lookup(ctx, fake)
+	require.ErrorIs(t, err, trongrid.ErrTokenNotAllowed)
+
+	found, ok := registry.Symbol("usdt")
+	require.True(t, ok)
+	assert.Equal(t, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", found.Address)
+	_, ok = registry.Symbol("JST")
+	require.True(t, ok, "well-known mainnet JST")
+	assert.False(t, registry.Trusted(jst))
+
+	formatted, err := registry.FormatAmount(ctx, jst, "1500000000000000000")
+	require.NoError(t, err)
+	assert.Equal(t, "1.5", formatted)
+
+	amount, err := registry.ParseAmount(ctx, "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t", "20")
+	require.NoError(t, err)
+	assert.Equal(t, "20000000", amount.BaseUnits())
+}
+
+func TestTokenRegistry_Allowlist(t *testing.T) {
+	ctx := context.Background()
+	chain := trongridtest.NewChain()
+	allowed, err := chain.DeployTRC20(trongrid.Token{Symbol: "A", Decimals: 2})
+	require.NoError(t, err)
+	other, err := chain.DeployTRC20(trongrid.Token{Symbol: "B", Decimals: 2})
+	require.NoError(t, err)
+	override, err := chain.DeployTRC20(trongrid.Token{Symbol: "C", Decimals: 2})
+	require.NoError(t, err)
+
+	registry := trongrid.NewTokenRegistry(chain, nil,
+		trongrid.WithTokenAllowlist(allowed),
+		trongrid.WithTokens(trongrid.Token{Address: override, Symbol: "CEE", Decimals: 4}),
+	)
+
+	_, err = registry.Lookup(ctx, allowed)
+	require.NoError(t, err)
+	assert.True(t, registry.Trusted(allowed))
+
+	_, err = registry.Lookup(ctx, other)
+	require.ErrorIs(t, err, trongrid.ErrTokenNotAllowed)
+
+	token, err := registry.Lookup(ctx, override)
+	require.NoError(t, err)
+	assert.Equal(t, "CEE", token.Symbol)
+	assert.EqualValues(t, 4, token.Decimals)
+}
+
+func TestTokenRegistry_Unresolved(t *testing.T) {
+	ctx := context.Background()
+	chain := trongridtest.NewChain()
+	spam := mustGenerateKey(t).Address().String()
+
+	mock := &trongridtest.Mock{Fallback: chain}
+	registry := trongrid.NewTokenRegistry(mock, nil)
+
+	// a contract without metadata is called once and then remembered
+	_, err := registry.Lookup(ctx, spam)
+	require.Error(t, err)
+	mock.AssertCalled(t, "TriggerConstantContract", 1)
+	_, cachedErr := registry.Lookup(ctx, spam)
+	require.Equal(t, err, cachedErr)
+	mock.AssertCalled(t, "TriggerConstantContract", 1)
+
+	// once remembered long enough, it is called again
+	mock = &trongridtest.Mock{Fallback: chain}
+	registry = trongrid.NewTokenRegistry(mock, nil, trongrid.WithUnresolvedTokenTTL(time.Millisecond))
+	_, err = registry.Lookup(ctx, spam)
+	require.Error(t, err)
+	time.Sleep(5 * time.Millisecond)
+	_, err = registry.Lookup(ctx, spam)
+	require.Error(t, err)
+	mock.AssertCalled(t, "TriggerConstantContract", 2)
+}
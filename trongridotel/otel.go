@@ -0,0 +1,182 @@
+// Package trongridotel reports trongrid client spans and metrics through OpenTelemetry.
+package trongridotel
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+const scope = "github.com/eliohn/go-trongrid"
+
+type instrumentation struct {
+	tracer      trace.Tracer
+	requests    metric.Int64Counter
+	errors      metric.Int64Counter
+	retries     metric.Int64Counter
+	cacheHits   metric.Int64Counter
+	latency     metric.Float64Histogram
+	limiterWait metric.Float64Histogram
+}
+
+type span struct {
+	s trace.Span
+}
+
+type Option func(*config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	meterProvider  metric.MeterProvider
+}
+
+// WithTracerProvider uses provider instead of the global tracer provider.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithMeterProvider uses provider instead of the global meter provider.
+func WithMeterProvider(provider metric.MeterProvider) Option {
+	return func(c *config) {
+		c.meterProvider = provider
+	}
+}
+
+// New returns a trongrid.Instrumentation backed by OpenTelemetry. It records:
+//
+//   - trongrid.requests: attempts per endpoint and status
+//   - trongrid.errors: failed attempts per endpoint
+//   - trongrid.retries: retries per endpoint
+//   - trongrid.cache.hits: calls answered from the cache per endpoint, which have no span
+//   - trongrid.request.duration: attempt latency in seconds
+//   - trongrid.ratelimiter.wait: time spent waiting on the rate limiter in seconds
+func New(opts ...Option) (trongrid.Instrumentation, error) {
+	c := &config{
+		tracerProvider: otel.GetTracerProvider(),
+		meterProvider:  otel.GetMeterProvider(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	meter := c.meterProvider.Meter(scope)
+	x := &instrumentation{tracer: c.tracerProvider.Tracer(scope)}
+
+	var err error
+	if x.requests, err = meter.Int64Counter("trongrid.requests",
+		metric.WithDescription("Number of HTTP attempts")); err != nil {
+		return nil, err
+	}
+	if x.errors, err = meter.Int64Counter("trongrid.errors",
+		metric.WithDescription("Number of failed HTTP attempts")); err != nil {
+		return nil, err
+	}
+	if x.retries, err = meter.Int64Counter("trongrid.retries",
+		metric.WithDescription("Number of retried HTTP attempts")); err != nil {
+		return nil, err
+	}
+	if x.cacheHits, err = meter.Int64Counter("trongrid.cache.hits",
+		metric.WithDescription("Number of calls answered from the cache")); err != nil {
+		return nil, err
+	}
+	if x.latency, err = meter.Float64Histogram("trongrid.request.duration",
+		metric.WithDescription("Latency of HTTP attempts"), metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+	if x.limiterWait, err = meter.Float64Histogram("trongrid.ratelimiter.wait",
+		metric.WithDescription("Time spent waiting on the rate limiter"), metric.WithUnit("s")); err != nil {
+		return nil, err
+	}
+
+	return x, nil
+}
+
+func (x *instrumentation) StartSpan(
+	ctx context.Context,
+	endpoint string,
+	attrs ...trongrid.Attribute,
+) (context.Context, trongrid.Span) {
+	ctx, s := x.tracer.Start(ctx, "trongrid."+endpoint,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(convert(attrs)...),
+	)
+
+	return ctx, &span{s: s}
+}
+
+func (x *instrumentation) RecordRequest(
+	ctx context.Context,
+	endpoint string,
+	status int,
+	latency time.Duration,
+	err error,
+) {
+	attrs := metric.WithAttributes(
+		attribute.String(trongrid.AttributeEndpoint, endpoint),
+		attribute.Int(trongrid.AttributeStatus, status),
+	)
+
+	x.requests.Add(ctx, 1, attrs)
+	x.latency.Record(ctx, latency.Seconds(), attrs)
+	if err != nil || status >= 400 {
+		x.errors.Add(ctx, 1, attrs)
+	}
+}
+
+func (x *instrumentation) RecordRetry(ctx context.Context, endpoint string, _ int) {
+	x.retries.Add(ctx, 1, metric.WithAttributes(attribute.String(trongrid.AttributeEndpoint, endpoint)))
+}
+
+func (x *instrumentation) RecordRateLimiterWait(ctx context.Context, endpoint string, wait time.Duration) {
+	x.limiterWait.Record(ctx, wait.Seconds(),
+		metric.WithAttributes(attribute.String(trongrid.AttributeEndpoint, endpoint)))
+}
+
+func (x *instrumentation) RecordCacheHit(ctx context.Context, endpoint string) {
+	x.cacheHits.Add(ctx, 1, metric.WithAttributes(attribute.String(trongrid.AttributeEndpoint, endpoint)))
+}
+
+func (s *span) SetAttributes(attrs ...trongrid.Attribute) {
+	s.s.SetAttributes(convert(attrs)...)
+}
+
+func (s *span) RecordError(err error) {
+	s.s.RecordError(err)
+	s.s.SetStatus(codes.Error, err.Error())
+}
+
+func (s *span) End() {
+	s.s.End()
+}
+
+func convert(attrs []trongrid.Attribute) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, 0, len(attrs))
+	for _, a := range attrs {
+		switch v := a.Value.(type) {
+		case string:
+			kvs = append(kvs, attribute.String(a.Key, v))
+		case int:
+			kvs = append(kvs, attribute.Int(a.Key, v))
+		case int64:
+			kvs = append(kvs, attribute.Int64(a.Key, v))
+		case bool:
+			kvs = append(kvs, attribute.Bool(a.Key, v))
+		case float64:
+			kvs = append(kvs, attribute.Float64(a.Key, v))
+		default:
+			kvs = append(kvs, attribute.String(a.Key, fmt.Sprint(v)))
+		}
+	}
+
+	return kvs
+}
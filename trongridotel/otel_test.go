@@ -0,0 +1,123 @@
+package trongridotel_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridotel"
+)
+
+func TestNew(t *testing.T) {
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&hits, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+
+			return
+		}
+		_, _ = w.Write([]byte(`{"txID":"abc","raw_data":{}}`))
+	}))
+	defer srv.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	instrumentation, err := trongridotel.New(
+		trongridotel.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		trongridotel.WithMeterProvider(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))),
+	)
+	require.NoError(t, err)
+
+	api := trongrid.NewAPI(
+		trongrid.WithURI(srv.URL),
+		trongrid.WithRateLimit(1000, 10),
+		trongrid.WithRetryPolicy(trongrid.RetryPolicy{MaxRetries: 1, BaseDelay: time.Millisecond}),
+		trongrid.WithCache(trongrid.NewMemoryCache(10)),
+		trongrid.WithInstrumentation(instrumentation),
+	)
+
+	// the second call is answered from the cache
+	req := &trongrid.GetTransactionRequest{ID: "abc", OnlyConfirmed: true}
+	for i := 0; i < 2; i++ {
+		_, err = api.GetTransactionByID(context.Background(), req)
+		require.NoError(t, err)
+	}
+
+	ended := spans.Ended()
+	require.Len(t, ended, 1)
+	assert.Equal(t, "trongrid.GetTransactionByID", ended[0].Name())
+	assert.Equal(t, trace.SpanKindClient, ended[0].SpanKind())
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String(trongrid.AttributeEndpoint, "GetTransactionByID"),
+		attribute.String(trongrid.AttributeNetwork, ""),
+		attribute.Int(trongrid.AttributeStatus, http.StatusOK),
+		attribute.Int(trongrid.AttributeAttempts, 2),
+	}, ended[0].Attributes())
+
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+	metrics := make(map[string]metricdata.Aggregation)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m.Data
+		}
+	}
+
+	assert.Equal(t, int64(2), sum(t, metrics["trongrid.requests"]))
+	assert.Equal(t, int64(1), sum(t, metrics["trongrid.errors"]))
+	assert.Equal(t, int64(1), sum(t, metrics["trongrid.retries"]))
+	assert.Equal(t, int64(1), sum(t, metrics["trongrid.cache.hits"]))
+	assert.Equal(t, uint64(2), count(t, metrics["trongrid.request.duration"]))
+	assert.Equal(t, uint64(2), count(t, metrics["trongrid.ratelimiter.wait"]))
+
+	// requests are counted per status
+	requests, ok := metrics["trongrid.requests"].(metricdata.Sum[int64])
+	require.True(t, ok)
+	statuses := make(map[int64]int64)
+	for _, p := range requests.DataPoints {
+		status, _ := p.Attributes.Value(trongrid.AttributeStatus)
+		statuses[status.AsInt64()] += p.Value
+	}
+	assert.Equal(t, map[int64]int64{http.StatusBadGateway: 1, http.StatusOK: 1}, statuses)
+}
+
+func sum(t *testing.T, data metricdata.Aggregation) int64 {
+	t.Helper()
+
+	s, ok := data.(metricdata.Sum[int64])
+	require.True(t, ok, "%T", data)
+
+	var total int64
+	for _, p := range s.DataPoints {
+		total += p.Value
+	}
+
+	return total
+}
+
+func count(t *testing.T, data metricdata.Aggregation) uint64 {
+	t.Helper()
+
+	h, ok := data.(metricdata.Histogram[float64])
+	require.True(t, ok, "%T", data)
+
+	var total uint64
+	for _, p := range h.DataPoints {
+		total += p.Count
+	}
+
+	return total
+}
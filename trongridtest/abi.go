@@ -0,0 +1,18 @@
+package trongridtest
+
+import "math/big"
+
+// abiUint encodes v as a uint256 return value.
+func abiUint(v *big.Int) []byte {
+	return v.FillBytes(make([]byte, 32))
+}
+
+// abiString encodes s as a string return value: offset, length and padded bytes.
+func abiString(s string) []byte {
+	b := make([]byte, 64+(len(s)+31)/32*32)
+	b[31] = 32
+	big.NewInt(int64(len(s))).FillBytes(b[32:64])
+	copy(b[64:], s)
+
+	return b
+}
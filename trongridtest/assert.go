@@ -0,0 +1,81 @@
+package trongridtest
+
+// TestingT is the subset of testing.TB used by the assertions.
+type TestingT interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// AssertRequested checks that a request with method was sent to path.
+func (s *Server) AssertRequested(t TestingT, method, path string) bool {
+	t.Helper()
+
+	for _, r := range s.RequestsTo(path) {
+		if r.Method == method {
+			return true
+		}
+	}
+
+	t.Errorf("trongridtest: expected %s %s to be requested", method, path)
+
+	return false
+}
+
+// AssertNotRequested checks that no request was sent to path.
+func (s *Server) AssertNotRequested(t TestingT, path string) bool {
+	t.Helper()
+
+	if n := len(s.RequestsTo(path)); n != 0 {
+		t.Errorf("trongridtest: expected %s not to be requested, got %d requests", path, n)
+
+		return false
+	}
+
+	return true
+}
+
+// AssertRequestCount checks that exactly n requests were sent to path.
+func (s *Server) AssertRequestCount(t TestingT, path string, n int) bool {
+	t.Helper()
+
+	if got := len(s.RequestsTo(path)); got != n {
+		t.Errorf("trongridtest: expected %d requests to %s, got %d", n, path, got)
+
+		return false
+	}
+
+	return true
+}
+
+// AssertQuery checks that the last request to path had the query parameter key set to value.
+func (s *Server) AssertQuery(t TestingT, path, key, value string) bool {
+	t.Helper()
+
+	reqs := s.RequestsTo(path)
+	if len(reqs) == 0 {
+		t.Errorf("trongridtest: expected %s to be requested", path)
+
+		return false
+	}
+
+	if got := reqs[len(reqs)-1].Query.Get(key); got != value {
+		t.Errorf("trongridtest: expected %s query %s=%q, got %q", path, key, value, got)
+
+		return false
+	}
+
+	return true
+}
+
+// AssertCalled checks that method of the mock was called exactly times times.
+func (m *Mock) AssertCalled(t TestingT, method string, times int) bool {
+	t.Helper()
+
+	if n := len(m.CallsTo(method)); n != times {
+		t.Errorf("trongridtest: expected %s to be called %d times, got %d", method, times, n)
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,253 @@
+package trongridtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+// ErrCassetteMiss is returned when a replayed request has no recorded interaction.
+var ErrCassetteMiss = errors.New("trongridtest: no recorded interaction")
+
+// Mode selects whether a cassette records or replays.
+type Mode int
+
+const (
+	// ModeReplay serves recorded interactions and never reaches the network
+	ModeReplay Mode = iota
+	// ModeRecord sends requests to the network and records them, replacing the file on Save
+	ModeRecord
+	// ModeAuto replays when the cassette file exists and records otherwise
+	ModeAuto
+)
+
+// scrubbedHeaders are never written to cassette files.
+var scrubbedHeaders = []string{"TRON-PRO-API-KEY", "Authorization", "Cookie", "Set-Cookie"}
+
+// Interaction is a recorded request/response pair.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type RecordedRequest struct {
+	Method string      `json:"method"`
+	Path   string      `json:"path"`
+	Query  string      `json:"query,omitempty"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+type RecordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+// Cassette is an http.RoundTripper recording TronGrid traffic to a file and replaying it.
+// Requests match on method, path, normalized query and, for JSON bodies, normalized body.
+// Identical requests are replayed in recording order; the last one is repeated once exhausted.
+type Cassette struct {
+	path      string
+	mode      Mode
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []*Interaction
+	used         map[int]bool
+}
+
+// NewCassette opens the cassette at path. transport reaches the network while recording;
+// nil uses a new http.Transport.
+func NewCassette(path string, mode Mode, transport http.RoundTripper) (*Cassette, error) {
+	if transport == nil {
+		transport = new(http.Transport)
+	}
+
+	c := &Cassette{path: path, mode: mode, transport: transport, used: make(map[int]bool)}
+
+	b, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if mode == ModeAuto {
+			c.mode = ModeReplay
+		}
+		if c.mode == ModeReplay {
+			if err = json.Unmarshal(b, &c.interactions); err != nil {
+				return nil, fmt.Errorf("trongridtest: cassette %s: %w", path, err)
+			}
+		}
+	case errors.Is(err, os.ErrNotExist) && mode != ModeReplay:
+		c.mode = ModeRecord
+	default:
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// Option returns a client option sending requests through the cassette.
+func (c *Cassette) Option() trongrid.Option {
+	return trongrid.WithTransport(c)
+}
+
+// Recording reports whether the cassette records instead of replaying.
+func (c *Cassette) Recording() bool {
+	return c.mode == ModeRecord
+}
+
+func (c *Cassette) RoundTrip(r *http.Request) (*http.Response, error) {
+	var body []byte
+	if r.Body != nil {
+		var err error
+		if body, err = io.ReadAll(r.Body); err != nil {
+			return nil, err
+		}
+		_ = r.Body.Close()
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	req := RecordedRequest{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  normalizeQuery(r.URL.Query()),
+		Body:   normalizeBody(body),
+	}
+
+	if c.mode != ModeRecord {
+		return c.replay(r, &req)
+	}
+
+	resp, err := c.transport.RoundTrip(r)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	req.Header = scrub(r.Header)
+
+	c.mu.Lock()
+	c.interactions = append(c.interactions, &Interaction{
+		Request: req,
+		Response: RecordedResponse{
+			Status: resp.StatusCode,
+			Header: scrub(resp.Header),
+			Body:   string(respBody),
+		},
+	})
+	c.mu.Unlock()
+
+	return resp, nil
+}
+
+func (c *Cassette) replay(r *http.Request, req *RecordedRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	last := -1
+	for i, in := range c.interactions {
+		if in.Request.Method != req.Method || in.Request.Path != req.Path ||
+			in.Request.Query != req.Query || in.Request.Body != req.Body {
+			continue
+		}
+
+		last = i
+		if !c.used[i] {
+			break
+		}
+	}
+
+	if last < 0 {
+		return nil, fmt.Errorf("%w: %s %s?%s", ErrCassetteMiss, req.Method, req.Path, req.Query)
+	}
+
+	c.used[last] = true
+	in := c.interactions[last]
+
+	header := in.Response.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", in.Response.Status, http.StatusText(in.Response.Status)),
+		StatusCode:    in.Response.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(in.Response.Body))),
+		ContentLength: int64(len(in.Response.Body)),
+		Request:       r,
+	}, nil
+}
+
+// Save writes the recorded interactions to the cassette file. It does nothing when replaying.
+func (c *Cassette) Save() error {
+	if c.mode != ModeRecord {
+		return nil
+	}
+
+	c.mu.Lock()
+	b, err := json.MarshalIndent(c.interactions, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(c.path, b, 0o600)
+}
+
+// normalizeQuery encodes q with sorted keys and drops API keys passed as query parameters.
+func normalizeQuery(q url.Values) string {
+	q.Del("apikey")
+
+	return q.Encode()
+}
+
+// normalizeBody re-encodes JSON bodies so that key order and whitespace do not matter.
+func normalizeBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	var v interface{}
+	if json.Unmarshal(body, &v) != nil {
+		return string(body)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return string(body)
+	}
+
+	return string(b)
+}
+
+func scrub(h http.Header) http.Header {
+	h = h.Clone()
+	for _, k := range scrubbedHeaders {
+		h.Del(k)
+	}
+
+	return h
+}
@@ -0,0 +1,61 @@
+package trongridtest_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+func TestCassette(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassettes", "account.json")
+	ctx := context.Background()
+
+	srv := trongridtest.NewServer()
+	srv.AddAccount(&trongrid.Account{Address: address, Balance: 7})
+	srv.AddTransactionInfo(&trongrid.TransactionInfo{ID: "ab12", Fee: 1100})
+
+	recorder, err := trongridtest.NewCassette(path, trongridtest.ModeAuto, nil)
+	require.NoError(t, err)
+	require.True(t, recorder.Recording())
+
+	api := srv.Client(recorder.Option(), trongrid.WithToken("secret-key"))
+	_, err = api.GetAccount(ctx, &trongrid.GetAccountRequest{Address: address, OnlyConfirmed: true})
+	require.NoError(t, err)
+	_, err = api.GetTransactionInfoByID(ctx, &trongrid.GetTransactionRequest{ID: "ab12"})
+	require.NoError(t, err)
+	require.NoError(t, recorder.Save())
+	srv.Close()
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(b), "secret-key")
+
+	player, err := trongridtest.NewCassette(path, trongridtest.ModeAuto, nil)
+	require.NoError(t, err)
+	require.False(t, player.Recording())
+
+	api = trongrid.NewAPI(
+		trongrid.WithURI(srv.URL),
+		trongrid.WithRateLimiter(nil),
+		trongrid.WithRetryPolicy(trongrid.NoRetry()),
+		player.Option(),
+	)
+	account, err := api.GetAccount(ctx, &trongrid.GetAccountRequest{Address: address, OnlyConfirmed: true})
+	require.NoError(t, err)
+	assert.EqualValues(t, 7, account.Balance)
+
+	info, err := api.GetTransactionInfoByID(ctx, &trongrid.GetTransactionRequest{ID: "ab12"})
+	require.NoError(t, err)
+	assert.EqualValues(t, 1100, info.Fee)
+
+	_, err = api.GetTransactionInfoByID(ctx, &trongrid.GetTransactionRequest{ID: "cd34"})
+	require.ErrorIs(t, err, trongrid.ErrNetworkError)
+	require.ErrorIs(t, err, trongridtest.ErrCassetteMiss)
+}
@@ -0,0 +1,1214 @@
+package trongridtest
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+// ChainNetwork is the network name reported by a Chain.
+const ChainNetwork = "simulated"
+
+const (
+	// transactionLifetime is how long a created transaction may be broadcast
+	transactionLifetime = time.Minute
+	// blockInterval is the time between produced blocks when the chain uses its own clock
+	blockInterval = 3 * time.Second
+)
+
+// trc20TransferEvent is the signature of the TRC20 Transfer event.
+const trc20TransferEvent = "Transfer(address,address,uint256)"
+
+// Energy reported by TriggerConstantContract, matching a typical TRC20 token on mainnet.
+const (
+	constantCallEnergy      = 500
+	transferEnergy          = 14_650
+	newHolderTransferEnergy = 29_650
+)
+
+// Fees are burned from the sender of every transaction included in a block, in sun.
+type Fees struct {
+	// Transfer is burned for a TRX transfer
+	Transfer int64
+	// TRC20Transfer is burned for a smart contract call
+	TRC20Transfer int64
+	// AccountCreation is burned when a transfer activates a new account
+	AccountCreation int64
+}
+
+// ChainOption configures a Chain.
+type ChainOption func(*Chain)
+
+// WithClock sets the time source used for transaction and block timestamps.
+// By default every produced block advances the chain clock by 3 seconds.
+func WithClock(now func() time.Time) ChainOption {
+	return func(c *Chain) {
+		c.now = now
+	}
+}
+
+// WithFees sets the fees burned by transactions. By default transactions are free.
+func WithFees(fees Fees) ChainOption {
+	return func(c *Chain) {
+		c.fees = fees
+	}
+}
+
+// Chain is an in-memory TRON chain implementing trongrid.API. It keeps TRX and TRC20 balances,
+// builds and verifies signed transactions, produces blocks on demand and indexes account history,
+// receipts and events, so applications can be tested end to end without a node.
+//
+// Broadcast transactions stay pending until Produce includes them in a block.
+// Every produced block is considered solidified.
+type Chain struct {
+	mu      sync.Mutex
+	now     func() time.Time
+	clock   time.Time
+	fees    Fees
+	witness trongrid.Address
+
+	accounts map[trongrid.Address]*chainAccount
+	tokens   map[trongrid.Address]*chainToken
+	assets   map[string]*chainAsset
+	pending  []*trongrid.Transaction
+	blocks   []*trongrid.Block
+	txs      map[string]*trongrid.Transaction
+	infos    map[string]*trongrid.TransactionInfo
+	history  map[trongrid.Address][]*trongrid.Transaction
+	trc20    map[trongrid.Address][]trongrid.TRC20Transaction
+	txEvents map[string][]*trongrid.Event
+	events   map[trongrid.Address][]*trongrid.Event
+
+	delegations map[delegationKey]*trongrid.DelegatedResource
+	witnesses   map[trongrid.Address]*chainWitness
+}
+
+type chainAccount struct {
+	balance    int64
+	createTime int64
+	latest     int64
+	// frozen is the Stake 2.0 balance by resource, excluding what is delegated
+	frozen     map[string]int64
+	unfreezing []trongrid.UnfrozenV2
+	// votes are by base58 witness address; reward is unclaimed and withdrawn is when it was last claimed
+	votes       []trongrid.Vote
+	reward      int64
+	withdrawn   int64
+	permissions chainPermissions
+}
+
+type chainToken struct {
+	token    trongrid.Token
+	balances map[trongrid.Address]*big.Int
+}
+
+var _ trongrid.API = (*Chain)(nil)
+
+// NewChain returns a chain containing only its genesis block.
+func NewChain(opts ...ChainOption) *Chain {
+	c := &Chain{
+		clock:    time.Now().Truncate(time.Second),
+		accounts: make(map[trongrid.Address]*chainAccount),
+		tokens:   make(map[trongrid.Address]*chainToken),
+		assets:   make(map[string]*chainAsset),
+		txs:      make(map[string]*trongrid.Transaction),
+		infos:    make(map[string]*trongrid.TransactionInfo),
+		history:  make(map[trongrid.Address][]*trongrid.Transaction),
+		trc20:    make(map[trongrid.Address][]trongrid.TRC20Transaction),
+		txEvents: make(map[string][]*trongrid.Event),
+		events:   make(map[trongrid.Address][]*trongrid.Event),
+
+		delegations: make(map[delegationKey]*trongrid.DelegatedResource),
+		witnesses:   make(map[trongrid.Address]*chainWitness),
+	}
+	c.now = func() time.Time { return c.clock }
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	c.witness = randomAddress()
+	c.seal(nil)
+
+	return c
+}
+
+// Fund credits sun to address, creating the account if needed.
+func (c *Chain) Fund(address string, sun int64) error {
+	a, err := trongrid.ParseAddress(address)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.account(a).balance += sun
+
+	return nil
+}
+
+// DeployTRC20 registers a TRC20 token and returns its address. A random address is used
+// when token.Address is empty.
+func (c *Chain) DeployTRC20(token trongrid.Token) (string, error) {
+	var a trongrid.Address
+	if token.Address == "" {
+		a = randomAddress()
+	} else {
+		var err error
+		if a, err = trongrid.ParseAddress(token.Address); err != nil {
+			return "", err
+		}
+	}
+	token.Address = a.String()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.tokens[a]; ok {
+		return "", fmt.Errorf("%w: token %s already deployed", trongrid.ErrInvalidRequest, token.Address)
+	}
+	c.tokens[a] = &chainToken{token: token, balances: make(map[trongrid.Address]*big.Int)}
+
+	return token.Address, nil
+}
+
+// Mint credits amount of the token deployed at contract to address.
+func (c *Chain) Mint(contract, address string, amount *big.Int) error {
+	token, to, err := c.tokenAndAddress(contract, address)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.account(to)
+	token.balances[to] = new(big.Int).Add(token.balance(to), amount)
+
+	return nil
+}
+
+// Balance returns the TRX balance of address in sun.
+func (c *Chain) Balance(address string) int64 {
+	a, err := trongrid.ParseAddress(address)
+	if err != nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if acc, ok := c.accounts[a]; ok {
+		return acc.balance
+	}
+
+	return 0
+}
+
+// TokenBalance returns the balance of address in the token deployed at contract.
+func (c *Chain) TokenBalance(contract, address string) *big.Int {
+	token, a, err := c.tokenAndAddress(contract, address)
+	if err != nil {
+		return new(big.Int)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return new(big.Int).Set(token.balance(a))
+}
+
+// Pending returns the number of broadcast transactions waiting for the next block.
+func (c *Chain) Pending() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.pending)
+}
+
+// Produce executes the pending transactions and seals them into a new block.
+// Transactions that no longer validate, e.g. because they expired, are dropped.
+func (c *Chain) Produce() *trongrid.Block {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.clock = c.clock.Add(blockInterval)
+	pending := c.pending
+	c.pending = nil
+
+	return clone(c.seal(pending))
+}
+
+func (c *Chain) Network() *trongrid.Network {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := &trongrid.Network{Name: ChainNetwork, Tokens: make(map[string]trongrid.Token, len(c.tokens))}
+	for _, t := range c.tokens {
+		n.Tokens[strings.ToUpper(t.token.Symbol)] = t.token
+	}
+
+	return n
+}
+
+func (c *Chain) ListTransactions(
+	ctx context.Context,
+	req *trongrid.ListTransactionsRequest,
+) (*trongrid.ListTransactionsResponse, error) {
+	a, err := requestAddress(ctx, req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	var txs []*trongrid.Transaction
+	for _, tx := range c.history[a] {
+		owner, _ := trongrid.ParseAddress(tx.RawData.Contract[0].Parameter.Value.OwnerAddress)
+		switch {
+		case !inRange(tx.BlockTimestamp, req.MinTimestamp, req.MaxTimestamp):
+		case req.OnlyFrom && owner != a:
+		case req.OnlyTo && owner == a:
+		default:
+			txs = append(txs, clone(tx))
+		}
+	}
+	c.mu.Unlock()
+
+	sort.SliceStable(txs, func(i, j int) bool {
+		if req.OrderBy == trongrid.OrderByTimestampAsc {
+			return txs[i].BlockTimestamp < txs[j].BlockTimestamp
+		}
+
+		return txs[i].BlockTimestamp > txs[j].BlockTimestamp
+	})
+
+	start, end, next, err := page(req.Fingerprint, int(req.Limit), len(txs))
+	if err != nil {
+		return nil, err
+	}
+
+	return &trongrid.ListTransactionsResponse{
+		Meta:    c.meta(end-start, next),
+		Data:    txs[start:end],
+		Success: true,
+	}, nil
+}
+
+func (c *Chain) ListTransactionsTrc20(
+	ctx context.Context,
+	req *trongrid.ListTransactionsRequest,
+) (*trongrid.TRC20Response, error) {
+	a, err := requestAddress(ctx, req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	address := a.String()
+
+	c.mu.Lock()
+	transfers := []trongrid.TRC20Transaction{}
+	for _, t := range c.trc20[a] {
+		switch {
+		case !inRange(t.BlockTimestamp, req.MinTimestamp, req.MaxTimestamp):
+		case req.OnlyFrom && t.From != address:
+		case req.OnlyTo && t.To != address:
+		default:
+			transfers = append(transfers, t)
+		}
+	}
+	c.mu.Unlock()
+
+	sort.SliceStable(transfers, func(i, j int) bool {
+		if req.OrderBy == trongrid.OrderByTimestampAsc {
+			return transfers[i].BlockTimestamp < transfers[j].BlockTimestamp
+		}
+
+		return transfers[i].BlockTimestamp > transfers[j].BlockTimestamp
+	})
+
+	start, end, next, err := page(req.Fingerprint, int(req.Limit), len(transfers))
+	if err != nil {
+		return nil, err
+	}
+
+	return &trongrid.TRC20Response{
+		Data:    transfers[start:end],
+		Success: true,
+		Meta:    *c.meta(end-start, next),
+	}, nil
+}
+
+func (c *Chain) GetAccount(ctx context.Context, req *trongrid.GetAccountRequest) (*trongrid.Account, error) {
+	a, err := requestAddress(ctx, req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	acc, ok := c.accounts[a]
+	if !ok {
+		return nil, trongrid.ErrEmpty
+	}
+
+	account := &trongrid.Account{
+		Address:            a.Hex(),
+		Balance:            acc.balance,
+		CreateTime:         acc.createTime,
+		LatestOprationTime: acc.latest,
+	}
+	for contract, token := range c.tokens {
+		if b, ok := token.balances[a]; ok {
+			account.TRC20 = append(account.TRC20, map[string]string{contract.String(): b.String()})
+		}
+	}
+	for id, asset := range c.assets {
+		if b, ok := asset.balances[a]; ok {
+			account.AssetV2 = append(account.AssetV2, trongrid.AccountAsset{Key: id, Value: b})
+		}
+	}
+	sort.Slice(account.AssetV2, func(i, j int) bool { return account.AssetV2[i].Key < account.AssetV2[j].Key })
+	c.stakeInfo(a, acc, account)
+	acc.permissionInfo(account)
+
+	return account, nil
+}
+
+func (c *Chain) ListTransactionEvents(
+	ctx context.Context,
+	req *trongrid.ListTransactionEventsRequest,
+) (*trongrid.ListEventsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	events := make([]*trongrid.Event, 0, len(c.txEvents[req.ID]))
+	for _, e := range c.txEvents[req.ID] {
+		events = append(events, clone(e))
+	}
+
+	return &trongrid.ListEventsResponse{Data: events, Success: true, Meta: c.meta(len(events), "")}, nil
+}
+
+func (c *Chain) ListContractEvents(
+	ctx context.Context,
+	req *trongrid.ListContractEventsRequest,
+) (*trongrid.ListEventsResponse, error) {
+	a, err := requestAddress(ctx, req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	events := []*trongrid.Event{}
+	for _, e := range c.events[a] {
+		switch {
+		case req.EventName != "" && e.EventName != req.EventName:
+		case req.BlockNumber != 0 && e.BlockNumber != req.BlockNumber:
+		case req.MinTimestamp != 0 && e.BlockTimestamp < req.MinTimestamp:
+		case req.MaxTimestamp != 0 && e.BlockTimestamp > req.MaxTimestamp:
+		default:
+			events = append(events, clone(e))
+		}
+	}
+	c.mu.Unlock()
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if req.OrderBy == trongrid.OrderByTimestampAsc {
+			return events[i].BlockTimestamp < events[j].BlockTimestamp
+		}
+
+		return events[i].BlockTimestamp > events[j].BlockTimestamp
+	})
+
+	start, end, next, err := page(req.Fingerprint, int(req.Limit), len(events))
+	if err != nil {
+		return nil, err
+	}
+
+	return &trongrid.ListEventsResponse{Data: events[start:end], Success: true, Meta: c.meta(end-start, next)}, nil
+}
+
+// ListInternalTransactions always returns an empty list: contracts on the chain do not send TRX.
+func (c *Chain) ListInternalTransactions(
+	ctx context.Context,
+	req *trongrid.ListTransactionsRequest,
+) (*trongrid.ListInternalTransactionsResponse, error) {
+	if _, err := requestAddress(ctx, req.Address); err != nil {
+		return nil, err
+	}
+
+	return &trongrid.ListInternalTransactionsResponse{
+		Data:    []trongrid.InternalTransfer{},
+		Success: true,
+		Meta:    c.meta(0, ""),
+	}, nil
+}
+
+func (c *Chain) GetTransactionByID(
+	ctx context.Context,
+	req *trongrid.GetTransactionRequest,
+) (*trongrid.Transaction, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if tx, ok := c.txs[req.ID]; ok {
+		return clone(tx), nil
+	}
+
+	if !req.OnlyConfirmed {
+		for _, tx := range c.pending {
+			if tx.TxID == req.ID {
+				return clone(tx), nil
+			}
+		}
+	}
+
+	return nil, trongrid.ErrEmpty
+}
+
+func (c *Chain) GetTransactionInfoByID(
+	ctx context.Context,
+	req *trongrid.GetTransactionRequest,
+) (*trongrid.TransactionInfo, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if info, ok := c.infos[req.ID]; ok {
+		return clone(info), nil
+	}
+
+	return nil, trongrid.ErrEmpty
+}
+
+func (c *Chain) GetBlockByNum(ctx context.Context, req *trongrid.GetBlockRequest) (*trongrid.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if req.Num < 0 || req.Num >= int64(len(c.blocks)) {
+		return nil, trongrid.ErrEmpty
+	}
+
+	return clone(c.blocks[req.Num]), nil
+}
+
+func (c *Chain) GetNowBlock(ctx context.Context, _ bool) (*trongrid.Block, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return clone(c.blocks[len(c.blocks)-1]), nil
+}
+
+func (c *Chain) GetContract(ctx context.Context, address string) (*trongrid.SmartContract, error) {
+	a, err := requestAddress(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	token, ok := c.tokens[a]
+	if !ok {
+		return nil, trongrid.ErrEmpty
+	}
+
+	return &trongrid.SmartContract{
+		OriginAddress:   c.witness.String(),
+		ContractAddress: a.String(),
+		ABI:             trc20ABI(),
+		Name:            token.token.Name,
+	}, nil
+}
+
+func (c *Chain) CreateTransaction(
+	ctx context.Context,
+	req *trongrid.CreateTransactionRequest,
+) (*trongrid.Transaction, error) {
+	owner, err := requestAddress(ctx, req.OwnerAddress)
+	if err != nil {
+		return nil, err
+	}
+	to, err := trongrid.ParseAddress(req.ToAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch acc, ok := c.accounts[owner]; {
+	case req.Amount <= 0:
+		return nil, walletError("Amount must be greater than 0.")
+	case owner == to:
+		return nil, walletError("Cannot transfer TRX to yourself.")
+	case !ok:
+		return nil, walletError("Validate TransferContract error, no OwnerAccount.")
+	case acc.balance < req.Amount:
+		return nil, walletError("Validate TransferContract error, balance is not sufficient.")
+	}
+
+	tx := c.newTransaction(trongrid.Contract{
+		Type: trongrid.ContractTypeTRX,
+		Parameter: trongrid.ContractParameter{
+			Value: trongrid.ContractValue{
+				Amount:       int(req.Amount),
+				OwnerAddress: formatAddress(owner, req.Visible),
+				ToAddress:    formatAddress(to, req.Visible),
+			},
+			TypeUrl: "type.googleapis.com/protocol.TransferContract",
+		},
+		PermissionID: req.PermissionID,
+	}, 0)
+	if err = trongrid.SealTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+func (c *Chain) TriggerSmartContract(
+	ctx context.Context,
+	req *trongrid.TriggerSmartContractRequest,
+) (*trongrid.TriggerSmartContractResponse, error) {
+	owner, err := requestAddress(ctx, req.OwnerAddress)
+	if err != nil {
+		return nil, err
+	}
+	contract, err := trongrid.ParseAddress(req.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.tokens[contract]; !ok {
+		return nil, trongrid.NewAPIError(http.StatusOK, "Smart contract is not exist.", trongrid.ErrInvalidRequest)
+	}
+	if _, ok := c.accounts[owner]; !ok {
+		return nil, trongrid.NewAPIError(http.StatusOK, "Account is not exist.", trongrid.ErrInvalidRequest)
+	}
+
+	data := req.Data
+	if data == "" {
+		data = hex.EncodeToString(trongrid.Keccak256([]byte(req.FunctionSelector))[:4]) + req.Parameter
+	}
+	tx := c.newTransaction(trongrid.Contract{
+		Type: trongrid.ContractTypeTRC20,
+		Parameter: trongrid.ContractParameter{
+			Value: trongrid.ContractValue{
+				OwnerAddress:    formatAddress(owner, req.Visible),
+				ContractAddress: formatAddress(contract, req.Visible),
+				Data:            data,
+				CallValue:       req.CallValue,
+			},
+			TypeUrl: "type.googleapis.com/protocol.TriggerSmartContract",
+		},
+		PermissionID: req.PermissionID,
+	}, req.FeeLimit)
+	if err = trongrid.SealTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	resp := &trongrid.TriggerSmartContractResponse{Transaction: tx}
+	resp.Result.Result = true
+
+	return resp, nil
+}
+
+// TriggerConstantContract runs the TRC20 view methods name, symbol, decimals, totalSupply and
+// balanceOf, and estimates the energy of transfer.
+func (c *Chain) TriggerConstantContract(
+	ctx context.Context,
+	req *trongrid.TriggerSmartContractRequest,
+) (*trongrid.TriggerSmartContractResponse, error) {
+	contract, err := requestAddress(ctx, req.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	selector, args, err := constantCall(req)
+	if err != nil {
+		return nil, walletError(err.Error())
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	token, ok := c.tokens[contract]
+	if !ok {
+		return nil, walletError("Smart contract is not exist.")
+	}
+
+	resp := &trongrid.TriggerSmartContractResponse{EnergyUsed: constantCallEnergy}
+	resp.Result.Result = true
+
+	var result []byte
+	switch selector {
+	case "name()":
+		result = abiString(token.token.Name)
+	case "symbol()":
+		result = abiString(token.token.Symbol)
+	case "decimals()":
+		result = abiUint(big.NewInt(int64(token.token.Decimals)))
+	case "totalSupply()":
+		total := new(big.Int)
+		for _, b := range token.balances {
+			total.Add(total, b)
+		}
+		result = abiUint(total)
+	case "balanceOf(address)":
+		if len(args) != 32 {
+			return nil, walletError("balanceOf expects one address")
+		}
+		a, _ := trongrid.AddressFromBytes(args[12:32])
+		result = abiUint(token.balance(a))
+	case trongrid.TRC20TransferSelector:
+		owner, err := trongrid.ParseAddress(req.OwnerAddress)
+		if err != nil || len(args) != 64 {
+			return nil, walletError("transfer expects an owner, an address and an amount")
+		}
+		to, _ := trongrid.AddressFromBytes(args[12:32])
+		if token.balance(owner).Cmp(new(big.Int).SetBytes(args[32:])) < 0 {
+			return nil, walletError("REVERT opcode executed")
+		}
+
+		resp.EnergyUsed = transferEnergy
+		if _, ok := token.balances[to]; !ok || token.balances[to].Sign() == 0 {
+			resp.EnergyUsed = newHolderTransferEnergy
+		}
+		result = abiUint(big.NewInt(1))
+	default:
+		return nil, walletError("function " + selector + " is not supported by the simulated chain")
+	}
+
+	resp.ConstantResult = []string{hex.EncodeToString(result)}
+
+	return resp, nil
+}
+
+// EstimateEnergy returns the energy reported by TriggerConstantContract.
+func (c *Chain) EstimateEnergy(ctx context.Context, req *trongrid.TriggerSmartContractRequest) (int64, error) {
+	resp, err := c.TriggerConstantContract(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	return resp.EnergyUsed, nil
+}
+
+// constantSelectors are the methods run by TriggerConstantContract.
+var constantSelectors = []string{
+	"name()", "symbol()", "decimals()", "totalSupply()", "balanceOf(address)", trongrid.TRC20TransferSelector,
+}
+
+// constantCall returns the method and arguments of req, given either as a function selector
+// and parameter or as calldata.
+func constantCall(req *trongrid.TriggerSmartContractRequest) (selector string, args []byte, err error) {
+	if req.Data == "" {
+		args, err = hex.DecodeString(req.Parameter)
+
+		return req.FunctionSelector, args, err
+	}
+
+	data, err := hex.DecodeString(req.Data)
+	if err != nil {
+		return "", nil, err
+	}
+	if len(data) < 4 {
+		return "", nil, errors.New("data is too short")
+	}
+	for _, selector := range constantSelectors {
+		if bytes.Equal(trongrid.Keccak256([]byte(selector))[:4], data[:4]) {
+			return selector, data[4:], nil
+		}
+	}
+
+	return hex.EncodeToString(data[:4]), data[4:], nil
+}
+
+func (c *Chain) BroadcastTransaction(
+	ctx context.Context,
+	tx *trongrid.Transaction,
+) (*trongrid.BroadcastResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if code, message := c.validate(tx); code != "" {
+		resp := &trongrid.BroadcastResponse{
+			Result:  false,
+			TxID:    tx.TxID,
+			Code:    code,
+			Message: hex.EncodeToString([]byte(message)),
+		}
+
+		return resp, trongrid.NewAPIError(http.StatusOK, code+": "+message, trongrid.ErrBroadcastFailed)
+	}
+
+	c.pending = append(c.pending, clone(tx))
+
+	return &trongrid.BroadcastResponse{Result: true, TxID: tx.TxID, Code: "SUCCESS"}, nil
+}
+
+// validate checks that tx is well formed, signed by its permission and still executable.
+// It returns the wallet API error code and message. c.mu must be held.
+func (c *Chain) validate(tx *trongrid.Transaction) (code, message string) {
+	if len(tx.RawData.Contract) != 1 {
+		return "CONTRACT_VALIDATE_ERROR", "exactly one contract is supported"
+	}
+
+	sealed := clone(tx)
+	if err := trongrid.SealTransaction(sealed); err != nil {
+		return "OTHER_ERROR", err.Error()
+	}
+	if sealed.RawDataHex != tx.RawDataHex || sealed.TxID != tx.TxID {
+		return "SIGERROR", "raw_data does not match raw_data_hex"
+	}
+
+	if _, ok := c.txs[tx.TxID]; ok {
+		return "DUP_TRANSACTION_ERROR", "dup transaction"
+	}
+	for _, p := range c.pending {
+		if p.TxID == tx.TxID {
+			return "DUP_TRANSACTION_ERROR", "dup transaction"
+		}
+	}
+
+	if tx.RawData.Expiration <= c.now().UnixMilli() {
+		return "TRANSACTION_EXPIRATION_ERROR", "transaction expired"
+	}
+	if tx.RawData.Expiration > c.now().Add(trongrid.MaxTransactionLifetime*time.Hour).UnixMilli() {
+		return "TRANSACTION_EXPIRATION_ERROR", "transaction expiration is more than 24 hours ahead"
+	}
+
+	if _, err := trongrid.ParseAddress(tx.RawData.Contract[0].Parameter.Value.OwnerAddress); err != nil {
+		return "CONTRACT_VALIDATE_ERROR", err.Error()
+	}
+
+	if w := c.signWeight(tx); !w.Enough() {
+		return "SIGERROR", "Validate signature error: " + w.Result.Message
+	}
+
+	if message = c.check(tx); message != "" {
+		return "CONTRACT_VALIDATE_ERROR", message
+	}
+
+	return "", ""
+}
+
+// check returns why tx cannot be executed against the current state, if it cannot. c.mu must be held.
+func (c *Chain) check(tx *trongrid.Transaction) string {
+	contract := &tx.RawData.Contract[0]
+	owner, _ := trongrid.ParseAddress(contract.Parameter.Value.OwnerAddress)
+
+	acc, ok := c.accounts[owner]
+	if !ok {
+		return "Validate TransferContract error, no OwnerAccount."
+	}
+
+	switch contract.Type {
+	case trongrid.ContractTypeTRX:
+		if acc.balance < int64(contract.Parameter.Value.Amount)+c.fees.Transfer {
+			return "Validate TransferContract error, balance is not sufficient."
+		}
+	case trongrid.ContractTypeTRC20:
+		address, err := trongrid.ParseAddress(contract.Parameter.Value.ContractAddress)
+		if err != nil {
+			return err.Error()
+		}
+		if _, ok := c.tokens[address]; !ok {
+			return "No contract or not a smart contract"
+		}
+		if acc.balance < contract.Parameter.Value.CallValue {
+			return "callValue is not sufficient"
+		}
+	case trongrid.ContractTypeTRC10:
+		return c.checkAsset(owner, acc, &contract.Parameter.Value)
+	case trongrid.ContractTypeFreezeV2, trongrid.ContractTypeUnfreezeV2, trongrid.ContractTypeWithdrawExpireUnfreeze,
+		trongrid.ContractTypeCancelAllUnfreezeV2, trongrid.ContractTypeDelegateResource,
+		trongrid.ContractTypeUndelegateResource, trongrid.ContractTypeVote, trongrid.ContractTypeWithdrawBalance,
+		trongrid.ContractTypeAccountPermissionUpdate:
+		return c.checkStake(owner, acc, contract.Type, &contract.Parameter.Value)
+	default:
+		return "contract type " + contract.Type + " is not supported by the simulated chain"
+	}
+
+	return ""
+}
+
+// seal executes txs and appends the resulting block. c.mu must be held.
+func (c *Chain) seal(txs []*trongrid.Transaction) *trongrid.Block {
+	number := int64(len(c.blocks))
+	timestamp := c.now().UnixMilli()
+
+	block := &trongrid.Block{}
+	block.BlockHeader.RawData.Number = number
+	block.BlockHeader.RawData.Timestamp = timestamp
+	block.BlockHeader.RawData.WitnessAddress = c.witness.Hex()
+	block.BlockHeader.RawData.Version = 30
+	if number > 0 {
+		block.BlockHeader.RawData.ParentHash = c.blocks[number-1].BlockID
+	} else {
+		block.BlockHeader.RawData.ParentHash = strings.Repeat("0", 64)
+	}
+
+	trie := sha256.New()
+	for _, tx := range txs {
+		if c.check(tx) != "" || tx.RawData.Expiration < timestamp {
+			continue
+		}
+
+		c.execute(tx, number, timestamp)
+		block.Transactions = append(block.Transactions, tx)
+		_, _ = trie.Write([]byte(tx.TxID))
+	}
+	block.BlockHeader.RawData.TxTrieRoot = hex.EncodeToString(trie.Sum(nil))
+
+	header, _ := json.Marshal(block.BlockHeader.RawData)
+	hash := sha256.Sum256(header)
+	binary.BigEndian.PutUint64(hash[:8], uint64(number))
+	block.BlockID = hex.EncodeToString(hash[:])
+
+	c.blocks = append(c.blocks, block)
+
+	return block
+}
+
+// execute applies tx to the state and indexes it. c.mu must be held.
+func (c *Chain) execute(tx *trongrid.Transaction, number, timestamp int64) {
+	contract := &tx.RawData.Contract[0]
+	value := &contract.Parameter.Value
+	owner, _ := trongrid.ParseAddress(value.OwnerAddress)
+	sender := c.accounts[owner]
+	sender.latest = timestamp
+
+	info := &trongrid.TransactionInfo{ID: tx.TxID, BlockNumber: number, BlockTimeStamp: timestamp}
+	result := "SUCCESS"
+
+	switch contract.Type {
+	case trongrid.ContractTypeTRX:
+		to, _ := trongrid.ParseAddress(value.ToAddress)
+		fee := c.fees.Transfer
+		if _, ok := c.accounts[to]; !ok && sender.balance >= int64(value.Amount)+fee+c.fees.AccountCreation {
+			fee += c.fees.AccountCreation
+		}
+
+		sender.balance -= int64(value.Amount) + fee
+		recipient := c.account(to)
+		recipient.balance += int64(value.Amount)
+		recipient.latest = timestamp
+
+		info.Fee = fee
+		info.Receipt.NetFee = fee
+		tx.NetFee = int(fee)
+		c.history[to] = append(c.history[to], tx)
+	case trongrid.ContractTypeTRC10:
+		to := c.transferAsset(owner, value, timestamp)
+		info.Fee = c.fees.Transfer
+		info.Receipt.NetFee = c.fees.Transfer
+		tx.NetFee = int(c.fees.Transfer)
+		c.history[to] = append(c.history[to], tx)
+	case trongrid.ContractTypeTRC20:
+		fee := c.fees.TRC20Transfer
+		if sender.balance < fee+value.CallValue {
+			fee = sender.balance - value.CallValue
+			result = "OUT_OF_ENERGY"
+		}
+		sender.balance -= fee
+
+		info.Fee = fee
+		info.Receipt.EnergyFee = fee
+		info.ContractAddress = value.ContractAddress
+		tx.EnergyFee = int(fee)
+
+		if result == "SUCCESS" {
+			result = c.call(tx, info, number, timestamp)
+		}
+	default:
+		c.stake(owner, sender, contract.Type, value, timestamp)
+	}
+
+	tx.Ret = []trongrid.TransactionRet{{ContractRet: result, Fee: int(info.Fee)}}
+	tx.BlockNumber = int(number)
+	tx.BlockTimestamp = timestamp
+	info.Receipt.Result = result
+	if result != "SUCCESS" {
+		info.Result = "FAILED"
+		info.ResMessage = hex.EncodeToString([]byte(result))
+	}
+
+	c.txs[tx.TxID] = tx
+	c.infos[tx.TxID] = info
+	c.history[owner] = append(c.history[owner], tx)
+}
+
+// transferArgs decodes the call data of a TRC20 transfer: the method id followed by the recipient
+// and the amount, one 32 byte word each.
+func transferArgs(data string) (trongrid.Address, *big.Int, bool) {
+	b, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
+	if err != nil || len(b) < 4+2*32 ||
+		!bytes.Equal(b[:4], trongrid.Keccak256([]byte(trongrid.TRC20TransferSelector))[:4]) {
+		return trongrid.Address{}, nil, false
+	}
+
+	var to trongrid.Address
+	to[0] = trongrid.AddressPrefix
+	copy(to[1:], b[4+12:4+32])
+
+	return to, new(big.Int).SetBytes(b[4+32 : 4+64]), true
+}
+
+// call executes a TRC20 method and returns the contract result. c.mu must be held.
+func (c *Chain) call(tx *trongrid.Transaction, info *trongrid.TransactionInfo, number, timestamp int64) string {
+	value := &tx.RawData.Contract[0].Parameter.Value
+	owner, _ := trongrid.ParseAddress(value.OwnerAddress)
+	address, _ := trongrid.ParseAddress(value.ContractAddress)
+	token := c.tokens[address]
+
+	recipient, amount, ok := transferArgs(value.Data)
+	if !ok || token.balance(owner).Cmp(amount) < 0 {
+		return "REVERT"
+	}
+
+	token.balances[owner] = new(big.Int).Sub(token.balance(owner), amount)
+	token.balances[recipient] = new(big.Int).Add(token.balance(recipient), amount)
+	c.account(recipient)
+
+	info.ContractResult = []string{strings.Repeat("0", 63) + "1"}
+	info.Log = append(info.Log, struct {
+		Address string   `json:"address"`
+		Topics  []string `json:"topics"`
+		Data    string   `json:"data"`
+	}{
+		Address: address.Hex()[2:],
+		Topics: []string{
+			hex.EncodeToString(trongrid.Keccak256([]byte(trc20TransferEvent))),
+			strings.Repeat("0", 24) + owner.Hex()[2:],
+			strings.Repeat("0", 24) + recipient.Hex()[2:],
+		},
+		Data: fmt.Sprintf("%064x", amount),
+	})
+
+	transfer := trongrid.TRC20Transaction{
+		TransactionID: tx.TxID,
+		TokenInfo: trongrid.TokenInfo{
+			Symbol:   token.token.Symbol,
+			Address:  token.token.Address,
+			Decimals: token.token.Decimals,
+			Name:     token.token.Name,
+		},
+		BlockTimestamp: timestamp,
+		From:           owner.String(),
+		To:             recipient.String(),
+		Type:           "Transfer",
+		Value:          amount.String(),
+	}
+	c.trc20[owner] = append(c.trc20[owner], transfer)
+	if recipient != owner {
+		c.trc20[recipient] = append(c.trc20[recipient], transfer)
+	}
+
+	event := &trongrid.Event{
+		BlockNumber:           number,
+		BlockTimestamp:        timestamp,
+		CallerContractAddress: address.String(),
+		ContractAddress:       address.String(),
+		EventIndex:            0,
+		EventName:             "Transfer",
+		Event:                 "Transfer(address indexed from, address indexed to, uint256 value)",
+		Result: map[string]string{
+			"0": "0x" + owner.Hex()[2:], "from": "0x" + owner.Hex()[2:],
+			"1": "0x" + recipient.Hex()[2:], "to": "0x" + recipient.Hex()[2:],
+			"2": amount.String(), "value": amount.String(),
+		},
+		ResultType:    map[string]string{"from": "address", "to": "address", "value": "uint256"},
+		TransactionID: tx.TxID,
+	}
+	c.txEvents[tx.TxID] = append(c.txEvents[tx.TxID], event)
+	c.events[address] = append(c.events[address], event)
+
+	return "SUCCESS"
+}
+
+// newTransaction returns an unsealed transaction referencing the latest block. c.mu must be held.
+func (c *Chain) newTransaction(contract trongrid.Contract, feeLimit int64) *trongrid.Transaction {
+	ref, _ := trongrid.NewRefBlock(c.blocks[len(c.blocks)-1])
+	now := c.now()
+
+	return &trongrid.Transaction{
+		RawData: trongrid.TransactionRawData{
+			Contract:      []trongrid.Contract{contract},
+			RefBlockBytes: ref.Bytes,
+			RefBlockHash:  ref.Hash,
+			Expiration:    now.Add(transactionLifetime).UnixMilli(),
+			Timestamp:     now.UnixMilli(),
+			FeeLimit:      feeLimit,
+		},
+	}
+}
+
+// account returns the account at a, creating it if needed. c.mu must be held.
+func (c *Chain) account(a trongrid.Address) *chainAccount {
+	acc, ok := c.accounts[a]
+	if !ok {
+		acc = &chainAccount{createTime: c.now().UnixMilli()}
+		c.accounts[a] = acc
+	}
+
+	return acc
+}
+
+func (c *Chain) meta(size int, fingerprint string) *trongrid.Meta {
+	return &trongrid.Meta{
+		Links:       &trongrid.MetaLinks{},
+		Fingerprint: fingerprint,
+		At:          c.now().UnixMilli(),
+		PageSize:    int32(size),
+	}
+}
+
+func (c *Chain) tokenAndAddress(contract, address string) (*chainToken, trongrid.Address, error) {
+	ca, err := trongrid.ParseAddress(contract)
+	if err != nil {
+		return nil, trongrid.Address{}, err
+	}
+	a, err := trongrid.ParseAddress(address)
+	if err != nil {
+		return nil, trongrid.Address{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	token, ok := c.tokens[ca]
+	if !ok {
+		return nil, trongrid.Address{}, fmt.Errorf("%w: no token at %s", trongrid.ErrInvalidRequest, contract)
+	}
+
+	return token, a, nil
+}
+
+func (t *chainToken) balance(a trongrid.Address) *big.Int {
+	if b, ok := t.balances[a]; ok {
+		return b
+	}
+
+	return new(big.Int)
+}
+
+func requestAddress(ctx context.Context, address string) (trongrid.Address, error) {
+	if err := ctx.Err(); err != nil {
+		return trongrid.Address{}, err
+	}
+
+	return trongrid.ParseAddress(address)
+}
+
+func formatAddress(a trongrid.Address, visible bool) string {
+	if visible {
+		return a.String()
+	}
+
+	return a.Hex()
+}
+
+func inRange(ts int64, min, max time.Time) bool {
+	return (min.IsZero() || ts >= min.UnixMilli()) && (max.IsZero() || ts <= max.UnixMilli())
+}
+
+func walletError(message string) error {
+	return trongrid.NewAPIError(http.StatusOK, message, trongrid.ErrInvalidRequest)
+}
+
+func randomAddress() trongrid.Address {
+	var a trongrid.Address
+	a[0] = trongrid.AddressPrefix
+	_, _ = rand.Read(a[1:])
+
+	return a
+}
+
+// clone returns a deep copy of v, so callers cannot modify the chain state.
+func clone[T any](v *T) *T {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+
+	c := new(T)
+	if err = json.Unmarshal(b, c); err != nil {
+		panic(err)
+	}
+
+	return c
+}
+
+func trc20ABI() trongrid.ABI {
+	address := trongrid.ABIParam{Type: "address"}
+	uint256 := trongrid.ABIParam{Type: "uint256"}
+
+	return trongrid.ABI{Entrys: []trongrid.ABIEntry{
+		{Name: "name", Constant: true, Type: "Function", StateMutability: "View",
+			Outputs: []trongrid.ABIParam{{Type: "string"}}},
+		{Name: "symbol", Constant: true, Type: "Function", StateMutability: "View",
+			Outputs: []trongrid.ABIParam{{Type: "string"}}},
+		{Name: "decimals", Constant: true, Type: "Function", StateMutability: "View",
+			Outputs: []trongrid.ABIParam{{Type: "uint8"}}},
+		{Name: "totalSupply", Constant: true, Type: "Function", StateMutability: "View",
+			Outputs: []trongrid.ABIParam{uint256}},
+		{Name: "balanceOf", Constant: true, Type: "Function", StateMutability: "View",
+			Inputs: []trongrid.ABIParam{withName(address, "who")}, Outputs: []trongrid.ABIParam{uint256}},
+		{Name: "transfer", Type: "Function", StateMutability: "Nonpayable",
+			Inputs:  []trongrid.ABIParam{withName(address, "to"), withName(uint256, "value")},
+			Outputs: []trongrid.ABIParam{{Type: "bool"}}},
+		{Name: "Transfer", Type: "Event", Inputs: []trongrid.ABIParam{
+			{Indexed: true, Name: "from", Type: "address"},
+			{Indexed: true, Name: "to", Type: "address"},
+			{Name: "value", Type: "uint256"},
+		}},
+	}}
+}
+
+func withName(p trongrid.ABIParam, name string) trongrid.ABIParam {
+	p.Name = name
+
+	return p
+}
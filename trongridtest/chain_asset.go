@@ -0,0 +1,237 @@
+package trongridtest
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+// firstAssetID is the ID of the first TRC10 asset, as on mainnet.
+const firstAssetID = 1000001
+
+type chainAsset struct {
+	issue    trongrid.AssetIssue
+	balances map[trongrid.Address]int64
+}
+
+// IssueAsset creates a TRC10 asset and credits its total supply to its owner.
+// It returns the asset ID, assigned in sequence when issue.ID is empty.
+func (c *Chain) IssueAsset(issue trongrid.AssetIssue) (string, error) {
+	owner, err := trongrid.ParseAddress(issue.OwnerAddress)
+	if err != nil {
+		return "", err
+	}
+	issue.OwnerAddress = owner.String()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if issue.ID == "" {
+		issue.ID = strconv.Itoa(firstAssetID + len(c.assets))
+	}
+	if _, ok := c.assets[issue.ID]; ok {
+		return "", fmt.Errorf("%w: asset %s already issued", trongrid.ErrInvalidRequest, issue.ID)
+	}
+
+	c.account(owner)
+	c.assets[issue.ID] = &chainAsset{issue: issue, balances: map[trongrid.Address]int64{owner: issue.TotalSupply}}
+
+	return issue.ID, nil
+}
+
+// AssetBalance returns the balance of address in the TRC10 asset id.
+func (c *Chain) AssetBalance(id, address string) int64 {
+	a, err := trongrid.ParseAddress(address)
+	if err != nil {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if asset, ok := c.assets[id]; ok {
+		return asset.balances[a]
+	}
+
+	return 0
+}
+
+func (c *Chain) ListAssets(ctx context.Context, req *trongrid.ListAssetsRequest) (*trongrid.ListAssetsResponse, error) {
+	return c.listAssets(ctx, func(*trongrid.AssetIssue) bool { return true }, req.Fingerprint, req.Limit)
+}
+
+func (c *Chain) GetAssetByID(ctx context.Context, req *trongrid.GetAssetRequest) (*trongrid.AssetIssue, error) {
+	return c.GetAssetIssueByID(ctx, req)
+}
+
+func (c *Chain) GetAssetsByName(
+	ctx context.Context,
+	req *trongrid.GetAssetsByNameRequest,
+) (*trongrid.ListAssetsResponse, error) {
+	return c.listAssets(ctx, func(a *trongrid.AssetIssue) bool { return a.Name == req.Name }, req.Fingerprint, req.Limit)
+}
+
+func (c *Chain) GetAssetIssueByID(ctx context.Context, req *trongrid.GetAssetRequest) (*trongrid.AssetIssue, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	asset, ok := c.assets[req.ID]
+	if !ok {
+		return nil, trongrid.ErrEmpty
+	}
+	issue := asset.issue
+
+	return &issue, nil
+}
+
+func (c *Chain) GetAssetIssueByAccount(ctx context.Context, address string) ([]*trongrid.AssetIssue, error) {
+	a, err := requestAddress(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.listAssets(ctx, func(i *trongrid.AssetIssue) bool { return i.OwnerAddress == a.String() }, "", maxLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+func (c *Chain) GetAssetIssueListByName(ctx context.Context, name string) ([]*trongrid.AssetIssue, error) {
+	resp, err := c.listAssets(ctx, func(a *trongrid.AssetIssue) bool { return a.Name == name }, "", maxLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Data, nil
+}
+
+func (c *Chain) TransferAsset(ctx context.Context, req *trongrid.TransferAssetRequest) (*trongrid.Transaction, error) {
+	owner, err := requestAddress(ctx, req.OwnerAddress)
+	if err != nil {
+		return nil, err
+	}
+	to, err := trongrid.ParseAddress(req.ToAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	value := trongrid.ContractValue{
+		Amount:       int(req.Amount),
+		OwnerAddress: formatAddress(owner, req.Visible),
+		ToAddress:    formatAddress(to, req.Visible),
+		AssetName:    req.AssetName,
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	acc, ok := c.accounts[owner]
+	switch {
+	case req.Amount <= 0:
+		return nil, walletError("Amount must be greater than 0.")
+	case owner == to:
+		return nil, walletError("Cannot transfer asset to yourself.")
+	case !ok:
+		return nil, walletError("No owner account!")
+	}
+	if message := c.checkAsset(owner, acc, &value); message != "" {
+		return nil, walletError(message)
+	}
+
+	tx := c.newTransaction(trongrid.Contract{
+		Type: trongrid.ContractTypeTRC10,
+		Parameter: trongrid.ContractParameter{
+			Value:   value,
+			TypeUrl: "type.googleapis.com/protocol.TransferAssetContract",
+		},
+		PermissionID: req.PermissionID,
+	}, 0)
+	if err = trongrid.SealTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+func (c *Chain) listAssets(
+	ctx context.Context,
+	match func(*trongrid.AssetIssue) bool,
+	fingerprint string,
+	limit int32,
+) (*trongrid.ListAssetsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	issues := []*trongrid.AssetIssue{}
+	for _, asset := range c.assets {
+		issue := asset.issue
+		if match(&issue) {
+			issues = append(issues, &issue)
+		}
+	}
+	c.mu.Unlock()
+
+	sort.Slice(issues, func(i, j int) bool { return issues[i].ID < issues[j].ID })
+
+	start, end, next, err := page(fingerprint, int(limit), len(issues))
+	if err != nil {
+		return nil, err
+	}
+
+	return &trongrid.ListAssetsResponse{Data: issues[start:end], Success: true, Meta: c.meta(end-start, next)}, nil
+}
+
+// checkAsset returns why a TRC10 transfer cannot be executed, if it cannot. c.mu must be held.
+func (c *Chain) checkAsset(owner trongrid.Address, acc *chainAccount, value *trongrid.ContractValue) string {
+	asset, ok := c.assets[assetID(value)]
+	switch {
+	case !ok:
+		return "No asset!"
+	case asset.balances[owner] < int64(value.Amount):
+		return "assetBalance is not sufficient."
+	case acc.balance < c.fees.Transfer:
+		return "Validate TransferAssetContract error, balance is not sufficient."
+	}
+
+	return ""
+}
+
+// transferAsset moves a TRC10 transfer and returns the recipient. c.mu must be held.
+func (c *Chain) transferAsset(owner trongrid.Address, value *trongrid.ContractValue, timestamp int64) trongrid.Address {
+	to, _ := trongrid.ParseAddress(value.ToAddress)
+	asset := c.assets[assetID(value)]
+
+	c.accounts[owner].balance -= c.fees.Transfer
+	asset.balances[owner] -= int64(value.Amount)
+	asset.balances[to] += int64(value.Amount)
+	c.account(to).latest = timestamp
+
+	return to
+}
+
+// assetID returns the asset ID of a TransferAssetContract, hex encoded unless it is visible.
+func assetID(value *trongrid.ContractValue) string {
+	if strings.HasPrefix(value.OwnerAddress, "T") {
+		return value.AssetName
+	}
+
+	b, err := hex.DecodeString(value.AssetName)
+	if err != nil {
+		return ""
+	}
+
+	return string(b)
+}
@@ -0,0 +1,243 @@
+package trongridtest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+// firstActivePermissionID is the Permission_id of the first active permission.
+const firstActivePermissionID = 2
+
+// chainPermissions are the permissions set by AccountPermissionUpdate. Key addresses are base58.
+type chainPermissions struct {
+	owner   *trongrid.Permission
+	witness *trongrid.Permission
+	actives []*trongrid.Permission
+}
+
+func (c *Chain) AccountPermissionUpdate(
+	ctx context.Context,
+	req *trongrid.AccountPermissionUpdateRequest,
+) (*trongrid.Transaction, error) {
+	value := trongrid.ContractValue{OwnerAddress: req.OwnerAddress}
+	var err error
+	if value.Owner, err = formatPermission(req.Owner, req.Visible); err != nil {
+		return nil, err
+	}
+	if value.Witness, err = formatPermission(req.Witness, req.Visible); err != nil {
+		return nil, err
+	}
+	for _, active := range req.Actives {
+		p, err := formatPermission(active, req.Visible)
+		if err != nil {
+			return nil, err
+		}
+		value.Actives = append(value.Actives, p)
+	}
+
+	return c.buildStake(ctx, trongrid.ContractTypeAccountPermissionUpdate, value, req.PermissionID, req.Visible)
+}
+
+// GetSignWeight checks the signatures of tx against the permissions on the chain.
+func (c *Chain) GetSignWeight(ctx context.Context, tx *trongrid.Transaction) (*trongrid.SignWeight, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	w := c.signWeight(tx)
+	if code := w.Result.Code; code != trongrid.SignWeightEnough && code != trongrid.SignWeightNotEnough {
+		return nil, walletError(code + ": " + w.Result.Message)
+	}
+
+	return w, nil
+}
+
+func (c *Chain) GetApprovedList(ctx context.Context, tx *trongrid.Transaction) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	signers, err := trongrid.TransactionSigners(tx)
+	if err != nil {
+		return nil, walletError(err.Error())
+	}
+
+	approved := make([]string, len(signers))
+	for i, s := range signers {
+		approved[i] = s.String()
+	}
+
+	return approved, nil
+}
+
+// signWeight returns the weight of the signatures of tx against its permission. c.mu must be held.
+func (c *Chain) signWeight(tx *trongrid.Transaction) *trongrid.SignWeight {
+	w := &trongrid.SignWeight{}
+	fail := func(code, message string) *trongrid.SignWeight {
+		w.Result.Code, w.Result.Message = code, message
+		return w
+	}
+
+	if len(tx.RawData.Contract) != 1 {
+		return fail("OTHER_ERROR", "exactly one contract is supported")
+	}
+	contract := &tx.RawData.Contract[0]
+	owner, err := trongrid.ParseAddress(contract.Parameter.Value.OwnerAddress)
+	if err != nil {
+		return fail("OTHER_ERROR", err.Error())
+	}
+
+	w.Permission = c.permission(owner, contract.PermissionID)
+	switch {
+	case w.Permission == nil:
+		return fail("PERMISSION_ERROR", fmt.Sprintf("permission isn't exit, permission id %d", contract.PermissionID))
+	case !w.Permission.Allows(contract.Type):
+		return fail("PERMISSION_ERROR", "Permission denied")
+	}
+
+	signers, err := trongrid.TransactionSigners(tx)
+	if err != nil {
+		return fail("SIGNATURE_FORMAT_ERROR", err.Error())
+	}
+	seen := make(map[trongrid.Address]bool, len(signers))
+	for _, s := range signers {
+		weight := w.Permission.Weight(s)
+		switch {
+		case weight == 0:
+			return fail("PERMISSION_ERROR", s.String()+" is not contained of permission")
+		case seen[s]:
+			return fail("PERMISSION_ERROR", s.String()+" has signed twice!")
+		}
+		seen[s] = true
+		w.CurrentWeight += weight
+		w.ApprovedList = append(w.ApprovedList, s.String())
+	}
+
+	if w.CurrentWeight < w.Permission.Threshold {
+		return fail(trongrid.SignWeightNotEnough, fmt.Sprintf("Signature weight is %d, threshold is %d",
+			w.CurrentWeight, w.Permission.Threshold))
+	}
+	w.Result.Code = trongrid.SignWeightEnough
+
+	return w
+}
+
+// permission returns the permission id of owner, nil if it does not exist. Accounts whose permissions
+// were never set, including accounts that do not exist yet, are owned by their own key. c.mu must be held.
+func (c *Chain) permission(owner trongrid.Address, id int32) *trongrid.Permission {
+	var perms chainPermissions
+	if acc, ok := c.accounts[owner]; ok {
+		perms = acc.permissions
+	}
+
+	switch {
+	case id == trongrid.OwnerPermissionID && perms.owner == nil:
+		return &trongrid.Permission{
+			Type:      trongrid.PermissionOwner,
+			Name:      "owner",
+			Threshold: 1,
+			Keys:      []trongrid.PermissionKey{{Address: owner.String(), Weight: 1}},
+		}
+	case id == trongrid.OwnerPermissionID:
+		return perms.owner
+	case id == trongrid.WitnessPermissionID:
+		return perms.witness
+	case id >= firstActivePermissionID && int(id-firstActivePermissionID) < len(perms.actives):
+		return perms.actives[id-firstActivePermissionID]
+	}
+
+	return nil
+}
+
+// checkPermissionUpdate returns why an AccountPermissionUpdate cannot be executed, if it cannot.
+// c.mu must be held.
+func (c *Chain) checkPermissionUpdate(owner trongrid.Address, v *trongrid.ContractValue) string {
+	switch {
+	case v.Owner == nil:
+		return "owner permission is missed"
+	case v.Witness != nil && c.witnesses[owner] == nil:
+		return "account isn't witness can't set witness permission"
+	case len(v.Actives) == 0:
+		return "active permission is missed"
+	case len(v.Actives) > trongrid.MaxActivePermissions:
+		return fmt.Sprintf("active permission is too many, max is %d", trongrid.MaxActivePermissions)
+	}
+
+	check := func(p *trongrid.Permission, typ string) string {
+		if p.Type != typ && !(typ == trongrid.PermissionOwner && p.Type == "") {
+			return "permission type is error"
+		}
+		if err := p.Validate(); err != nil {
+			return err.Error()
+		}
+
+		return ""
+	}
+	if message := check(v.Owner, trongrid.PermissionOwner); message != "" {
+		return message
+	}
+	if v.Witness != nil {
+		if message := check(v.Witness, trongrid.PermissionWitness); message != "" {
+			return message
+		}
+	}
+	for _, active := range v.Actives {
+		if message := check(active, trongrid.PermissionActive); message != "" {
+			return message
+		}
+	}
+
+	return ""
+}
+
+// updatePermissions applies an AccountPermissionUpdate checked by checkPermissionUpdate,
+// numbering the active permissions from 2.
+func (acc *chainAccount) updatePermissions(v *trongrid.ContractValue) {
+	owner, _ := formatPermission(v.Owner, true)
+	owner.Type, owner.ID = trongrid.PermissionOwner, trongrid.OwnerPermissionID
+	witness, _ := formatPermission(v.Witness, true)
+	if witness != nil {
+		witness.ID = trongrid.WitnessPermissionID
+	}
+
+	acc.permissions = chainPermissions{owner: owner, witness: witness}
+	for i, active := range v.Actives {
+		p, _ := formatPermission(active, true)
+		p.ID = int32(firstActivePermissionID + i)
+		acc.permissions.actives = append(acc.permissions.actives, p)
+	}
+}
+
+// permissionInfo sets the permissions of acc on account, with hex addresses. c.mu must be held.
+func (acc *chainAccount) permissionInfo(account *trongrid.Account) {
+	account.OwnerPermission, _ = formatPermission(acc.permissions.owner, false)
+	account.WitnessPermission, _ = formatPermission(acc.permissions.witness, false)
+	for _, active := range acc.permissions.actives {
+		p, _ := formatPermission(active, false)
+		account.ActivePermission = append(account.ActivePermission, p)
+	}
+}
+
+// formatPermission returns a copy of p with its key addresses formatted as requested. A nil p is returned as is.
+func formatPermission(p *trongrid.Permission, visible bool) (*trongrid.Permission, error) {
+	if p == nil {
+		return nil, nil
+	}
+
+	formatted := *p
+	formatted.Keys = make([]trongrid.PermissionKey, len(p.Keys))
+	for i, k := range p.Keys {
+		a, err := trongrid.ParseAddress(k.Address)
+		if err != nil {
+			return nil, err
+		}
+		formatted.Keys[i] = trongrid.PermissionKey{Address: formatAddress(a, visible), Weight: k.Weight}
+	}
+
+	return &formatted, nil
+}
@@ -0,0 +1,114 @@
+package trongridtest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+func TestChain_AccountPermissionUpdate(t *testing.T) {
+	ctx := context.Background()
+	chain := trongridtest.NewChain()
+
+	var api trongrid.API = chain
+
+	owner := mustKey(t)
+	signers := []*trongrid.PrivateKey{mustKey(t), mustKey(t), mustKey(t)}
+	to := mustKey(t)
+	require.NoError(t, chain.Fund(owner.Address().String(), 10_000_000))
+
+	keys := make([]trongrid.PermissionKey, len(signers))
+	for i, k := range signers {
+		keys[i] = trongrid.PermissionKey{Address: k.Address().String(), Weight: 1}
+	}
+	active := &trongrid.Permission{Type: trongrid.PermissionActive, Name: "payments", Threshold: 2, Keys: keys}
+	require.NoError(t, active.SetOperations(trongrid.ContractTypeTRX))
+
+	// a witness permission needs a witness
+	_, err := api.AccountPermissionUpdate(ctx, &trongrid.AccountPermissionUpdateRequest{
+		OwnerAddress: owner.Address().String(),
+		Owner:        &trongrid.Permission{Name: "owner", Threshold: 1, Keys: keys[:1]},
+		Witness: &trongrid.Permission{
+			Type: trongrid.PermissionWitness, Name: "witness", Threshold: 1, Keys: keys[:1],
+		},
+		Actives: []*trongrid.Permission{active},
+	})
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+
+	tx, err := api.AccountPermissionUpdate(ctx, &trongrid.AccountPermissionUpdateRequest{
+		OwnerAddress: owner.Address().String(),
+		Owner: &trongrid.Permission{
+			Name:      "owner",
+			Threshold: 2,
+			Keys:      keys,
+		},
+		Actives: []*trongrid.Permission{active},
+		Visible: true,
+	})
+	require.NoError(t, err)
+	broadcast(t, api, tx, owner)
+	chain.Produce()
+
+	account, err := api.GetAccount(ctx, &trongrid.GetAccountRequest{Address: owner.Address().String()})
+	require.NoError(t, err)
+	require.NotNil(t, account.OwnerPermission)
+	assert.Equal(t, int64(2), account.OwnerPermission.Threshold)
+	assert.Equal(t, signers[0].Address().Hex(), account.OwnerPermission.Keys[0].Address)
+	require.Len(t, account.ActivePermission, 1)
+	assert.Equal(t, int32(2), account.ActivePermission[0].ID)
+
+	// the original key no longer signs for the account
+	tx, err = api.CreateTransaction(ctx, &trongrid.CreateTransactionRequest{
+		OwnerAddress: owner.Address().String(),
+		ToAddress:    to.Address().String(),
+		Amount:       1_000_000,
+		PermissionID: 2,
+	})
+	require.NoError(t, err)
+	require.NoError(t, trongrid.SignTransaction(tx, owner))
+	resp, err := api.BroadcastTransaction(ctx, tx)
+	require.ErrorIs(t, err, trongrid.ErrBroadcastFailed)
+	assert.Equal(t, "SIGERROR", resp.Code)
+
+	// one of two signatures is not enough
+	tx.Signature = nil
+	require.NoError(t, trongrid.SignTransaction(tx, signers[0]))
+	weight, err := api.GetSignWeight(ctx, tx)
+	require.NoError(t, err)
+	assert.False(t, weight.Enough())
+	assert.Equal(t, int64(1), weight.CurrentWeight)
+	resp, err = api.BroadcastTransaction(ctx, tx)
+	require.ErrorIs(t, err, trongrid.ErrBroadcastFailed)
+	assert.Equal(t, "SIGERROR", resp.Code)
+
+	require.NoError(t, trongrid.SignTransaction(tx, signers[2]))
+	weight, err = api.GetSignWeight(ctx, tx)
+	require.NoError(t, err)
+	assert.True(t, weight.Enough())
+	approved, err := api.GetApprovedList(ctx, tx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{signers[0].Address().String(), signers[2].Address().String()}, approved)
+
+	resp, err = api.BroadcastTransaction(ctx, tx)
+	require.NoError(t, err)
+	assert.True(t, resp.Result)
+	chain.Produce()
+	assert.Equal(t, int64(1_000_000), chain.Balance(to.Address().String()))
+
+	// the active permission only allows transfers
+	tx, err = api.FreezeBalanceV2(ctx, &trongrid.FreezeBalanceV2Request{
+		OwnerAddress:  owner.Address().String(),
+		FrozenBalance: 1_000_000,
+		Resource:      trongrid.ResourceEnergy,
+		PermissionID:  2,
+	})
+	require.NoError(t, err)
+	weight, err = api.GetSignWeight(ctx, tx)
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+	assert.Nil(t, weight)
+}
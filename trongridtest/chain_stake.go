@@ -0,0 +1,604 @@
+package trongridtest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+// defaultLockPeriod is the lock of a DelegateResource without lock_period, in blocks.
+const defaultLockPeriod = 3 * trongrid.BlocksPerLockDay
+
+// Network wide resources, as on mainnet. The rest of the network is simulated by a fixed stake in TRX,
+// so staking on the chain yields mainnet-like limits: about 9.5 energy and 1.4 bandwidth per TRX.
+const (
+	freeNetLimit        = 600
+	totalNetLimit       = 43_200_000_000
+	totalEnergyLimit    = 180_000_000_000
+	networkNetWeight    = 30_000_000_000
+	networkEnergyWeight = 19_000_000_000
+)
+
+type delegationKey struct {
+	from, to trongrid.Address
+}
+
+// Freeze stakes sun of the balance of address for resource, as if a FreezeBalanceV2 had been executed.
+func (c *Chain) Freeze(address, resource string, sun int64) error {
+	a, err := trongrid.ParseAddress(address)
+	if err != nil {
+		return err
+	}
+	res, message := stakeResource(resource)
+	if message != "" {
+		return walletError(message)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	acc, ok := c.accounts[a]
+	if !ok || acc.balance < sun {
+		return fmt.Errorf("%w: balance of %s is not sufficient", trongrid.ErrInvalidRequest, address)
+	}
+	acc.balance -= sun
+	acc.stake(res, sun)
+
+	return nil
+}
+
+func (c *Chain) FreezeBalanceV2(
+	ctx context.Context,
+	req *trongrid.FreezeBalanceV2Request,
+) (*trongrid.Transaction, error) {
+	return c.buildStake(ctx, trongrid.ContractTypeFreezeV2, trongrid.ContractValue{
+		OwnerAddress:  req.OwnerAddress,
+		FrozenBalance: req.FrozenBalance,
+		Resource:      req.Resource,
+	}, req.PermissionID, req.Visible)
+}
+
+func (c *Chain) UnfreezeBalanceV2(
+	ctx context.Context,
+	req *trongrid.UnfreezeBalanceV2Request,
+) (*trongrid.Transaction, error) {
+	return c.buildStake(ctx, trongrid.ContractTypeUnfreezeV2, trongrid.ContractValue{
+		OwnerAddress:    req.OwnerAddress,
+		UnfreezeBalance: req.UnfreezeBalance,
+		Resource:        req.Resource,
+	}, req.PermissionID, req.Visible)
+}
+
+func (c *Chain) WithdrawExpireUnfreeze(
+	ctx context.Context,
+	req *trongrid.WithdrawExpireUnfreezeRequest,
+) (*trongrid.Transaction, error) {
+	return c.buildStake(ctx, trongrid.ContractTypeWithdrawExpireUnfreeze, trongrid.ContractValue{
+		OwnerAddress: req.OwnerAddress,
+	}, req.PermissionID, req.Visible)
+}
+
+func (c *Chain) CancelAllUnfreezeV2(
+	ctx context.Context,
+	req *trongrid.CancelAllUnfreezeV2Request,
+) (*trongrid.Transaction, error) {
+	return c.buildStake(ctx, trongrid.ContractTypeCancelAllUnfreezeV2, trongrid.ContractValue{
+		OwnerAddress: req.OwnerAddress,
+	}, req.PermissionID, req.Visible)
+}
+
+func (c *Chain) DelegateResource(
+	ctx context.Context,
+	req *trongrid.DelegateResourceRequest,
+) (*trongrid.Transaction, error) {
+	return c.buildStake(ctx, trongrid.ContractTypeDelegateResource, trongrid.ContractValue{
+		OwnerAddress:    req.OwnerAddress,
+		ReceiverAddress: req.ReceiverAddress,
+		Balance:         req.Balance,
+		Resource:        req.Resource,
+		Lock:            req.Lock,
+		LockPeriod:      req.LockPeriod,
+	}, req.PermissionID, req.Visible)
+}
+
+func (c *Chain) UndelegateResource(
+	ctx context.Context,
+	req *trongrid.UndelegateResourceRequest,
+) (*trongrid.Transaction, error) {
+	return c.buildStake(ctx, trongrid.ContractTypeUndelegateResource, trongrid.ContractValue{
+		OwnerAddress:    req.OwnerAddress,
+		ReceiverAddress: req.ReceiverAddress,
+		Balance:         req.Balance,
+		Resource:        req.Resource,
+	}, req.PermissionID, req.Visible)
+}
+
+func (c *Chain) GetDelegatedResourceV2(ctx context.Context, from, to string) ([]*trongrid.DelegatedResource, error) {
+	f, err := requestAddress(ctx, from)
+	if err != nil {
+		return nil, err
+	}
+	t, err := trongrid.ParseAddress(to)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if d, ok := c.delegations[delegationKey{f, t}]; ok {
+		return []*trongrid.DelegatedResource{clone(d)}, nil
+	}
+
+	return nil, nil
+}
+
+func (c *Chain) GetDelegatedResourceAccountIndexV2(
+	ctx context.Context,
+	address string,
+) (*trongrid.DelegatedResourceAccountIndex, error) {
+	a, err := requestAddress(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	index := &trongrid.DelegatedResourceAccountIndex{Account: a.String()}
+	for key := range c.delegations {
+		switch a {
+		case key.to:
+			index.FromAccounts = append(index.FromAccounts, key.from.String())
+		case key.from:
+			index.ToAccounts = append(index.ToAccounts, key.to.String())
+		}
+	}
+	sort.Strings(index.FromAccounts)
+	sort.Strings(index.ToAccounts)
+
+	return index, nil
+}
+
+func (c *Chain) GetCanWithdrawUnfreezeAmount(ctx context.Context, address string, at time.Time) (int64, error) {
+	a, err := requestAddress(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if at.IsZero() {
+		at = c.now()
+	}
+	if acc, ok := c.accounts[a]; ok {
+		return acc.withdrawable(at.UnixMilli()), nil
+	}
+
+	return 0, nil
+}
+
+func (c *Chain) GetAvailableUnfreezeCount(ctx context.Context, address string) (int64, error) {
+	a, err := requestAddress(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if acc, ok := c.accounts[a]; ok {
+		return int64(trongrid.MaxUnfreezingCount - acc.waiting(c.now().UnixMilli())), nil
+	}
+
+	return trongrid.MaxUnfreezingCount, nil
+}
+
+func (c *Chain) GetCanDelegatedMaxSize(ctx context.Context, address, resource string) (int64, error) {
+	a, err := requestAddress(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+	res, message := stakeResource(resource)
+	if message != "" {
+		return 0, walletError(message)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if acc, ok := c.accounts[a]; ok {
+		return acc.frozen[res], nil
+	}
+
+	return 0, nil
+}
+
+// GetAccountResource reports limits from the stakes on the chain. Usage is not metered, so nothing is ever used.
+func (c *Chain) GetAccountResource(ctx context.Context, address string) (*trongrid.AccountResourceMessage, error) {
+	a, err := requestAddress(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	acc, ok := c.accounts[a]
+	if !ok {
+		return nil, trongrid.ErrEmpty
+	}
+
+	netWeight := networkNetWeight + c.staked(trongrid.ResourceBandwidth)/trongrid.SunPerTRX
+	energyWeight := networkEnergyWeight + c.staked(trongrid.ResourceEnergy)/trongrid.SunPerTRX
+	resources := func(res string, total, weight int64) int64 {
+		_, in := c.delegated(a, res)
+		return (acc.frozen[res] + in) / trongrid.SunPerTRX * total / weight
+	}
+
+	return &trongrid.AccountResourceMessage{
+		FreeNetLimit:      freeNetLimit,
+		NetLimit:          resources(trongrid.ResourceBandwidth, totalNetLimit, netWeight),
+		TotalNetLimit:     totalNetLimit,
+		TotalNetWeight:    netWeight,
+		TronPowerUsed:     acc.voted(),
+		TronPowerLimit:    c.tronPower(a, acc),
+		EnergyLimit:       resources(trongrid.ResourceEnergy, totalEnergyLimit, energyWeight),
+		TotalEnergyLimit:  totalEnergyLimit,
+		TotalEnergyWeight: energyWeight,
+	}, nil
+}
+
+// buildStake validates and builds a Stake 2.0 or voting transaction from value, whose addresses are as requested.
+func (c *Chain) buildStake(
+	ctx context.Context,
+	typ string,
+	value trongrid.ContractValue,
+	permissionID int32,
+	visible bool,
+) (*trongrid.Transaction, error) {
+	owner, err := requestAddress(ctx, value.OwnerAddress)
+	if err != nil {
+		return nil, err
+	}
+	value.OwnerAddress = formatAddress(owner, visible)
+	if value.ReceiverAddress != "" {
+		receiver, err := trongrid.ParseAddress(value.ReceiverAddress)
+		if err != nil {
+			return nil, err
+		}
+		value.ReceiverAddress = formatAddress(receiver, visible)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	acc, ok := c.accounts[owner]
+	if !ok {
+		return nil, walletError("Account[" + owner.Hex() + "] not exists")
+	}
+	if message := c.checkStake(owner, acc, typ, &value); message != "" {
+		return nil, walletError(message)
+	}
+
+	tx := c.newTransaction(trongrid.Contract{
+		Type: typ,
+		Parameter: trongrid.ContractParameter{
+			Value:   value,
+			TypeUrl: "type.googleapis.com/protocol." + typ,
+		},
+		PermissionID: permissionID,
+	}, 0)
+	if err = trongrid.SealTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	return tx, nil
+}
+
+// checkStake returns why a Stake 2.0 or voting contract cannot be executed, if it cannot. c.mu must be held.
+func (c *Chain) checkStake(owner trongrid.Address, acc *chainAccount, typ string, v *trongrid.ContractValue) string {
+	now := c.now().UnixMilli()
+	res, message := stakeResource(v.Resource)
+
+	switch typ {
+	case trongrid.ContractTypeFreezeV2:
+		switch {
+		case message != "":
+			return message
+		case v.FrozenBalance < trongrid.SunPerTRX:
+			return "frozenBalance must be greater than or equal to 1 TRX"
+		case v.FrozenBalance > acc.balance:
+			return "frozenBalance must be less than or equal to accountBalance"
+		}
+	case trongrid.ContractTypeUnfreezeV2:
+		switch {
+		case message != "":
+			return message
+		case v.UnfreezeBalance <= 0 || v.UnfreezeBalance > acc.frozen[res]:
+			return "Invalid unfreeze_balance, freezing resource[" + res + "] is not enough"
+		case acc.waiting(now) >= trongrid.MaxUnfreezingCount:
+			return "Invalid unfreeze operation, unfreezing times is over limit"
+		}
+	case trongrid.ContractTypeWithdrawExpireUnfreeze:
+		if acc.withdrawable(now) == 0 {
+			return "no unFreeze balance to withdraw "
+		}
+	case trongrid.ContractTypeCancelAllUnfreezeV2:
+		if len(acc.unfreezing) == 0 {
+			return "No unfreezeV2 list to cancel"
+		}
+	case trongrid.ContractTypeDelegateResource:
+		receiver, err := trongrid.ParseAddress(v.ReceiverAddress)
+		switch {
+		case message != "":
+			return message
+		case err != nil:
+			return "Invalid receiverAddress"
+		case receiver == owner:
+			return "receiverAddress must not be the same as ownerAddress"
+		case c.accounts[receiver] == nil:
+			return "Account[" + receiver.Hex() + "] not exists"
+		case v.Balance < trongrid.SunPerTRX:
+			return "delegateBalance must be greater than or equal to 1 TRX"
+		case v.Balance > acc.frozen[res]:
+			return "delegateBalance must be less than or equal to available FreezeV2 balance"
+		case v.LockPeriod < 0:
+			return "The lock_period must be greater than or equal to 0"
+		}
+	case trongrid.ContractTypeUndelegateResource:
+		receiver, err := trongrid.ParseAddress(v.ReceiverAddress)
+		if message != "" {
+			return message
+		}
+		if err != nil {
+			return "Invalid receiverAddress"
+		}
+		if v.Balance <= 0 {
+			return "unDelegateBalance must be more than 0 TRX"
+		}
+		unlocked := unlocked(c.delegations[delegationKey{owner, receiver}], res, now)
+		if unlocked < v.Balance {
+			return fmt.Sprintf("insufficient delegatedFrozenBalance(%s), request=%d, unlock_balance=%d",
+				res, v.Balance, unlocked)
+		}
+	case trongrid.ContractTypeVote, trongrid.ContractTypeWithdrawBalance:
+		return c.checkVote(owner, acc, typ, v, now)
+	case trongrid.ContractTypeAccountPermissionUpdate:
+		return c.checkPermissionUpdate(owner, v)
+	}
+
+	return ""
+}
+
+// stake applies a Stake 2.0 or voting contract checked by checkStake. c.mu must be held.
+func (c *Chain) stake(owner trongrid.Address, acc *chainAccount, typ string, v *trongrid.ContractValue, now int64) {
+	res, _ := stakeResource(v.Resource)
+
+	switch typ {
+	case trongrid.ContractTypeFreezeV2:
+		acc.balance -= v.FrozenBalance
+		acc.stake(res, v.FrozenBalance)
+	case trongrid.ContractTypeUnfreezeV2:
+		acc.withdraw(now)
+		acc.stake(res, -v.UnfreezeBalance)
+		acc.unfreezing = append(acc.unfreezing, trongrid.UnfrozenV2{
+			Type:               resourceType(res),
+			UnfreezeAmount:     v.UnfreezeBalance,
+			UnfreezeExpireTime: now + trongrid.UnfreezeDelay.Milliseconds(),
+		})
+		// votes no longer backed by TRON Power are cancelled
+		if acc.voted() > c.tronPower(owner, acc) {
+			acc.votes = nil
+		}
+	case trongrid.ContractTypeWithdrawExpireUnfreeze:
+		acc.withdraw(now)
+	case trongrid.ContractTypeCancelAllUnfreezeV2:
+		acc.withdraw(now)
+		for _, u := range acc.unfreezing {
+			r, _ := stakeResource(u.Type)
+			acc.stake(r, u.UnfreezeAmount)
+		}
+		acc.unfreezing = nil
+	case trongrid.ContractTypeDelegateResource:
+		receiver, _ := trongrid.ParseAddress(v.ReceiverAddress)
+		key := delegationKey{owner, receiver}
+		d, ok := c.delegations[key]
+		if !ok {
+			d = &trongrid.DelegatedResource{From: owner.String(), To: receiver.String()}
+			c.delegations[key] = d
+		}
+
+		var expire int64
+		if v.Lock {
+			period := v.LockPeriod
+			if period == 0 {
+				period = defaultLockPeriod
+			}
+			expire = now + period*blockInterval.Milliseconds()
+		}
+
+		acc.stake(res, -v.Balance)
+		if res == trongrid.ResourceEnergy {
+			d.FrozenBalanceForEnergy += v.Balance
+			if expire > d.ExpireTimeForEnergy {
+				d.ExpireTimeForEnergy = expire
+			}
+		} else {
+			d.FrozenBalanceForBandwidth += v.Balance
+			if expire > d.ExpireTimeForBandwidth {
+				d.ExpireTimeForBandwidth = expire
+			}
+		}
+	case trongrid.ContractTypeUndelegateResource:
+		receiver, _ := trongrid.ParseAddress(v.ReceiverAddress)
+		key := delegationKey{owner, receiver}
+		d := c.delegations[key]
+
+		acc.stake(res, v.Balance)
+		if res == trongrid.ResourceEnergy {
+			d.FrozenBalanceForEnergy -= v.Balance
+		} else {
+			d.FrozenBalanceForBandwidth -= v.Balance
+		}
+		if d.FrozenBalanceForEnergy == 0 && d.FrozenBalanceForBandwidth == 0 {
+			delete(c.delegations, key)
+		}
+	case trongrid.ContractTypeVote, trongrid.ContractTypeWithdrawBalance:
+		acc.vote(typ, v, now)
+	case trongrid.ContractTypeAccountPermissionUpdate:
+		acc.updatePermissions(v)
+	}
+}
+
+// staked returns the TRX staked on the chain for res, in sun. c.mu must be held.
+func (c *Chain) staked(res string) int64 {
+	var sum int64
+	for _, acc := range c.accounts {
+		sum += acc.frozen[res]
+	}
+	for _, d := range c.delegations {
+		if res == trongrid.ResourceEnergy {
+			sum += d.FrozenBalanceForEnergy
+		} else {
+			sum += d.FrozenBalanceForBandwidth
+		}
+	}
+
+	return sum
+}
+
+// tronPower returns the votes of a: one per staked TRX, whether it is delegated or not. c.mu must be held.
+func (c *Chain) tronPower(a trongrid.Address, acc *chainAccount) int64 {
+	bandwidthOut, _ := c.delegated(a, trongrid.ResourceBandwidth)
+	energyOut, _ := c.delegated(a, trongrid.ResourceEnergy)
+	sum := bandwidthOut + energyOut
+	for _, sun := range acc.frozen {
+		sum += sun
+	}
+
+	return sum / trongrid.SunPerTRX
+}
+
+// delegated returns the staked TRX delegated from and to a for res. c.mu must be held.
+func (c *Chain) delegated(a trongrid.Address, res string) (out, in int64) {
+	for key, d := range c.delegations {
+		balance := d.FrozenBalanceForBandwidth
+		if res == trongrid.ResourceEnergy {
+			balance = d.FrozenBalanceForEnergy
+		}
+		if key.from == a {
+			out += balance
+		}
+		if key.to == a {
+			in += balance
+		}
+	}
+
+	return out, in
+}
+
+// stakeInfo fills the Stake 2.0 fields of account. c.mu must be held.
+func (c *Chain) stakeInfo(a trongrid.Address, acc *chainAccount, account *trongrid.Account) {
+	for _, res := range []string{trongrid.ResourceBandwidth, trongrid.ResourceEnergy} {
+		if amount := acc.frozen[res]; amount != 0 {
+			account.FrozenV2 = append(account.FrozenV2, trongrid.FrozenV2{Type: resourceType(res), Amount: amount})
+		}
+	}
+	account.UnfrozenV2 = append(account.UnfrozenV2, acc.unfreezing...)
+
+	account.DelegatedFrozenV2BalanceForBandwidth, account.AcquiredDelegatedFrozenV2BalanceForBandwidth =
+		c.delegated(a, trongrid.ResourceBandwidth)
+	account.AccountResource = &trongrid.AccountResource{}
+	account.AccountResource.DelegatedFrozenV2BalanceForEnergy,
+		account.AccountResource.AcquiredDelegatedFrozenV2BalanceForEnergy = c.delegated(a, trongrid.ResourceEnergy)
+
+	for _, v := range acc.votes {
+		witness, _ := trongrid.ParseAddress(v.VoteAddress)
+		account.Votes = append(account.Votes, trongrid.Vote{VoteAddress: witness.Hex(), VoteCount: v.VoteCount})
+	}
+	account.LatestWithdrawTime = acc.withdrawn
+}
+
+func (acc *chainAccount) stake(res string, sun int64) {
+	if acc.frozen == nil {
+		acc.frozen = make(map[string]int64)
+	}
+	acc.frozen[res] += sun
+}
+
+// withdrawable returns the unfrozen TRX that can be withdrawn at now.
+func (acc *chainAccount) withdrawable(now int64) int64 {
+	var sum int64
+	for _, u := range acc.unfreezing {
+		if u.UnfreezeExpireTime <= now {
+			sum += u.UnfreezeAmount
+		}
+	}
+
+	return sum
+}
+
+// waiting returns the number of unfreezes that cannot be withdrawn yet at now.
+func (acc *chainAccount) waiting(now int64) int {
+	n := 0
+	for _, u := range acc.unfreezing {
+		if u.UnfreezeExpireTime > now {
+			n++
+		}
+	}
+
+	return n
+}
+
+// withdraw credits the unfrozen TRX that can be withdrawn at now.
+func (acc *chainAccount) withdraw(now int64) {
+	acc.balance += acc.withdrawable(now)
+
+	unfreezing := acc.unfreezing[:0]
+	for _, u := range acc.unfreezing {
+		if u.UnfreezeExpireTime > now {
+			unfreezing = append(unfreezing, u)
+		}
+	}
+	acc.unfreezing = unfreezing
+}
+
+// unlocked returns the balance of d delegated for res that can be undelegated at now. d may be nil.
+func unlocked(d *trongrid.DelegatedResource, res string, now int64) int64 {
+	switch {
+	case d == nil:
+		return 0
+	case res == trongrid.ResourceEnergy && d.ExpireTimeForEnergy <= now:
+		return d.FrozenBalanceForEnergy
+	case res == trongrid.ResourceBandwidth && d.ExpireTimeForBandwidth <= now:
+		return d.FrozenBalanceForBandwidth
+	}
+
+	return 0
+}
+
+// stakeResource normalizes a Stake 2.0 resource, returning a wallet error message if it is not supported.
+func stakeResource(resource string) (res, message string) {
+	switch resource {
+	case "", trongrid.ResourceBandwidth:
+		return trongrid.ResourceBandwidth, ""
+	case trongrid.ResourceEnergy:
+		return trongrid.ResourceEnergy, ""
+	}
+
+	return "", "ResourceCode error, valid ResourceCode[BANDWIDTH、ENERGY]"
+}
+
+// resourceType returns the type field of res in account JSON, where bandwidth is left out.
+func resourceType(res string) string {
+	if res == trongrid.ResourceBandwidth {
+		return ""
+	}
+
+	return res
+}
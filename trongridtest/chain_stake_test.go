@@ -0,0 +1,159 @@
+package trongridtest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+func TestChain_StakeDelegateUnfreeze(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	chain := trongridtest.NewChain(trongridtest.WithClock(func() time.Time { return now }))
+
+	var api trongrid.API = chain
+
+	hot := mustKey(t)
+	payer := mustKey(t)
+	require.NoError(t, chain.Fund(hot.Address().String(), 500_000_000))
+	require.NoError(t, chain.Fund(payer.Address().String(), 1_000_000))
+
+	// the hot wallet stakes 300 TRX for energy and lends 100 TRX worth of it, locked, to a payout address
+	tx, err := api.FreezeBalanceV2(ctx, &trongrid.FreezeBalanceV2Request{
+		OwnerAddress:  hot.Address().String(),
+		FrozenBalance: 300_000_000,
+		Resource:      trongrid.ResourceEnergy,
+		Visible:       true,
+	})
+	require.NoError(t, err)
+	broadcast(t, api, tx, hot)
+	chain.Produce()
+
+	tx, err = api.DelegateResource(ctx, &trongrid.DelegateResourceRequest{
+		OwnerAddress:    hot.Address().String(),
+		ReceiverAddress: payer.Address().String(),
+		Balance:         100_000_000,
+		Resource:        trongrid.ResourceEnergy,
+		Lock:            true,
+		LockPeriod:      trongrid.BlocksPerLockDay,
+	})
+	require.NoError(t, err)
+	broadcast(t, api, tx, hot)
+	chain.Produce()
+
+	size, err := api.GetCanDelegatedMaxSize(ctx, hot.Address().String(), trongrid.ResourceEnergy)
+	require.NoError(t, err)
+	assert.Equal(t, int64(200_000_000), size)
+
+	delegated, err := api.GetDelegatedResourceV2(ctx, hot.Address().String(), payer.Address().String())
+	require.NoError(t, err)
+	require.Len(t, delegated, 1)
+	assert.Equal(t, int64(100_000_000), delegated[0].FrozenBalanceForEnergy)
+	assert.Equal(t, now.Add(24*time.Hour).UnixMilli(), delegated[0].ExpireTimeForEnergy)
+
+	index, err := api.GetDelegatedResourceAccountIndexV2(ctx, payer.Address().String())
+	require.NoError(t, err)
+	assert.Equal(t, []string{hot.Address().String()}, index.FromAccounts)
+
+	account, err := api.GetAccount(ctx, &trongrid.GetAccountRequest{Address: payer.Address().String()})
+	require.NoError(t, err)
+	assert.Equal(t, int64(100_000_000), account.AccountResource.AcquiredDelegatedFrozenV2BalanceForEnergy)
+
+	// 100 TRX yields about 947 energy a day on the payout address, and the hot wallet keeps its 300 votes
+	resources, err := api.GetAccountResource(ctx, payer.Address().String())
+	require.NoError(t, err)
+	assert.Equal(t, int64(947), resources.EnergyLimit)
+	assert.Equal(t, int64(600), resources.FreeNetLimit)
+	resources, err = api.GetAccountResource(ctx, hot.Address().String())
+	require.NoError(t, err)
+	assert.Equal(t, int64(300), resources.TronPowerLimit)
+
+	// the delegation is locked for a day
+	undelegate := &trongrid.UndelegateResourceRequest{
+		OwnerAddress:    hot.Address().String(),
+		ReceiverAddress: payer.Address().String(),
+		Balance:         100_000_000,
+		Resource:        trongrid.ResourceEnergy,
+	}
+	_, err = api.UndelegateResource(ctx, undelegate)
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+
+	now = now.Add(24 * time.Hour)
+	tx, err = api.UndelegateResource(ctx, undelegate)
+	require.NoError(t, err)
+	broadcast(t, api, tx, hot)
+	chain.Produce()
+
+	// unstaking waits 14 days before the TRX can be withdrawn
+	tx, err = api.UnfreezeBalanceV2(ctx, &trongrid.UnfreezeBalanceV2Request{
+		OwnerAddress:    hot.Address().String(),
+		UnfreezeBalance: 300_000_000,
+		Resource:        trongrid.ResourceEnergy,
+	})
+	require.NoError(t, err)
+	broadcast(t, api, tx, hot)
+	chain.Produce()
+
+	count, err := api.GetAvailableUnfreezeCount(ctx, hot.Address().String())
+	require.NoError(t, err)
+	assert.Equal(t, int64(trongrid.MaxUnfreezingCount-1), count)
+
+	_, err = api.WithdrawExpireUnfreeze(ctx, &trongrid.WithdrawExpireUnfreezeRequest{
+		OwnerAddress: hot.Address().String(),
+	})
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+
+	amount, err := api.GetCanWithdrawUnfreezeAmount(ctx, hot.Address().String(), now.Add(trongrid.UnfreezeDelay))
+	require.NoError(t, err)
+	assert.Equal(t, int64(300_000_000), amount)
+
+	now = now.Add(trongrid.UnfreezeDelay)
+	tx, err = api.WithdrawExpireUnfreeze(ctx, &trongrid.WithdrawExpireUnfreezeRequest{
+		OwnerAddress: hot.Address().String(),
+	})
+	require.NoError(t, err)
+	broadcast(t, api, tx, hot)
+	chain.Produce()
+
+	assert.Equal(t, int64(500_000_000), chain.Balance(hot.Address().String()))
+}
+
+func TestChain_CancelAllUnfreezeV2(t *testing.T) {
+	ctx := context.Background()
+	chain := trongridtest.NewChain()
+
+	owner := mustKey(t)
+	require.NoError(t, chain.Fund(owner.Address().String(), 100_000_000))
+	require.NoError(t, chain.Freeze(owner.Address().String(), trongrid.ResourceBandwidth, 50_000_000))
+
+	tx, err := chain.UnfreezeBalanceV2(ctx, &trongrid.UnfreezeBalanceV2Request{
+		OwnerAddress:    owner.Address().Hex(),
+		UnfreezeBalance: 20_000_000,
+	})
+	require.NoError(t, err)
+	broadcast(t, chain, tx, owner)
+	chain.Produce()
+
+	account, err := chain.GetAccount(ctx, &trongrid.GetAccountRequest{Address: owner.Address().String()})
+	require.NoError(t, err)
+	assert.Equal(t, []trongrid.FrozenV2{{Amount: 30_000_000}}, account.FrozenV2)
+	require.Len(t, account.UnfrozenV2, 1)
+	assert.Equal(t, int64(20_000_000), account.UnfrozenV2[0].UnfreezeAmount)
+
+	tx, err = chain.CancelAllUnfreezeV2(ctx, &trongrid.CancelAllUnfreezeV2Request{OwnerAddress: owner.Address().Hex()})
+	require.NoError(t, err)
+	broadcast(t, chain, tx, owner)
+	chain.Produce()
+
+	account, err = chain.GetAccount(ctx, &trongrid.GetAccountRequest{Address: owner.Address().String()})
+	require.NoError(t, err)
+	assert.Equal(t, []trongrid.FrozenV2{{Amount: 50_000_000}}, account.FrozenV2)
+	assert.Empty(t, account.UnfrozenV2)
+	assert.Equal(t, int64(50_000_000), account.Balance)
+}
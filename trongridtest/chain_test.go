@@ -0,0 +1,246 @@
+package trongridtest_test
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+func TestChain_DepositSweepWithdraw(t *testing.T) {
+	ctx := context.Background()
+	chain := trongridtest.NewChain(trongridtest.WithFees(trongridtest.Fees{Transfer: 1_000, TRC20Transfer: 5_000}))
+
+	var api trongrid.API = chain
+
+	usdt, err := chain.DeployTRC20(trongrid.Token{Symbol: "USDT", Name: "Tether USD", Decimals: 6})
+	require.NoError(t, err)
+	token, ok := api.Network().Token("usdt")
+	require.True(t, ok)
+	assert.Equal(t, usdt, token.Address)
+
+	customer := mustKey(t)
+	deposit := mustKey(t)
+	hot := mustKey(t)
+	require.NoError(t, chain.Fund(customer.Address().String(), 10_000_000))
+	require.NoError(t, chain.Fund(hot.Address().String(), 10_000_000))
+	require.NoError(t, chain.Mint(usdt, customer.Address().String(), big.NewInt(50_000_000)))
+
+	// customer deposits 20 USDT to their deposit address
+	depositID := transfer(t, api, customer, usdt, deposit.Address(), 20_000_000)
+	block := chain.Produce()
+	require.Len(t, block.Transactions, 1)
+
+	events, err := api.ListContractEvents(ctx, &trongrid.ListContractEventsRequest{Address: usdt, EventName: "Transfer"})
+	require.NoError(t, err)
+	require.Len(t, events.Data, 1)
+	assert.Equal(t, depositID, events.Data[0].TransactionID)
+	assert.Equal(t, "20000000", events.Data[0].Result["value"])
+
+	// the hot wallet funds the deposit address with TRX for fees, then sweeps it
+	tx, err := api.CreateTransaction(ctx, &trongrid.CreateTransactionRequest{
+		OwnerAddress: hot.Address().String(),
+		ToAddress:    deposit.Address().String(),
+		Amount:       1_000_000,
+		Visible:      true,
+	})
+	require.NoError(t, err)
+	broadcast(t, api, tx, hot)
+	chain.Produce()
+	assert.Equal(t, int64(1_000_000), chain.Balance(deposit.Address().String()))
+
+	sweepID := transfer(t, api, deposit, usdt, hot.Address(), 20_000_000)
+	chain.Produce()
+
+	info, err := api.GetTransactionInfoByID(ctx, &trongrid.GetTransactionRequest{ID: sweepID})
+	require.NoError(t, err)
+	assert.Equal(t, "SUCCESS", info.Receipt.Result)
+	assert.Equal(t, int64(5_000), info.Fee)
+	require.Len(t, info.Log, 1)
+
+	// the hot wallet withdraws 15 USDT to the customer
+	transfer(t, api, hot, usdt, customer.Address(), 15_000_000)
+	chain.Produce()
+
+	assert.Equal(t, big.NewInt(45_000_000), chain.TokenBalance(usdt, customer.Address().String()))
+	assert.Equal(t, big.NewInt(0), chain.TokenBalance(usdt, deposit.Address().String()))
+	assert.Equal(t, big.NewInt(5_000_000), chain.TokenBalance(usdt, hot.Address().String()))
+	assert.Equal(t, int64(10_000_000-1_000_000-1_000-5_000), chain.Balance(hot.Address().String()))
+
+	history, err := api.ListTransactionsTrc20(ctx, &trongrid.ListTransactionsRequest{
+		Address: deposit.Address().String(),
+		OrderBy: trongrid.OrderByTimestampAsc,
+	})
+	require.NoError(t, err)
+	require.Len(t, history.Data, 2)
+	assert.Equal(t, depositID, history.Data[0].TransactionID)
+	assert.Equal(t, sweepID, history.Data[1].TransactionID)
+
+	account, err := api.GetAccount(ctx, &trongrid.GetAccountRequest{Address: deposit.Address().String()})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_000_000-5_000), account.Balance)
+
+	now, err := api.GetNowBlock(ctx, true)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), now.BlockHeader.RawData.Number)
+
+	holders, err := api.ListTokenHolders(ctx, &trongrid.ListTokenHoldersRequest{Address: usdt})
+	require.NoError(t, err)
+	require.Len(t, holders.Data, 2)
+	assert.Equal(t, customer.Address().String(), holders.Data[0].Address)
+	assert.Equal(t, "45", holders.Data[0].Balance.String())
+
+	balances, err := api.ListTRC20Balances(ctx, &trongrid.ListTRC20BalancesRequest{Address: hot.Address().String()})
+	require.NoError(t, err)
+	require.Len(t, balances.Data, 1)
+	assert.Equal(t, "5", balances.Data[0].Balance.String())
+}
+
+func TestChain_BroadcastRejected(t *testing.T) {
+	ctx := context.Background()
+	chain := trongridtest.NewChain()
+
+	owner := mustKey(t)
+	other := mustKey(t)
+	require.NoError(t, chain.Fund(owner.Address().String(), 5_000_000))
+
+	tx, err := chain.CreateTransaction(ctx, &trongrid.CreateTransactionRequest{
+		OwnerAddress: owner.Address().Hex(),
+		ToAddress:    other.Address().Hex(),
+		Amount:       1_000_000,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, trongrid.SignTransaction(tx, other))
+	resp, err := chain.BroadcastTransaction(ctx, tx)
+	require.ErrorIs(t, err, trongrid.ErrBroadcastFailed)
+	assert.Equal(t, "SIGERROR", resp.Code)
+	assert.Zero(t, chain.Pending())
+
+	tx.Signature = nil
+	broadcast(t, chain, tx, owner)
+	resp, err = chain.BroadcastTransaction(ctx, tx)
+	require.ErrorIs(t, err, trongrid.ErrBroadcastFailed)
+	assert.Equal(t, "DUP_TRANSACTION_ERROR", resp.Code)
+
+	pending, err := chain.GetTransactionByID(ctx, &trongrid.GetTransactionRequest{ID: tx.TxID})
+	require.NoError(t, err)
+	assert.Empty(t, pending.Ret)
+	_, err = chain.GetTransactionByID(ctx, &trongrid.GetTransactionRequest{ID: tx.TxID, OnlyConfirmed: true})
+	require.ErrorIs(t, err, trongrid.ErrEmpty)
+
+	_, err = chain.CreateTransaction(ctx, &trongrid.CreateTransactionRequest{
+		OwnerAddress: other.Address().Hex(),
+		ToAddress:    owner.Address().Hex(),
+		Amount:       1,
+	})
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+}
+
+func TestChain_TRC20Revert(t *testing.T) {
+	ctx := context.Background()
+	chain := trongridtest.NewChain()
+
+	owner := mustKey(t)
+	require.NoError(t, chain.Fund(owner.Address().String(), 1_000_000))
+	usdt, err := chain.DeployTRC20(trongrid.Token{Symbol: "USDT", Decimals: 6})
+	require.NoError(t, err)
+
+	id := transfer(t, chain, owner, usdt, mustKey(t).Address(), 1)
+	chain.Produce()
+
+	info, err := chain.GetTransactionInfoByID(ctx, &trongrid.GetTransactionRequest{ID: id})
+	require.NoError(t, err)
+	assert.Equal(t, "REVERT", info.Receipt.Result)
+	assert.Equal(t, "FAILED", info.Result)
+
+	tx, err := chain.GetTransactionByID(ctx, &trongrid.GetTransactionRequest{ID: id, OnlyConfirmed: true})
+	require.NoError(t, err)
+	assert.Equal(t, "REVERT", tx.Ret[0].ContractRet)
+}
+
+func mustKey(t *testing.T) *trongrid.PrivateKey {
+	t.Helper()
+
+	key, err := trongrid.GenerateKey()
+	require.NoError(t, err)
+
+	return key
+}
+
+func transfer(t *testing.T, api trongrid.API, from *trongrid.PrivateKey, token string, to trongrid.Address, amount int64) string {
+	t.Helper()
+
+	parameter, err := trongrid.EncodeTRC20Transfer(to.String(), big.NewInt(amount))
+	require.NoError(t, err)
+
+	resp, err := api.TriggerSmartContract(context.Background(), &trongrid.TriggerSmartContractRequest{
+		OwnerAddress:     from.Address().String(),
+		ContractAddress:  token,
+		FunctionSelector: trongrid.TRC20TransferSelector,
+		Parameter:        parameter,
+		FeeLimit:         10_000_000,
+		Visible:          true,
+	})
+	require.NoError(t, err)
+	broadcast(t, api, resp.Transaction, from)
+
+	return resp.Transaction.TxID
+}
+
+func broadcast(t *testing.T, api trongrid.API, tx *trongrid.Transaction, key *trongrid.PrivateKey) {
+	t.Helper()
+
+	require.NoError(t, trongrid.SignTransaction(tx, key))
+	resp, err := api.BroadcastTransaction(context.Background(), tx)
+	require.NoError(t, err)
+	require.True(t, resp.Result)
+}
+
+func TestChain_TRC10Transfer(t *testing.T) {
+	ctx := context.Background()
+	chain := trongridtest.NewChain()
+
+	owner := mustKey(t)
+	to := mustKey(t)
+	require.NoError(t, chain.Fund(owner.Address().String(), 1_000_000))
+	id, err := chain.IssueAsset(trongrid.AssetIssue{
+		OwnerAddress: owner.Address().String(),
+		Name:         "Legacy",
+		Abbr:         "LGC",
+		TotalSupply:  1_000,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1000001", id)
+
+	tx, err := chain.TransferAsset(ctx, &trongrid.TransferAssetRequest{
+		OwnerAddress: owner.Address().Hex(),
+		ToAddress:    to.Address().Hex(),
+		AssetName:    hex.EncodeToString([]byte(id)),
+		Amount:       400,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, trongrid.ContractTypeTRC10, tx.RawData.Contract[0].Type)
+	broadcast(t, chain, tx, owner)
+	chain.Produce()
+
+	assert.Equal(t, int64(600), chain.AssetBalance(id, owner.Address().String()))
+	account, err := chain.GetAccount(ctx, &trongrid.GetAccountRequest{Address: to.Address().String()})
+	require.NoError(t, err)
+	assert.Equal(t, []trongrid.AccountAsset{{Key: id, Value: 400}}, account.AssetV2)
+
+	_, err = chain.TransferAsset(ctx, &trongrid.TransferAssetRequest{
+		OwnerAddress: owner.Address().String(),
+		ToAddress:    to.Address().String(),
+		AssetName:    id,
+		Amount:       601,
+		Visible:      true,
+	})
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+}
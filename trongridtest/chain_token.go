@@ -0,0 +1,89 @@
+package trongridtest
+
+import (
+	"context"
+	"sort"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+// ListTokenHolders returns the holders of a deployed token, by descending balance unless
+// OrderBy is "balance,asc".
+func (c *Chain) ListTokenHolders(
+	ctx context.Context,
+	req *trongrid.ListTokenHoldersRequest,
+) (*trongrid.ListTokenHoldersResponse, error) {
+	contract, err := requestAddress(ctx, req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	token, ok := c.tokens[contract]
+	if !ok {
+		c.mu.Unlock()
+
+		return nil, trongrid.ErrEmpty
+	}
+
+	holders := []trongrid.TokenHolder{}
+	for a, b := range token.balances {
+		if b.Sign() > 0 {
+			holders = append(holders, trongrid.TokenHolder{
+				Address: a.String(),
+				Balance: trongrid.NewAmount(b, token.token.Decimals),
+			})
+		}
+	}
+	c.mu.Unlock()
+
+	sort.Slice(holders, func(i, j int) bool {
+		if cmp := holders[i].Balance.Cmp(holders[j].Balance); cmp != 0 {
+			return cmp > 0 != (req.OrderBy == "balance,asc")
+		}
+
+		return holders[i].Address < holders[j].Address
+	})
+
+	start, end, next, err := page(req.Fingerprint, int(req.Limit), len(holders))
+	if err != nil {
+		return nil, err
+	}
+
+	return &trongrid.ListTokenHoldersResponse{
+		Token: token.token,
+		Data:  holders[start:end],
+		Meta:  c.meta(end-start, next),
+	}, nil
+}
+
+func (c *Chain) ListTRC20Balances(
+	ctx context.Context,
+	req *trongrid.ListTRC20BalancesRequest,
+) (*trongrid.ListTRC20BalancesResponse, error) {
+	a, err := requestAddress(ctx, req.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	balances := []trongrid.TokenBalance{}
+	for _, token := range c.tokens {
+		if b, ok := token.balances[a]; ok {
+			balances = append(balances, trongrid.TokenBalance{
+				Token:   token.token,
+				Balance: trongrid.NewAmount(b, token.token.Decimals),
+			})
+		}
+	}
+	c.mu.Unlock()
+
+	sort.Slice(balances, func(i, j int) bool { return balances[i].Token.Address < balances[j].Token.Address })
+
+	start, end, next, err := page(req.Fingerprint, int(req.Limit), len(balances))
+	if err != nil {
+		return nil, err
+	}
+
+	return &trongrid.ListTRC20BalancesResponse{Data: balances[start:end], Meta: c.meta(end-start, next)}, nil
+}
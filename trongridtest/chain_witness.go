@@ -0,0 +1,246 @@
+package trongridtest
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+const (
+	// defaultBrokerage is the share of rewards a witness keeps unless set, in percent
+	defaultBrokerage = 20
+	// maintenanceInterval is the time between maintenance periods
+	maintenanceInterval = 6 * time.Hour
+	// withdrawInterval is the time between two reward claims of an account
+	withdrawInterval = 24 * time.Hour
+)
+
+type chainWitness struct {
+	url       string
+	brokerage int64
+}
+
+// AddWitness registers address as a witness candidate, creating the account if needed.
+// brokerage is the percentage of voting rewards it keeps.
+func (c *Chain) AddWitness(address, url string, brokerage int64) error {
+	a, err := trongrid.ParseAddress(address)
+	if err != nil {
+		return err
+	}
+	if brokerage < 0 || brokerage > 100 {
+		return fmt.Errorf("%w: brokerage must be between 0 and 100", trongrid.ErrInvalidRequest)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.account(a)
+	c.witnesses[a] = &chainWitness{url: url, brokerage: brokerage}
+
+	return nil
+}
+
+// AddReward credits sun of unclaimed voting rewards to address, as a maintenance period would.
+func (c *Chain) AddReward(address string, sun int64) error {
+	a, err := trongrid.ParseAddress(address)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	acc, ok := c.accounts[a]
+	if !ok {
+		return fmt.Errorf("%w: account %s does not exist", trongrid.ErrInvalidRequest, address)
+	}
+	acc.reward += sun
+
+	return nil
+}
+
+// ListWitnesses returns the candidates added with AddWitness by votes. The first 27 produce blocks.
+func (c *Chain) ListWitnesses(ctx context.Context) ([]*trongrid.Witness, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	votes := make(map[string]int64)
+	for _, acc := range c.accounts {
+		for _, v := range acc.votes {
+			votes[v.VoteAddress] += v.VoteCount
+		}
+	}
+
+	witnesses := make([]*trongrid.Witness, 0, len(c.witnesses))
+	for a, w := range c.witnesses {
+		witnesses = append(witnesses, &trongrid.Witness{Address: a.String(), VoteCount: votes[a.String()], URL: w.url})
+	}
+	if len(witnesses) == 0 {
+		return nil, trongrid.ErrEmpty
+	}
+	sort.Slice(witnesses, func(i, j int) bool {
+		if witnesses[i].VoteCount != witnesses[j].VoteCount {
+			return witnesses[i].VoteCount > witnesses[j].VoteCount
+		}
+
+		return witnesses[i].Address < witnesses[j].Address
+	})
+	for i := range witnesses {
+		witnesses[i].IsJobs = i < 27
+	}
+
+	return witnesses, nil
+}
+
+func (c *Chain) GetBrokerage(ctx context.Context, address string) (int64, error) {
+	a, err := requestAddress(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if w, ok := c.witnesses[a]; ok {
+		return w.brokerage, nil
+	}
+
+	return defaultBrokerage, nil
+}
+
+// GetReward returns the rewards added with AddReward and not claimed yet.
+func (c *Chain) GetReward(ctx context.Context, address string) (int64, error) {
+	a, err := requestAddress(ctx, address)
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if acc, ok := c.accounts[a]; ok {
+		return acc.reward, nil
+	}
+
+	return 0, nil
+}
+
+// GetNextMaintenanceTime returns the next multiple of 6 hours since the Unix epoch on the chain clock.
+func (c *Chain) GetNextMaintenanceTime(ctx context.Context) (time.Time, error) {
+	if err := ctx.Err(); err != nil {
+		return time.Time{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.now().Truncate(maintenanceInterval).Add(maintenanceInterval), nil
+}
+
+func (c *Chain) VoteWitnessAccount(
+	ctx context.Context,
+	req *trongrid.VoteWitnessRequest,
+) (*trongrid.Transaction, error) {
+	votes := make([]trongrid.Vote, len(req.Votes))
+	for i, v := range req.Votes {
+		witness, err := trongrid.ParseAddress(v.VoteAddress)
+		if err != nil {
+			return nil, err
+		}
+		votes[i] = trongrid.Vote{VoteAddress: formatAddress(witness, req.Visible), VoteCount: v.VoteCount}
+	}
+
+	return c.buildStake(ctx, trongrid.ContractTypeVote, trongrid.ContractValue{
+		OwnerAddress: req.OwnerAddress,
+		Votes:        votes,
+	}, req.PermissionID, req.Visible)
+}
+
+func (c *Chain) WithdrawBalance(
+	ctx context.Context,
+	req *trongrid.WithdrawBalanceRequest,
+) (*trongrid.Transaction, error) {
+	return c.buildStake(ctx, trongrid.ContractTypeWithdrawBalance, trongrid.ContractValue{
+		OwnerAddress: req.OwnerAddress,
+	}, req.PermissionID, req.Visible)
+}
+
+// checkVote returns why a vote or reward claim cannot be executed, if it cannot. c.mu must be held.
+func (c *Chain) checkVote(
+	owner trongrid.Address,
+	acc *chainAccount,
+	typ string,
+	v *trongrid.ContractValue,
+	now int64,
+) string {
+	if typ == trongrid.ContractTypeWithdrawBalance {
+		switch {
+		case acc.reward <= 0:
+			return "witnessAccount does not have any reward"
+		case acc.withdrawn != 0 && now-acc.withdrawn < withdrawInterval.Milliseconds():
+			return fmt.Sprintf("The last withdraw time is %d, less than 24 hours", acc.withdrawn)
+		}
+
+		return ""
+	}
+
+	switch {
+	case len(v.Votes) == 0:
+		return "VoteNumber must more than 0"
+	case len(v.Votes) > trongrid.MaxVoteCount:
+		return fmt.Sprintf("VoteNumber more than maxVoteNumber %d", trongrid.MaxVoteCount)
+	}
+
+	var sum int64
+	for _, vote := range v.Votes {
+		witness, err := trongrid.ParseAddress(vote.VoteAddress)
+		switch {
+		case err != nil:
+			return "Invalid vote address!"
+		case c.witnesses[witness] == nil:
+			return "Witness[" + witness.Hex() + "] not exists"
+		case vote.VoteCount <= 0:
+			return "vote count must be greater than 0"
+		}
+		sum += vote.VoteCount
+	}
+	if power := c.tronPower(owner, acc); sum > power {
+		return fmt.Sprintf("The total number of votes[%d] is greater than the tronPower[%d]", sum, power)
+	}
+
+	return ""
+}
+
+// vote applies a vote or reward claim checked by checkVote. c.mu must be held.
+func (acc *chainAccount) vote(typ string, v *trongrid.ContractValue, now int64) {
+	if typ == trongrid.ContractTypeWithdrawBalance {
+		acc.balance += acc.reward
+		acc.reward = 0
+		acc.withdrawn = now
+
+		return
+	}
+
+	acc.votes = make([]trongrid.Vote, len(v.Votes))
+	for i, vote := range v.Votes {
+		witness, _ := trongrid.ParseAddress(vote.VoteAddress)
+		acc.votes[i] = trongrid.Vote{VoteAddress: witness.String(), VoteCount: vote.VoteCount}
+	}
+}
+
+// voted returns the TRON Power used by the votes of acc.
+func (acc *chainAccount) voted() int64 {
+	var sum int64
+	for _, v := range acc.votes {
+		sum += v.VoteCount
+	}
+
+	return sum
+}
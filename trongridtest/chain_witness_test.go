@@ -0,0 +1,102 @@
+package trongridtest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+func TestChain_VoteAndWithdrawBalance(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 5, 1, 7, 30, 0, 0, time.UTC)
+	chain := trongridtest.NewChain(trongridtest.WithClock(func() time.Time { return now }))
+
+	var api trongrid.API = chain
+
+	voter := mustKey(t)
+	sr := mustKey(t)
+	candidate := mustKey(t)
+	require.NoError(t, chain.Fund(voter.Address().String(), 1_000_000_000))
+	require.NoError(t, chain.Freeze(voter.Address().String(), trongrid.ResourceEnergy, 500_000_000))
+	require.NoError(t, chain.AddWitness(sr.Address().String(), "https://sr.example.com", 10))
+	require.NoError(t, chain.AddWitness(candidate.Address().String(), "https://candidate.example.com", 0))
+
+	// votes are limited by TRON Power, one vote per staked TRX
+	_, err := api.VoteWitnessAccount(ctx, &trongrid.VoteWitnessRequest{
+		OwnerAddress: voter.Address().String(),
+		Votes:        []trongrid.Vote{{VoteAddress: sr.Address().String(), VoteCount: 501}},
+	})
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+
+	tx, err := api.VoteWitnessAccount(ctx, &trongrid.VoteWitnessRequest{
+		OwnerAddress: voter.Address().String(),
+		Votes: []trongrid.Vote{
+			{VoteAddress: sr.Address().String(), VoteCount: 400},
+			{VoteAddress: candidate.Address().String(), VoteCount: 100},
+		},
+		Visible: true,
+	})
+	require.NoError(t, err)
+	broadcast(t, api, tx, voter)
+	chain.Produce()
+
+	witnesses, err := api.ListWitnesses(ctx)
+	require.NoError(t, err)
+	require.Len(t, witnesses, 2)
+	assert.Equal(t, sr.Address().String(), witnesses[0].Address)
+	assert.Equal(t, int64(400), witnesses[0].VoteCount)
+
+	resources, err := api.GetAccountResource(ctx, voter.Address().String())
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), resources.TronPowerUsed)
+
+	brokerage, err := api.GetBrokerage(ctx, sr.Address().String())
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), brokerage)
+
+	next, err := api.GetNextMaintenanceTime(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), next.UTC())
+
+	// rewards can be claimed once a day
+	_, err = api.WithdrawBalance(ctx, &trongrid.WithdrawBalanceRequest{OwnerAddress: voter.Address().String()})
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+
+	require.NoError(t, chain.AddReward(voter.Address().String(), 2_500_000))
+	reward, err := api.GetReward(ctx, voter.Address().String())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2_500_000), reward)
+
+	tx, err = api.WithdrawBalance(ctx, &trongrid.WithdrawBalanceRequest{OwnerAddress: voter.Address().String()})
+	require.NoError(t, err)
+	broadcast(t, api, tx, voter)
+	chain.Produce()
+	assert.Equal(t, int64(502_500_000), chain.Balance(voter.Address().String()))
+
+	require.NoError(t, chain.AddReward(voter.Address().String(), 1_000_000))
+	_, err = api.WithdrawBalance(ctx, &trongrid.WithdrawBalanceRequest{OwnerAddress: voter.Address().String()})
+	require.ErrorIs(t, err, trongrid.ErrInvalidRequest)
+	now = now.Add(24 * time.Hour)
+	_, err = api.WithdrawBalance(ctx, &trongrid.WithdrawBalanceRequest{OwnerAddress: voter.Address().String()})
+	require.NoError(t, err)
+
+	// unstaking below the votes cancels them
+	tx, err = api.UnfreezeBalanceV2(ctx, &trongrid.UnfreezeBalanceV2Request{
+		OwnerAddress:    voter.Address().String(),
+		UnfreezeBalance: 100_000_000,
+		Resource:        trongrid.ResourceEnergy,
+	})
+	require.NoError(t, err)
+	broadcast(t, api, tx, voter)
+	chain.Produce()
+
+	account, err := api.GetAccount(ctx, &trongrid.GetAccountRequest{Address: voter.Address().String()})
+	require.NoError(t, err)
+	assert.Empty(t, account.Votes)
+}
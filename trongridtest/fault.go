@@ -0,0 +1,102 @@
+package trongridtest
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Fault makes the server misbehave for matching requests.
+type Fault struct {
+	// Path matches the request path; a trailing "*" matches a prefix and "" matches every path
+	Path string
+	// Status is written instead of the fixture response, e.g. 429 or 503
+	Status int
+	// RetryAfter is sent as the Retry-After header
+	RetryAfter string
+	// Body replaces the default error body
+	Body string
+	// Malformed writes a truncated JSON body with a 200 status
+	Malformed bool
+	// Latency delays the response
+	Latency time.Duration
+	// Times limits the number of affected requests; 0 affects all of them
+	Times int
+}
+
+// Inject adds f to the faults applied to subsequent requests; the first matching fault wins.
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &f)
+}
+
+// fault returns the fault for path and consumes one of its uses. s.mu must be held.
+func (s *Server) fault(path string) *Fault {
+	for i, f := range s.faults {
+		if !f.matches(path) {
+			continue
+		}
+
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+
+		return f
+	}
+
+	return nil
+}
+
+func (f *Fault) matches(path string) bool {
+	switch {
+	case f.Path == "":
+		return true
+	case strings.HasSuffix(f.Path, "*"):
+		return strings.HasPrefix(path, strings.TrimSuffix(f.Path, "*"))
+	default:
+		return f.Path == path
+	}
+}
+
+// apply writes the fault and reports whether the response was written.
+func (f *Fault) apply(w http.ResponseWriter, r *http.Request) bool {
+	if f.Latency > 0 {
+		t := time.NewTimer(f.Latency)
+		select {
+		case <-r.Context().Done():
+			t.Stop()
+
+			return true
+		case <-t.C:
+		}
+	}
+
+	switch {
+	case f.Malformed:
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"success":true,"data":[{"txID":`))
+
+		return true
+	case f.Status != 0:
+		if f.RetryAfter != "" {
+			w.Header().Set("Retry-After", f.RetryAfter)
+		}
+		if f.Body != "" {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(f.Status)
+			_, _ = w.Write([]byte(f.Body))
+
+			return true
+		}
+		writeError(w, f.Status, http.StatusText(f.Status))
+
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,130 @@
+package trongridtest
+
+import (
+	"math/big"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+// AddAccount serves account under its address.
+func (s *Server) AddAccount(account *trongrid.Account) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.accounts[account.Address] = account
+}
+
+// AddAccountResource serves the resources of address from /wallet/getaccountresource.
+func (s *Server) AddAccountResource(address string, resources *trongrid.AccountResourceMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.resources[address] = resources
+}
+
+// AddTransaction lists tx in the history of address and serves it by ID.
+func (s *Server) AddTransaction(address string, tx *trongrid.Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.transactions[address] = append(s.transactions[address], tx)
+	s.txByID[tx.TxID] = tx
+}
+
+// AddInternalTransfer lists transfer in the transaction history of its sender and recipient,
+// as a row of its own like TronGrid does.
+func (s *Server) AddInternalTransfer(transfer trongrid.InternalTransfer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	from := trongrid.MustParseAddress(transfer.InternalTransaction.CallerAddress).String()
+	to := trongrid.MustParseAddress(transfer.InternalTransaction.TransferToAddress).String()
+	s.internal[from] = append(s.internal[from], transfer)
+	if to != from {
+		s.internal[to] = append(s.internal[to], transfer)
+	}
+}
+
+// AddTRC20Transfer lists transfer in the TRC20 history of its sender and recipient.
+func (s *Server) AddTRC20Transfer(transfer trongrid.TRC20Transaction) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.trc20[transfer.From] = append(s.trc20[transfer.From], transfer)
+	if transfer.To != transfer.From {
+		s.trc20[transfer.To] = append(s.trc20[transfer.To], transfer)
+	}
+}
+
+// AddTransactionInfo serves the receipt of a transaction.
+func (s *Server) AddTransactionInfo(info *trongrid.TransactionInfo) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.txInfo[info.ID] = info
+}
+
+// AddEvent serves event by its transaction and contract.
+func (s *Server) AddEvent(event *trongrid.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.txEvents[event.TransactionID] = append(s.txEvents[event.TransactionID], event)
+	s.events[event.ContractAddress] = append(s.events[event.ContractAddress], event)
+}
+
+// AddBlock serves block by number and its transactions by ID. The latest block added is the now block.
+func (s *Server) AddBlock(block *trongrid.Block) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.blocks[block.BlockHeader.RawData.Number] = block
+	for _, tx := range block.Transactions {
+		s.txByID[tx.TxID] = tx
+	}
+}
+
+// SetSolidified sets the highest block number served by the solidity endpoints.
+// By default every block is considered solidified.
+func (s *Server) SetSolidified(num int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.solidified = num
+}
+
+// AddContract serves contract by its address.
+func (s *Server) AddContract(contract *trongrid.SmartContract) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.contracts[contract.ContractAddress] = contract
+}
+
+// AddAsset serves a TRC10 asset by ID, issuer and name.
+func (s *Server) AddAsset(asset *trongrid.AssetIssue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.assets[asset.ID] = asset
+}
+
+// AddToken answers the name(), symbol() and decimals() constant calls of a TRC20 token.
+func (s *Server) AddToken(token trongrid.Token) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[token.Address] = token
+}
+
+// SetTokenBalance sets the balance of address in the token at contract, in base units.
+// It is listed both in the token holders and in the account TRC20 balances.
+func (s *Server) SetTokenBalance(contract, address string, balance *big.Int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.balances[contract] == nil {
+		s.balances[contract] = make(map[string]*big.Int)
+	}
+	s.balances[contract][address] = balance
+}
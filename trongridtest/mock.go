@@ -0,0 +1,840 @@
+package trongridtest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+// ErrUnexpectedCall is returned by a Mock method that has neither a function nor a fallback.
+var ErrUnexpectedCall = errors.New("trongridtest: unexpected call")
+
+// Call is a method call recorded by a Mock.
+type Call struct {
+	// Method is the API method name, e.g. "GetAccount"
+	Method string
+	// Request is the request argument: a request struct, the transaction for BroadcastTransaction
+	// and the signature queries, the address for GetContract, GetAccountResource, the account queries and rewards,
+	// [2]string{from, to} for GetDelegatedResourceV2 and the onlyConfirmed flag for GetNowBlock.
+	// It is nil for Network, GetChainParameters, the price histories, ListWitnesses
+	// and GetNextMaintenanceTime.
+	Request interface{}
+}
+
+// Mock is a trongrid.API whose responses are programmed per method. Every call is recorded.
+// A method calls its function field when set, then Fallback, and otherwise fails with ErrUnexpectedCall.
+// The zero value is ready to use; set the fields before sharing the mock between goroutines.
+type Mock struct {
+	// Fallback serves methods without a function, e.g. a Chain or a Server client
+	Fallback trongrid.API
+
+	GetAccountFunc            func(context.Context, *trongrid.GetAccountRequest) (*trongrid.Account, error)
+	GetAccountResourceFunc    func(context.Context, string) (*trongrid.AccountResourceMessage, error)
+	ListTransactionsTrc20Func func(context.Context, *trongrid.ListTransactionsRequest) (*trongrid.TRC20Response, error)
+	ListTransactionsFunc      func(
+		context.Context, *trongrid.ListTransactionsRequest,
+	) (*trongrid.ListTransactionsResponse, error)
+	GetTransactionByIDFunc       func(context.Context, *trongrid.GetTransactionRequest) (*trongrid.Transaction, error)
+	GetTransactionInfoByIDFunc   func(context.Context, *trongrid.GetTransactionRequest) (*trongrid.TransactionInfo, error)
+	ListInternalTransactionsFunc func(
+		context.Context, *trongrid.ListTransactionsRequest,
+	) (*trongrid.ListInternalTransactionsResponse, error)
+	GetBlockByNumFunc         func(context.Context, *trongrid.GetBlockRequest) (*trongrid.Block, error)
+	GetNowBlockFunc           func(context.Context, bool) (*trongrid.Block, error)
+	ListTransactionEventsFunc func(
+		context.Context, *trongrid.ListTransactionEventsRequest,
+	) (*trongrid.ListEventsResponse, error)
+	ListContractEventsFunc func(
+		context.Context, *trongrid.ListContractEventsRequest,
+	) (*trongrid.ListEventsResponse, error)
+	GetContractFunc          func(context.Context, string) (*trongrid.SmartContract, error)
+	TriggerSmartContractFunc func(
+		context.Context, *trongrid.TriggerSmartContractRequest,
+	) (*trongrid.TriggerSmartContractResponse, error)
+	TriggerConstantContractFunc func(
+		context.Context, *trongrid.TriggerSmartContractRequest,
+	) (*trongrid.TriggerSmartContractResponse, error)
+	EstimateEnergyFunc     func(context.Context, *trongrid.TriggerSmartContractRequest) (int64, error)
+	GetChainParametersFunc func(context.Context) (*trongrid.ChainParameters, error)
+	GetEnergyPricesFunc    func(context.Context) (trongrid.PriceHistory, error)
+	GetBandwidthPricesFunc func(context.Context) (trongrid.PriceHistory, error)
+	ListAssetsFunc         func(context.Context, *trongrid.ListAssetsRequest) (*trongrid.ListAssetsResponse, error)
+	GetAssetByIDFunc       func(context.Context, *trongrid.GetAssetRequest) (*trongrid.AssetIssue, error)
+	GetAssetsByNameFunc    func(
+		context.Context, *trongrid.GetAssetsByNameRequest,
+	) (*trongrid.ListAssetsResponse, error)
+	GetAssetIssueByIDFunc       func(context.Context, *trongrid.GetAssetRequest) (*trongrid.AssetIssue, error)
+	GetAssetIssueByAccountFunc  func(context.Context, string) ([]*trongrid.AssetIssue, error)
+	GetAssetIssueListByNameFunc func(context.Context, string) ([]*trongrid.AssetIssue, error)
+	ListTokenHoldersFunc        func(
+		context.Context, *trongrid.ListTokenHoldersRequest,
+	) (*trongrid.ListTokenHoldersResponse, error)
+	ListTRC20BalancesFunc func(
+		context.Context, *trongrid.ListTRC20BalancesRequest,
+	) (*trongrid.ListTRC20BalancesResponse, error)
+	TransferAssetFunc        func(context.Context, *trongrid.TransferAssetRequest) (*trongrid.Transaction, error)
+	CreateTransactionFunc    func(context.Context, *trongrid.CreateTransactionRequest) (*trongrid.Transaction, error)
+	BroadcastTransactionFunc func(context.Context, *trongrid.Transaction) (*trongrid.BroadcastResponse, error)
+
+	FreezeBalanceV2Func        func(context.Context, *trongrid.FreezeBalanceV2Request) (*trongrid.Transaction, error)
+	UnfreezeBalanceV2Func      func(context.Context, *trongrid.UnfreezeBalanceV2Request) (*trongrid.Transaction, error)
+	CancelAllUnfreezeV2Func    func(context.Context, *trongrid.CancelAllUnfreezeV2Request) (*trongrid.Transaction, error)
+	DelegateResourceFunc       func(context.Context, *trongrid.DelegateResourceRequest) (*trongrid.Transaction, error)
+	UndelegateResourceFunc     func(context.Context, *trongrid.UndelegateResourceRequest) (*trongrid.Transaction, error)
+	WithdrawExpireUnfreezeFunc func(
+		context.Context, *trongrid.WithdrawExpireUnfreezeRequest,
+	) (*trongrid.Transaction, error)
+	GetDelegatedResourceV2Func             func(context.Context, string, string) ([]*trongrid.DelegatedResource, error)
+	GetDelegatedResourceAccountIndexV2Func func(context.Context, string) (*trongrid.DelegatedResourceAccountIndex, error)
+	GetCanWithdrawUnfreezeAmountFunc       func(context.Context, string, time.Time) (int64, error)
+	GetAvailableUnfreezeCountFunc          func(context.Context, string) (int64, error)
+	GetCanDelegatedMaxSizeFunc             func(context.Context, string, string) (int64, error)
+
+	ListWitnessesFunc          func(context.Context) ([]*trongrid.Witness, error)
+	GetBrokerageFunc           func(context.Context, string) (int64, error)
+	GetRewardFunc              func(context.Context, string) (int64, error)
+	GetNextMaintenanceTimeFunc func(context.Context) (time.Time, error)
+	VoteWitnessAccountFunc     func(context.Context, *trongrid.VoteWitnessRequest) (*trongrid.Transaction, error)
+	WithdrawBalanceFunc        func(context.Context, *trongrid.WithdrawBalanceRequest) (*trongrid.Transaction, error)
+
+	AccountPermissionUpdateFunc func(
+		context.Context, *trongrid.AccountPermissionUpdateRequest,
+	) (*trongrid.Transaction, error)
+	GetSignWeightFunc   func(context.Context, *trongrid.Transaction) (*trongrid.SignWeight, error)
+	GetApprovedListFunc func(context.Context, *trongrid.Transaction) ([]string, error)
+
+	NetworkFunc func() *trongrid.Network
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+var _ trongrid.API = (*Mock)(nil)
+
+// Returns is a convenience for programming a fixed response:
+//
+//	m.GetAccountFunc = trongridtest.Returns[*trongrid.GetAccountRequest](&trongrid.Account{Balance: 1}, nil)
+func Returns[Req, Resp any](resp Resp, err error) func(context.Context, Req) (Resp, error) {
+	return func(context.Context, Req) (Resp, error) {
+		return resp, err
+	}
+}
+
+// Calls returns the recorded calls in order.
+func (m *Mock) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]Call(nil), m.calls...)
+}
+
+// CallsTo returns the recorded calls of method in order.
+func (m *Mock) CallsTo(method string) []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var calls []Call
+	for _, c := range m.calls {
+		if c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+
+	return calls
+}
+
+// Reset forgets the recorded calls.
+func (m *Mock) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = nil
+}
+
+func (m *Mock) record(method string, req interface{}) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls = append(m.calls, Call{Method: method, Request: req})
+}
+
+func (m *Mock) GetAccount(ctx context.Context, req *trongrid.GetAccountRequest) (*trongrid.Account, error) {
+	m.record("GetAccount", req)
+	switch {
+	case m.GetAccountFunc != nil:
+		return m.GetAccountFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.GetAccount(ctx, req)
+	}
+
+	return nil, unexpected("GetAccount")
+}
+
+func (m *Mock) GetAccountResource(ctx context.Context, address string) (*trongrid.AccountResourceMessage, error) {
+	m.record("GetAccountResource", address)
+	switch {
+	case m.GetAccountResourceFunc != nil:
+		return m.GetAccountResourceFunc(ctx, address)
+	case m.Fallback != nil:
+		return m.Fallback.GetAccountResource(ctx, address)
+	}
+
+	return nil, unexpected("GetAccountResource")
+}
+
+func (m *Mock) ListTransactionsTrc20(
+	ctx context.Context,
+	req *trongrid.ListTransactionsRequest,
+) (*trongrid.TRC20Response, error) {
+	m.record("ListTransactionsTrc20", req)
+	switch {
+	case m.ListTransactionsTrc20Func != nil:
+		return m.ListTransactionsTrc20Func(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.ListTransactionsTrc20(ctx, req)
+	}
+
+	return nil, unexpected("ListTransactionsTrc20")
+}
+
+func (m *Mock) ListTransactions(
+	ctx context.Context,
+	req *trongrid.ListTransactionsRequest,
+) (*trongrid.ListTransactionsResponse, error) {
+	m.record("ListTransactions", req)
+	switch {
+	case m.ListTransactionsFunc != nil:
+		return m.ListTransactionsFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.ListTransactions(ctx, req)
+	}
+
+	return nil, unexpected("ListTransactions")
+}
+
+func (m *Mock) ListInternalTransactions(
+	ctx context.Context,
+	req *trongrid.ListTransactionsRequest,
+) (*trongrid.ListInternalTransactionsResponse, error) {
+	m.record("ListInternalTransactions", req)
+	switch {
+	case m.ListInternalTransactionsFunc != nil:
+		return m.ListInternalTransactionsFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.ListInternalTransactions(ctx, req)
+	}
+
+	return nil, unexpected("ListInternalTransactions")
+}
+
+func (m *Mock) GetTransactionByID(
+	ctx context.Context,
+	req *trongrid.GetTransactionRequest,
+) (*trongrid.Transaction, error) {
+	m.record("GetTransactionByID", req)
+	switch {
+	case m.GetTransactionByIDFunc != nil:
+		return m.GetTransactionByIDFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.GetTransactionByID(ctx, req)
+	}
+
+	return nil, unexpected("GetTransactionByID")
+}
+
+func (m *Mock) GetTransactionInfoByID(
+	ctx context.Context,
+	req *trongrid.GetTransactionRequest,
+) (*trongrid.TransactionInfo, error) {
+	m.record("GetTransactionInfoByID", req)
+	switch {
+	case m.GetTransactionInfoByIDFunc != nil:
+		return m.GetTransactionInfoByIDFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.GetTransactionInfoByID(ctx, req)
+	}
+
+	return nil, unexpected("GetTransactionInfoByID")
+}
+
+func (m *Mock) GetBlockByNum(ctx context.Context, req *trongrid.GetBlockRequest) (*trongrid.Block, error) {
+	m.record("GetBlockByNum", req)
+	switch {
+	case m.GetBlockByNumFunc != nil:
+		return m.GetBlockByNumFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.GetBlockByNum(ctx, req)
+	}
+
+	return nil, unexpected("GetBlockByNum")
+}
+
+func (m *Mock) GetNowBlock(ctx context.Context, onlyConfirmed bool) (*trongrid.Block, error) {
+	m.record("GetNowBlock", onlyConfirmed)
+	switch {
+	case m.GetNowBlockFunc != nil:
+		return m.GetNowBlockFunc(ctx, onlyConfirmed)
+	case m.Fallback != nil:
+		return m.Fallback.GetNowBlock(ctx, onlyConfirmed)
+	}
+
+	return nil, unexpected("GetNowBlock")
+}
+
+func (m *Mock) ListTransactionEvents(
+	ctx context.Context,
+	req *trongrid.ListTransactionEventsRequest,
+) (*trongrid.ListEventsResponse, error) {
+	m.record("ListTransactionEvents", req)
+	switch {
+	case m.ListTransactionEventsFunc != nil:
+		return m.ListTransactionEventsFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.ListTransactionEvents(ctx, req)
+	}
+
+	return nil, unexpected("ListTransactionEvents")
+}
+
+func (m *Mock) ListContractEvents(
+	ctx context.Context,
+	req *trongrid.ListContractEventsRequest,
+) (*trongrid.ListEventsResponse, error) {
+	m.record("ListContractEvents", req)
+	switch {
+	case m.ListContractEventsFunc != nil:
+		return m.ListContractEventsFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.ListContractEvents(ctx, req)
+	}
+
+	return nil, unexpected("ListContractEvents")
+}
+
+func (m *Mock) GetContract(ctx context.Context, address string) (*trongrid.SmartContract, error) {
+	m.record("GetContract", address)
+	switch {
+	case m.GetContractFunc != nil:
+		return m.GetContractFunc(ctx, address)
+	case m.Fallback != nil:
+		return m.Fallback.GetContract(ctx, address)
+	}
+
+	return nil, unexpected("GetContract")
+}
+
+func (m *Mock) TriggerSmartContract(
+	ctx context.Context,
+	req *trongrid.TriggerSmartContractRequest,
+) (*trongrid.TriggerSmartContractResponse, error) {
+	m.record("TriggerSmartContract", req)
+	switch {
+	case m.TriggerSmartContractFunc != nil:
+		return m.TriggerSmartContractFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.TriggerSmartContract(ctx, req)
+	}
+
+	return nil, unexpected("TriggerSmartContract")
+}
+
+func (m *Mock) TriggerConstantContract(
+	ctx context.Context,
+	req *trongrid.TriggerSmartContractRequest,
+) (*trongrid.TriggerSmartContractResponse, error) {
+	m.record("TriggerConstantContract", req)
+	switch {
+	case m.TriggerConstantContractFunc != nil:
+		return m.TriggerConstantContractFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.TriggerConstantContract(ctx, req)
+	}
+
+	return nil, unexpected("TriggerConstantContract")
+}
+
+func (m *Mock) EstimateEnergy(ctx context.Context, req *trongrid.TriggerSmartContractRequest) (int64, error) {
+	m.record("EstimateEnergy", req)
+	switch {
+	case m.EstimateEnergyFunc != nil:
+		return m.EstimateEnergyFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.EstimateEnergy(ctx, req)
+	}
+
+	return 0, unexpected("EstimateEnergy")
+}
+
+func (m *Mock) GetChainParameters(ctx context.Context) (*trongrid.ChainParameters, error) {
+	m.record("GetChainParameters", nil)
+	switch {
+	case m.GetChainParametersFunc != nil:
+		return m.GetChainParametersFunc(ctx)
+	case m.Fallback != nil:
+		return m.Fallback.GetChainParameters(ctx)
+	}
+
+	return nil, unexpected("GetChainParameters")
+}
+
+func (m *Mock) GetEnergyPrices(ctx context.Context) (trongrid.PriceHistory, error) {
+	m.record("GetEnergyPrices", nil)
+	switch {
+	case m.GetEnergyPricesFunc != nil:
+		return m.GetEnergyPricesFunc(ctx)
+	case m.Fallback != nil:
+		return m.Fallback.GetEnergyPrices(ctx)
+	}
+
+	return nil, unexpected("GetEnergyPrices")
+}
+
+func (m *Mock) GetBandwidthPrices(ctx context.Context) (trongrid.PriceHistory, error) {
+	m.record("GetBandwidthPrices", nil)
+	switch {
+	case m.GetBandwidthPricesFunc != nil:
+		return m.GetBandwidthPricesFunc(ctx)
+	case m.Fallback != nil:
+		return m.Fallback.GetBandwidthPrices(ctx)
+	}
+
+	return nil, unexpected("GetBandwidthPrices")
+}
+
+func (m *Mock) ListAssets(ctx context.Context, req *trongrid.ListAssetsRequest) (*trongrid.ListAssetsResponse, error) {
+	m.record("ListAssets", req)
+	switch {
+	case m.ListAssetsFunc != nil:
+		return m.ListAssetsFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.ListAssets(ctx, req)
+	}
+
+	return nil, unexpected("ListAssets")
+}
+
+func (m *Mock) GetAssetByID(ctx context.Context, req *trongrid.GetAssetRequest) (*trongrid.AssetIssue, error) {
+	m.record("GetAssetByID", req)
+	switch {
+	case m.GetAssetByIDFunc != nil:
+		return m.GetAssetByIDFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.GetAssetByID(ctx, req)
+	}
+
+	return nil, unexpected("GetAssetByID")
+}
+
+func (m *Mock) GetAssetsByName(
+	ctx context.Context,
+	req *trongrid.GetAssetsByNameRequest,
+) (*trongrid.ListAssetsResponse, error) {
+	m.record("GetAssetsByName", req)
+	switch {
+	case m.GetAssetsByNameFunc != nil:
+		return m.GetAssetsByNameFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.GetAssetsByName(ctx, req)
+	}
+
+	return nil, unexpected("GetAssetsByName")
+}
+
+func (m *Mock) GetAssetIssueByID(ctx context.Context, req *trongrid.GetAssetRequest) (*trongrid.AssetIssue, error) {
+	m.record("GetAssetIssueByID", req)
+	switch {
+	case m.GetAssetIssueByIDFunc != nil:
+		return m.GetAssetIssueByIDFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.GetAssetIssueByID(ctx, req)
+	}
+
+	return nil, unexpected("GetAssetIssueByID")
+}
+
+func (m *Mock) GetAssetIssueByAccount(ctx context.Context, address string) ([]*trongrid.AssetIssue, error) {
+	m.record("GetAssetIssueByAccount", address)
+	switch {
+	case m.GetAssetIssueByAccountFunc != nil:
+		return m.GetAssetIssueByAccountFunc(ctx, address)
+	case m.Fallback != nil:
+		return m.Fallback.GetAssetIssueByAccount(ctx, address)
+	}
+
+	return nil, unexpected("GetAssetIssueByAccount")
+}
+
+func (m *Mock) GetAssetIssueListByName(ctx context.Context, name string) ([]*trongrid.AssetIssue, error) {
+	m.record("GetAssetIssueListByName", name)
+	switch {
+	case m.GetAssetIssueListByNameFunc != nil:
+		return m.GetAssetIssueListByNameFunc(ctx, name)
+	case m.Fallback != nil:
+		return m.Fallback.GetAssetIssueListByName(ctx, name)
+	}
+
+	return nil, unexpected("GetAssetIssueListByName")
+}
+
+func (m *Mock) ListTokenHolders(
+	ctx context.Context,
+	req *trongrid.ListTokenHoldersRequest,
+) (*trongrid.ListTokenHoldersResponse, error) {
+	m.record("ListTokenHolders", req)
+	switch {
+	case m.ListTokenHoldersFunc != nil:
+		return m.ListTokenHoldersFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.ListTokenHolders(ctx, req)
+	}
+
+	return nil, unexpected("ListTokenHolders")
+}
+
+func (m *Mock) ListTRC20Balances(
+	ctx context.Context,
+	req *trongrid.ListTRC20BalancesRequest,
+) (*trongrid.ListTRC20BalancesResponse, error) {
+	m.record("ListTRC20Balances", req)
+	switch {
+	case m.ListTRC20BalancesFunc != nil:
+		return m.ListTRC20BalancesFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.ListTRC20Balances(ctx, req)
+	}
+
+	return nil, unexpected("ListTRC20Balances")
+}
+
+func (m *Mock) TransferAsset(ctx context.Context, req *trongrid.TransferAssetRequest) (*trongrid.Transaction, error) {
+	m.record("TransferAsset", req)
+	switch {
+	case m.TransferAssetFunc != nil:
+		return m.TransferAssetFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.TransferAsset(ctx, req)
+	}
+
+	return nil, unexpected("TransferAsset")
+}
+
+func (m *Mock) CreateTransaction(
+	ctx context.Context,
+	req *trongrid.CreateTransactionRequest,
+) (*trongrid.Transaction, error) {
+	m.record("CreateTransaction", req)
+	switch {
+	case m.CreateTransactionFunc != nil:
+		return m.CreateTransactionFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.CreateTransaction(ctx, req)
+	}
+
+	return nil, unexpected("CreateTransaction")
+}
+
+func (m *Mock) BroadcastTransaction(
+	ctx context.Context,
+	tx *trongrid.Transaction,
+) (*trongrid.BroadcastResponse, error) {
+	m.record("BroadcastTransaction", tx)
+	switch {
+	case m.BroadcastTransactionFunc != nil:
+		return m.BroadcastTransactionFunc(ctx, tx)
+	case m.Fallback != nil:
+		return m.Fallback.BroadcastTransaction(ctx, tx)
+	}
+
+	return nil, unexpected("BroadcastTransaction")
+}
+
+func (m *Mock) FreezeBalanceV2(
+	ctx context.Context,
+	req *trongrid.FreezeBalanceV2Request,
+) (*trongrid.Transaction, error) {
+	m.record("FreezeBalanceV2", req)
+	switch {
+	case m.FreezeBalanceV2Func != nil:
+		return m.FreezeBalanceV2Func(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.FreezeBalanceV2(ctx, req)
+	}
+
+	return nil, unexpected("FreezeBalanceV2")
+}
+
+func (m *Mock) UnfreezeBalanceV2(
+	ctx context.Context,
+	req *trongrid.UnfreezeBalanceV2Request,
+) (*trongrid.Transaction, error) {
+	m.record("UnfreezeBalanceV2", req)
+	switch {
+	case m.UnfreezeBalanceV2Func != nil:
+		return m.UnfreezeBalanceV2Func(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.UnfreezeBalanceV2(ctx, req)
+	}
+
+	return nil, unexpected("UnfreezeBalanceV2")
+}
+
+func (m *Mock) WithdrawExpireUnfreeze(
+	ctx context.Context,
+	req *trongrid.WithdrawExpireUnfreezeRequest,
+) (*trongrid.Transaction, error) {
+	m.record("WithdrawExpireUnfreeze", req)
+	switch {
+	case m.WithdrawExpireUnfreezeFunc != nil:
+		return m.WithdrawExpireUnfreezeFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.WithdrawExpireUnfreeze(ctx, req)
+	}
+
+	return nil, unexpected("WithdrawExpireUnfreeze")
+}
+
+func (m *Mock) CancelAllUnfreezeV2(
+	ctx context.Context,
+	req *trongrid.CancelAllUnfreezeV2Request,
+) (*trongrid.Transaction, error) {
+	m.record("CancelAllUnfreezeV2", req)
+	switch {
+	case m.CancelAllUnfreezeV2Func != nil:
+		return m.CancelAllUnfreezeV2Func(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.CancelAllUnfreezeV2(ctx, req)
+	}
+
+	return nil, unexpected("CancelAllUnfreezeV2")
+}
+
+func (m *Mock) DelegateResource(
+	ctx context.Context,
+	req *trongrid.DelegateResourceRequest,
+) (*trongrid.Transaction, error) {
+	m.record("DelegateResource", req)
+	switch {
+	case m.DelegateResourceFunc != nil:
+		return m.DelegateResourceFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.DelegateResource(ctx, req)
+	}
+
+	return nil, unexpected("DelegateResource")
+}
+
+func (m *Mock) UndelegateResource(
+	ctx context.Context,
+	req *trongrid.UndelegateResourceRequest,
+) (*trongrid.Transaction, error) {
+	m.record("UndelegateResource", req)
+	switch {
+	case m.UndelegateResourceFunc != nil:
+		return m.UndelegateResourceFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.UndelegateResource(ctx, req)
+	}
+
+	return nil, unexpected("UndelegateResource")
+}
+
+func (m *Mock) GetDelegatedResourceV2(ctx context.Context, from, to string) ([]*trongrid.DelegatedResource, error) {
+	m.record("GetDelegatedResourceV2", [2]string{from, to})
+	switch {
+	case m.GetDelegatedResourceV2Func != nil:
+		return m.GetDelegatedResourceV2Func(ctx, from, to)
+	case m.Fallback != nil:
+		return m.Fallback.GetDelegatedResourceV2(ctx, from, to)
+	}
+
+	return nil, unexpected("GetDelegatedResourceV2")
+}
+
+func (m *Mock) GetDelegatedResourceAccountIndexV2(
+	ctx context.Context,
+	address string,
+) (*trongrid.DelegatedResourceAccountIndex, error) {
+	m.record("GetDelegatedResourceAccountIndexV2", address)
+	switch {
+	case m.GetDelegatedResourceAccountIndexV2Func != nil:
+		return m.GetDelegatedResourceAccountIndexV2Func(ctx, address)
+	case m.Fallback != nil:
+		return m.Fallback.GetDelegatedResourceAccountIndexV2(ctx, address)
+	}
+
+	return nil, unexpected("GetDelegatedResourceAccountIndexV2")
+}
+
+func (m *Mock) GetCanWithdrawUnfreezeAmount(ctx context.Context, address string, at time.Time) (int64, error) {
+	m.record("GetCanWithdrawUnfreezeAmount", address)
+	switch {
+	case m.GetCanWithdrawUnfreezeAmountFunc != nil:
+		return m.GetCanWithdrawUnfreezeAmountFunc(ctx, address, at)
+	case m.Fallback != nil:
+		return m.Fallback.GetCanWithdrawUnfreezeAmount(ctx, address, at)
+	}
+
+	return 0, unexpected("GetCanWithdrawUnfreezeAmount")
+}
+
+func (m *Mock) GetAvailableUnfreezeCount(ctx context.Context, address string) (int64, error) {
+	m.record("GetAvailableUnfreezeCount", address)
+	switch {
+	case m.GetAvailableUnfreezeCountFunc != nil:
+		return m.GetAvailableUnfreezeCountFunc(ctx, address)
+	case m.Fallback != nil:
+		return m.Fallback.GetAvailableUnfreezeCount(ctx, address)
+	}
+
+	return 0, unexpected("GetAvailableUnfreezeCount")
+}
+
+func (m *Mock) GetCanDelegatedMaxSize(ctx context.Context, address, resource string) (int64, error) {
+	m.record("GetCanDelegatedMaxSize", address)
+	switch {
+	case m.GetCanDelegatedMaxSizeFunc != nil:
+		return m.GetCanDelegatedMaxSizeFunc(ctx, address, resource)
+	case m.Fallback != nil:
+		return m.Fallback.GetCanDelegatedMaxSize(ctx, address, resource)
+	}
+
+	return 0, unexpected("GetCanDelegatedMaxSize")
+}
+
+func (m *Mock) ListWitnesses(ctx context.Context) ([]*trongrid.Witness, error) {
+	m.record("ListWitnesses", nil)
+	switch {
+	case m.ListWitnessesFunc != nil:
+		return m.ListWitnessesFunc(ctx)
+	case m.Fallback != nil:
+		return m.Fallback.ListWitnesses(ctx)
+	}
+
+	return nil, unexpected("ListWitnesses")
+}
+
+func (m *Mock) GetBrokerage(ctx context.Context, address string) (int64, error) {
+	m.record("GetBrokerage", address)
+	switch {
+	case m.GetBrokerageFunc != nil:
+		return m.GetBrokerageFunc(ctx, address)
+	case m.Fallback != nil:
+		return m.Fallback.GetBrokerage(ctx, address)
+	}
+
+	return 0, unexpected("GetBrokerage")
+}
+
+func (m *Mock) GetReward(ctx context.Context, address string) (int64, error) {
+	m.record("GetReward", address)
+	switch {
+	case m.GetRewardFunc != nil:
+		return m.GetRewardFunc(ctx, address)
+	case m.Fallback != nil:
+		return m.Fallback.GetReward(ctx, address)
+	}
+
+	return 0, unexpected("GetReward")
+}
+
+func (m *Mock) GetNextMaintenanceTime(ctx context.Context) (time.Time, error) {
+	m.record("GetNextMaintenanceTime", nil)
+	switch {
+	case m.GetNextMaintenanceTimeFunc != nil:
+		return m.GetNextMaintenanceTimeFunc(ctx)
+	case m.Fallback != nil:
+		return m.Fallback.GetNextMaintenanceTime(ctx)
+	}
+
+	return time.Time{}, unexpected("GetNextMaintenanceTime")
+}
+
+func (m *Mock) VoteWitnessAccount(
+	ctx context.Context,
+	req *trongrid.VoteWitnessRequest,
+) (*trongrid.Transaction, error) {
+	m.record("VoteWitnessAccount", req)
+	switch {
+	case m.VoteWitnessAccountFunc != nil:
+		return m.VoteWitnessAccountFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.VoteWitnessAccount(ctx, req)
+	}
+
+	return nil, unexpected("VoteWitnessAccount")
+}
+
+func (m *Mock) WithdrawBalance(
+	ctx context.Context,
+	req *trongrid.WithdrawBalanceRequest,
+) (*trongrid.Transaction, error) {
+	m.record("WithdrawBalance", req)
+	switch {
+	case m.WithdrawBalanceFunc != nil:
+		return m.WithdrawBalanceFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.WithdrawBalance(ctx, req)
+	}
+
+	return nil, unexpected("WithdrawBalance")
+}
+
+func (m *Mock) AccountPermissionUpdate(
+	ctx context.Context,
+	req *trongrid.AccountPermissionUpdateRequest,
+) (*trongrid.Transaction, error) {
+	m.record("AccountPermissionUpdate", req)
+	switch {
+	case m.AccountPermissionUpdateFunc != nil:
+		return m.AccountPermissionUpdateFunc(ctx, req)
+	case m.Fallback != nil:
+		return m.Fallback.AccountPermissionUpdate(ctx, req)
+	}
+
+	return nil, unexpected("AccountPermissionUpdate")
+}
+
+func (m *Mock) GetSignWeight(ctx context.Context, tx *trongrid.Transaction) (*trongrid.SignWeight, error) {
+	m.record("GetSignWeight", tx)
+	switch {
+	case m.GetSignWeightFunc != nil:
+		return m.GetSignWeightFunc(ctx, tx)
+	case m.Fallback != nil:
+		return m.Fallback.GetSignWeight(ctx, tx)
+	}
+
+	return nil, unexpected("GetSignWeight")
+}
+
+func (m *Mock) GetApprovedList(ctx context.Context, tx *trongrid.Transaction) ([]string, error) {
+	m.record("GetApprovedList", tx)
+	switch {
+	case m.GetApprovedListFunc != nil:
+		return m.GetApprovedListFunc(ctx, tx)
+	case m.Fallback != nil:
+		return m.Fallback.GetApprovedList(ctx, tx)
+	}
+
+	return nil, unexpected("GetApprovedList")
+}
+
+// Network returns the NetworkFunc result, the fallback network or an empty "mock" network.
+func (m *Mock) Network() *trongrid.Network {
+	m.record("Network", nil)
+	switch {
+	case m.NetworkFunc != nil:
+		return m.NetworkFunc()
+	case m.Fallback != nil:
+		return m.Fallback.Network()
+	}
+
+	return &trongrid.Network{Name: "mock"}
+}
+
+func unexpected(method string) error {
+	return fmt.Errorf("%w: %s", ErrUnexpectedCall, method)
+}
@@ -0,0 +1,61 @@
+package trongridtest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+// balance depends only on the narrow interface it needs.
+func balance(ctx context.Context, r trongrid.AccountReader, address string) (int64, error) {
+	account, err := r.GetAccount(ctx, &trongrid.GetAccountRequest{Address: address})
+	if err != nil {
+		return 0, err
+	}
+
+	return account.Balance, nil
+}
+
+func TestMock(t *testing.T) {
+	ctx := context.Background()
+	m := &trongridtest.Mock{}
+	m.GetAccountFunc = trongridtest.Returns[*trongrid.GetAccountRequest](&trongrid.Account{Balance: 42}, nil)
+
+	b, err := balance(ctx, m, "TPqG9VfqXycvNTaoBunqLWxUi69gnnQ6Fq")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), b)
+
+	_, err = m.GetNowBlock(ctx, true)
+	require.ErrorIs(t, err, trongridtest.ErrUnexpectedCall)
+
+	m.AssertCalled(t, "GetAccount", 1)
+	calls := m.Calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, "TPqG9VfqXycvNTaoBunqLWxUi69gnnQ6Fq", calls[0].Request.(*trongrid.GetAccountRequest).Address)
+	assert.Equal(t, true, calls[1].Request)
+
+	m.Reset()
+	assert.Empty(t, m.Calls())
+}
+
+func TestMock_Fallback(t *testing.T) {
+	ctx := context.Background()
+	chain := trongridtest.NewChain()
+	m := &trongridtest.Mock{Fallback: chain}
+	m.GetNowBlockFunc = func(context.Context, bool) (*trongrid.Block, error) {
+		return nil, trongrid.ErrServerError
+	}
+
+	_, err := m.GetNowBlock(ctx, false)
+	require.ErrorIs(t, err, trongrid.ErrServerError)
+
+	block, err := m.GetBlockByNum(ctx, &trongrid.GetBlockRequest{Num: 0})
+	require.NoError(t, err)
+	assert.Zero(t, block.BlockHeader.RawData.Number)
+	assert.Equal(t, trongridtest.ChainNetwork, m.Network().Name)
+}
@@ -0,0 +1,94 @@
+package trongridtest
+
+import (
+	"context"
+	"sort"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+// defaultChainParameters are served by a Chain and a Server, with mainnet values.
+var defaultChainParameters = map[string]int64{
+	trongrid.ParamMaintenanceTimeInterval:             21_600_000,
+	trongrid.ParamAccountUpgradeCost:                  9_999_000_000,
+	trongrid.ParamCreateAccountFee:                    100_000,
+	trongrid.ParamTransactionFee:                      1_000,
+	trongrid.ParamAssetIssueFee:                       1_024_000_000,
+	trongrid.ParamWitnessPayPerBlock:                  8_000_000,
+	trongrid.ParamWitness127PayPerBlock:               128_000_000,
+	trongrid.ParamCreateNewAccountFeeInSystemContract: 1_000_000,
+	trongrid.ParamCreateNewAccountBandwidthRate:       1,
+	trongrid.ParamEnergyFee:                           420,
+	trongrid.ParamMaxFeeLimit:                         15_000_000_000,
+	trongrid.ParamTotalEnergyCurrentLimit:             totalEnergyLimit,
+	trongrid.ParamFreeNetLimit:                        freeNetLimit,
+	trongrid.ParamTotalNetLimit:                       totalNetLimit,
+	trongrid.ParamMemoFee:                             1_000_000,
+	trongrid.ParamUnfreezeDelayDays:                   14,
+	trongrid.ParamUpdateAccountPermissionFee:          100_000_000,
+	trongrid.ParamMultiSignFee:                        1_000_000,
+}
+
+// Price histories served by a Chain and a Server, ending in the default prices.
+const (
+	defaultEnergyPrices = "0:100,1575871200000:10,1606537680000:40,1614238080000:140," +
+		"1635739080000:280,1681895880000:420"
+	defaultBandwidthPrices = "0:10,1606537680000:40,1614238080000:140,1626501000000:1000"
+)
+
+// chainParameters returns params in the wallet API shape, ordered by key.
+func chainParameters(params map[string]int64) *trongrid.ChainParameters {
+	list := make([]trongrid.ChainParameter, 0, len(params))
+	for key, value := range params {
+		list = append(list, trongrid.ChainParameter{Key: key, Value: value})
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+
+	return trongrid.NewChainParameters(list...)
+}
+
+// SetChainParameter overrides a parameter served by /wallet/getchainparameters.
+func (s *Server) SetChainParameter(key string, value int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.params[key] = value
+}
+
+// SetPrices overrides the "timestamp:price" histories served by /wallet/getenergyprices
+// and /wallet/getbandwidthprices. An empty history is served as such.
+func (s *Server) SetPrices(energy, bandwidth string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.prices["getenergyprices"] = energy
+	s.prices["getbandwidthprices"] = bandwidth
+}
+
+// GetChainParameters returns mainnet resource prices. They are informational:
+// the fees burned by the chain are set with WithFees.
+func (c *Chain) GetChainParameters(ctx context.Context) (*trongrid.ChainParameters, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return chainParameters(defaultChainParameters), nil
+}
+
+// GetEnergyPrices returns a mainnet-like history of the price of energy.
+func (c *Chain) GetEnergyPrices(ctx context.Context) (trongrid.PriceHistory, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return trongrid.ParsePriceHistory(defaultEnergyPrices)
+}
+
+// GetBandwidthPrices returns a mainnet-like history of the price of bandwidth.
+func (c *Chain) GetBandwidthPrices(ctx context.Context) (trongrid.PriceHistory, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return trongrid.ParsePriceHistory(defaultBandwidthPrices)
+}
@@ -0,0 +1,217 @@
+// Package trongridtest provides a fake TronGrid server for tests that must run without network access.
+package trongridtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+// Request is a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// Server is an httptest based fake of the TronGrid v1 and wallet APIs serving in-memory fixtures.
+type Server struct {
+	// URL is the base URI of the server, suitable for trongrid.WithURI
+	URL string
+
+	srv *httptest.Server
+	mu  sync.Mutex
+
+	accounts     map[string]*trongrid.Account
+	resources    map[string]*trongrid.AccountResourceMessage
+	params       map[string]int64
+	prices       map[string]string
+	transactions map[string][]*trongrid.Transaction
+	internal     map[string][]trongrid.InternalTransfer
+	trc20        map[string][]trongrid.TRC20Transaction
+	txByID       map[string]*trongrid.Transaction
+	txInfo       map[string]*trongrid.TransactionInfo
+	txEvents     map[string][]*trongrid.Event
+	events       map[string][]*trongrid.Event
+	blocks       map[int64]*trongrid.Block
+	contracts    map[string]*trongrid.SmartContract
+	assets       map[string]*trongrid.AssetIssue
+	tokens       map[string]trongrid.Token
+	balances     map[string]map[string]*big.Int
+	solidified   int64
+	handlers     map[string]http.HandlerFunc
+	faults       []*Fault
+	requests     []Request
+}
+
+// NewServer starts a server without fixtures. Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		accounts:     make(map[string]*trongrid.Account),
+		resources:    make(map[string]*trongrid.AccountResourceMessage),
+		params:       make(map[string]int64, len(defaultChainParameters)),
+		prices:       map[string]string{"getenergyprices": defaultEnergyPrices, "getbandwidthprices": defaultBandwidthPrices},
+		transactions: make(map[string][]*trongrid.Transaction),
+		internal:     make(map[string][]trongrid.InternalTransfer),
+		trc20:        make(map[string][]trongrid.TRC20Transaction),
+		txByID:       make(map[string]*trongrid.Transaction),
+		txInfo:       make(map[string]*trongrid.TransactionInfo),
+		txEvents:     make(map[string][]*trongrid.Event),
+		events:       make(map[string][]*trongrid.Event),
+		blocks:       make(map[int64]*trongrid.Block),
+		contracts:    make(map[string]*trongrid.SmartContract),
+		assets:       make(map[string]*trongrid.AssetIssue),
+		tokens:       make(map[string]trongrid.Token),
+		balances:     make(map[string]map[string]*big.Int),
+		solidified:   -1,
+		handlers:     make(map[string]http.HandlerFunc),
+	}
+	for key, value := range defaultChainParameters {
+		s.params[key] = value
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+
+	return s
+}
+
+// Close shuts the server down.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns a client for the server without rate limiting. opts are applied last.
+func (s *Server) Client(opts ...trongrid.Option) trongrid.API {
+	return trongrid.NewAPI(append([]trongrid.Option{
+		trongrid.WithURI(s.URL),
+		trongrid.WithRateLimiter(nil),
+		trongrid.WithRetryPolicy(trongrid.RetryPolicy{MaxRetries: 3, BaseDelay: time.Millisecond}),
+	}, opts...)...)
+}
+
+// Handle serves path with h instead of the built-in handlers, e.g. for endpoints the fake does not know.
+func (s *Server) Handle(path string, h http.HandlerFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.handlers[path] = h
+}
+
+// Requests returns every request received so far.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Request(nil), s.requests...)
+}
+
+// RequestsTo returns the requests received for path.
+func (s *Server) RequestsTo(path string) []Request {
+	var reqs []Request
+	for _, r := range s.Requests() {
+		if r.Path == path {
+			reqs = append(reqs, r)
+		}
+	}
+
+	return reqs
+}
+
+// Reset forgets the recorded requests and pending faults, but keeps the fixtures.
+func (s *Server) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.requests = nil
+	s.faults = nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	})
+	fault := s.fault(r.URL.Path)
+	handler := s.handlers[r.URL.Path]
+	s.mu.Unlock()
+
+	if fault != nil && fault.apply(w, r) {
+		return
+	}
+
+	if handler != nil {
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		handler(w, r)
+
+		return
+	}
+
+	s.route(w, r, body)
+}
+
+func (s *Server) route(w http.ResponseWriter, r *http.Request, body []byte) {
+	path := r.URL.Path
+	if strings.HasPrefix(path, "/wallet/") || strings.HasPrefix(path, "/walletsolidity/") {
+		s.serveWallet(w, path, body)
+
+		return
+	}
+
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	switch {
+	case len(parts) == 3 && parts[0] == "v1" && parts[1] == "accounts":
+		s.serveAccount(w, r, parts[2])
+	case len(parts) == 4 && parts[0] == "v1" && parts[1] == "accounts" && parts[3] == "transactions":
+		s.serveTransactions(w, r, parts[2])
+	case len(parts) == 5 && parts[0] == "v1" && parts[1] == "accounts" && parts[3] == "transactions" &&
+		parts[4] == "trc20":
+		s.serveTRC20(w, r, parts[2])
+	case len(parts) == 4 && parts[0] == "v1" && parts[1] == "transactions" && parts[3] == "events":
+		s.serveTransactionEvents(w, r, parts[2])
+	case len(parts) == 4 && parts[0] == "v1" && parts[1] == "contracts" && parts[3] == "events":
+		s.serveContractEvents(w, r, parts[2])
+	case len(parts) == 4 && parts[0] == "v1" && parts[1] == "contracts" && parts[3] == "tokens":
+		s.serveTokenHolders(w, r, parts[2])
+	case len(parts) == 5 && parts[0] == "v1" && parts[1] == "accounts" && parts[3] == "trc20" && parts[4] == "balance":
+		s.serveTRC20Balances(w, r, parts[2])
+	case len(parts) == 2 && parts[0] == "v1" && parts[1] == "assets":
+		s.serveAssets(w, r, func(*trongrid.AssetIssue) bool { return true })
+	case len(parts) == 3 && parts[0] == "v1" && parts[1] == "assets":
+		s.serveAssets(w, r, func(a *trongrid.AssetIssue) bool { return a.ID == parts[2] || a.OwnerAddress == parts[2] })
+	case len(parts) == 4 && parts[0] == "v1" && parts[1] == "assets" && parts[3] == "list":
+		s.serveAssets(w, r, func(a *trongrid.AssetIssue) bool { return a.Name == parts[2] })
+	default:
+		writeError(w, http.StatusNotFound, "not found: "+path)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]interface{}{
+		"success":    false,
+		"error":      message,
+		"statusCode": status,
+	})
+}
@@ -0,0 +1,118 @@
+package trongridtest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+	"github.com/eliohn/go-trongrid/trongridtest"
+)
+
+const address = "TJRabPrwbZy45sbavfcjinPJC18kjpRTv8"
+
+func TestServer_Pagination(t *testing.T) {
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	for i := 0; i < 5; i++ {
+		srv.AddTRC20Transfer(trongrid.TRC20Transaction{
+			TransactionID:  string(rune('a' + i)),
+			BlockTimestamp: int64(1_700_000_000_000 + i),
+			From:           "TDuzLK9vBRuSdhLovyy5gCD2bGp4fjecHk",
+			To:             address,
+			Type:           "Transfer",
+			Value:          "1000000",
+		})
+	}
+
+	api := srv.Client()
+	ctx := context.Background()
+
+	var ids []string
+	req := &trongrid.ListTransactionsRequest{Address: address, Limit: 2, OnlyTo: true}
+	for {
+		resp, err := api.ListTransactionsTrc20(ctx, req)
+		require.NoError(t, err)
+		for _, tx := range resp.Data {
+			ids = append(ids, tx.TransactionID)
+		}
+		if resp.Meta.Fingerprint == "" {
+			break
+		}
+		req.Fingerprint = resp.Meta.Fingerprint
+	}
+
+	assert.Equal(t, []string{"e", "d", "c", "b", "a"}, ids)
+	srv.AssertRequestCount(t, "/v1/accounts/"+address+"/transactions/trc20", 3)
+	srv.AssertQuery(t, "/v1/accounts/"+address+"/transactions/trc20", "only_to", "true")
+}
+
+func TestServer_Faults(t *testing.T) {
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	srv.AddAccount(&trongrid.Account{Address: address, Balance: 42})
+	api := srv.Client()
+	ctx := context.Background()
+
+	srv.Inject(trongridtest.Fault{Path: "/v1/accounts/*", Status: http.StatusTooManyRequests, RetryAfter: "0", Times: 1})
+	account, err := api.GetAccount(ctx, &trongrid.GetAccountRequest{Address: address})
+	require.NoError(t, err)
+	assert.EqualValues(t, 42, account.Balance)
+	srv.AssertRequestCount(t, "/v1/accounts/"+address, 2)
+
+	srv.Reset()
+	srv.Inject(trongridtest.Fault{Status: http.StatusServiceUnavailable})
+	_, err = api.GetAccount(ctx, &trongrid.GetAccountRequest{Address: address})
+	require.ErrorIs(t, err, trongrid.ErrServerError)
+
+	srv.Reset()
+	srv.Inject(trongridtest.Fault{Malformed: true, Times: 1})
+	_, err = api.GetAccount(ctx, &trongrid.GetAccountRequest{Address: address})
+	require.ErrorIs(t, err, trongrid.ErrEmpty)
+
+	srv.Reset()
+	srv.Inject(trongridtest.Fault{Latency: time.Second})
+	ctx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = api.GetAccount(ctx, &trongrid.GetAccountRequest{Address: address})
+	require.Error(t, err)
+}
+
+func TestServer_Wallet(t *testing.T) {
+	srv := trongridtest.NewServer()
+	defer srv.Close()
+
+	tx := &trongrid.Transaction{TxID: "ab12", BlockNumber: 11}
+	block := &trongrid.Block{BlockID: "0b", Transactions: []*trongrid.Transaction{tx}}
+	block.BlockHeader.RawData.Number = 11
+	srv.AddBlock(block)
+	srv.AddTransactionInfo(&trongrid.TransactionInfo{ID: "ab12", BlockNumber: 11, Fee: 345000})
+	srv.SetSolidified(10)
+
+	api := srv.Client()
+	ctx := context.Background()
+
+	got, err := api.GetTransactionByID(ctx, &trongrid.GetTransactionRequest{ID: "ab12"})
+	require.NoError(t, err)
+	assert.Equal(t, 11, got.BlockNumber)
+
+	_, err = api.GetTransactionInfoByID(ctx, &trongrid.GetTransactionRequest{ID: "ab12", OnlyConfirmed: true})
+	require.ErrorIs(t, err, trongrid.ErrEmpty)
+
+	srv.SetSolidified(11)
+	info, err := api.GetTransactionInfoByID(ctx, &trongrid.GetTransactionRequest{ID: "ab12", OnlyConfirmed: true})
+	require.NoError(t, err)
+	assert.EqualValues(t, 345000, info.Fee)
+
+	now, err := api.GetNowBlock(ctx, false)
+	require.NoError(t, err)
+	assert.Equal(t, "0b", now.BlockID)
+
+	srv.AssertRequested(t, http.MethodPost, "/walletsolidity/gettransactioninfobyid")
+}
@@ -0,0 +1,411 @@
+package trongridtest
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+const (
+	defaultLimit = 20
+	maxLimit     = 200
+	// timestampLayout matches the layout the client encodes timestamps with
+	timestampLayout = "2006-01-02T15:04:05"
+)
+
+func (s *Server) serveAccount(w http.ResponseWriter, _ *http.Request, address string) {
+	s.mu.Lock()
+	account, ok := s.accounts[address]
+	s.mu.Unlock()
+
+	data := []*trongrid.Account{}
+	if ok {
+		data = append(data, account)
+	}
+
+	writeJSON(w, http.StatusOK, &trongrid.AccountResponse{
+		Data:    data,
+		Success: true,
+		Meta:    &trongrid.Meta{At: time.Now().UnixMilli(), PageSize: int32(len(data))},
+	})
+}
+
+// v1InternalTransaction is an internal transaction as a row of an account's transaction history.
+type v1InternalTransaction struct {
+	InternalTxID   string `json:"internal_tx_id"`
+	TxID           string `json:"tx_id"`
+	BlockTimestamp int64  `json:"block_timestamp"`
+	FromAddress    string `json:"from_address"`
+	ToAddress      string `json:"to_address"`
+	Data           struct {
+		Note      string           `json:"note"`
+		Rejected  bool             `json:"rejected"`
+		CallValue map[string]int64 `json:"call_value"`
+	} `json:"data"`
+}
+
+func newV1InternalTransaction(t trongrid.InternalTransfer) *v1InternalTransaction {
+	row := &v1InternalTransaction{
+		InternalTxID:   t.InternalTransaction.Hash,
+		TxID:           t.TransactionID,
+		BlockTimestamp: t.BlockTimestamp,
+		FromAddress:    t.InternalTransaction.CallerAddress,
+		ToAddress:      t.InternalTransaction.TransferToAddress,
+	}
+	row.Data.Note = t.InternalTransaction.Note
+	row.Data.Rejected = t.InternalTransaction.Rejected
+	row.Data.CallValue = make(map[string]int64, len(t.InternalTransaction.CallValueInfo))
+	for _, v := range t.InternalTransaction.CallValueInfo {
+		token := v.TokenID
+		if token == "" {
+			token = "_"
+		}
+		row.Data.CallValue[token] += v.CallValue
+	}
+
+	return row
+}
+
+// serveTransactions lists the transactions of an account followed by its internal transactions,
+// which TronGrid returns as rows of their own.
+func (s *Server) serveTransactions(w http.ResponseWriter, r *http.Request, address string) {
+	q := r.URL.Query()
+	minTs, maxTs := timestampRange(q.Get("min_timestamp"), q.Get("max_timestamp"))
+
+	type row struct {
+		timestamp int64
+		data      interface{}
+	}
+
+	s.mu.Lock()
+	var rows []row
+	for _, tx := range s.transactions[address] {
+		if tx.BlockTimestamp >= minTs && tx.BlockTimestamp <= maxTs {
+			rows = append(rows, row{tx.BlockTimestamp, tx})
+		}
+	}
+	for _, t := range s.internal[address] {
+		if t.BlockTimestamp >= minTs && t.BlockTimestamp <= maxTs {
+			rows = append(rows, row{t.BlockTimestamp, newV1InternalTransaction(t)})
+		}
+	}
+	s.mu.Unlock()
+
+	asc := q.Get("order_by") == trongrid.OrderByTimestampAsc
+	sort.SliceStable(rows, func(i, j int) bool {
+		if asc {
+			return rows[i].timestamp < rows[j].timestamp
+		}
+
+		return rows[i].timestamp > rows[j].timestamp
+	})
+
+	start, end, meta, err := paginate(r, len(rows))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	data := make([]interface{}, 0, end-start)
+	for _, v := range rows[start:end] {
+		data = append(data, v.data)
+	}
+	writeJSON(w, http.StatusOK, &struct {
+		Meta    *trongrid.Meta `json:"meta"`
+		Data    []interface{}  `json:"data"`
+		Success bool           `json:"success"`
+	}{Meta: meta, Data: data, Success: true})
+}
+
+func (s *Server) serveTRC20(w http.ResponseWriter, r *http.Request, address string) {
+	q := r.URL.Query()
+	minTs, maxTs := timestampRange(q.Get("min_timestamp"), q.Get("max_timestamp"))
+	onlyFrom := q.Get("only_from") == "true"
+	onlyTo := q.Get("only_to") == "true"
+	contract := q.Get("contract_address")
+
+	s.mu.Lock()
+	transfers := []trongrid.TRC20Transaction{}
+	for _, t := range s.trc20[address] {
+		switch {
+		case t.BlockTimestamp < minTs || t.BlockTimestamp > maxTs:
+		case onlyFrom && t.From != address:
+		case onlyTo && t.To != address:
+		case contract != "" && t.TokenInfo.Address != contract:
+		default:
+			transfers = append(transfers, t)
+		}
+	}
+	s.mu.Unlock()
+
+	asc := q.Get("order_by") == trongrid.OrderByTimestampAsc
+	sort.SliceStable(transfers, func(i, j int) bool {
+		if asc {
+			return transfers[i].BlockTimestamp < transfers[j].BlockTimestamp
+		}
+
+		return transfers[i].BlockTimestamp > transfers[j].BlockTimestamp
+	})
+
+	start, end, meta, err := paginate(r, len(transfers))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &trongrid.TRC20Response{
+		Data:    transfers[start:end],
+		Success: true,
+		Meta:    *meta,
+	})
+}
+
+func (s *Server) serveTransactionEvents(w http.ResponseWriter, _ *http.Request, id string) {
+	s.mu.Lock()
+	events := append([]*trongrid.Event{}, s.txEvents[id]...)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, &trongrid.ListEventsResponse{
+		Data:    events,
+		Success: true,
+		Meta:    &trongrid.Meta{At: time.Now().UnixMilli(), PageSize: int32(len(events))},
+	})
+}
+
+func (s *Server) serveContractEvents(w http.ResponseWriter, r *http.Request, address string) {
+	q := r.URL.Query()
+	minTs, maxTs := timestampRange(q.Get("min_block_timestamp"), q.Get("max_block_timestamp"))
+	name := q.Get("event_name")
+	block, _ := strconv.ParseInt(q.Get("block_number"), 10, 64)
+
+	s.mu.Lock()
+	events := []*trongrid.Event{}
+	for _, e := range s.events[address] {
+		switch {
+		case e.BlockTimestamp < minTs || e.BlockTimestamp > maxTs:
+		case name != "" && e.EventName != name:
+		case block != 0 && e.BlockNumber != block:
+		default:
+			events = append(events, e)
+		}
+	}
+	s.mu.Unlock()
+
+	asc := q.Get("order_by") == trongrid.OrderByTimestampAsc
+	sort.SliceStable(events, func(i, j int) bool {
+		if asc {
+			return events[i].BlockTimestamp < events[j].BlockTimestamp
+		}
+
+		return events[i].BlockTimestamp > events[j].BlockTimestamp
+	})
+
+	start, end, meta, err := paginate(r, len(events))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &trongrid.ListEventsResponse{
+		Data:    events[start:end],
+		Success: true,
+		Meta:    meta,
+	})
+}
+
+func (s *Server) serveAssets(w http.ResponseWriter, r *http.Request, match func(*trongrid.AssetIssue) bool) {
+	s.mu.Lock()
+	assets := []*trongrid.AssetIssue{}
+	for _, a := range s.sortedAssets() {
+		if match(a) {
+			assets = append(assets, a)
+		}
+	}
+	s.mu.Unlock()
+
+	start, end, meta, err := paginate(r, len(assets))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &trongrid.ListAssetsResponse{
+		Data:    assets[start:end],
+		Success: true,
+		Meta:    meta,
+	})
+}
+
+func (s *Server) serveTokenHolders(w http.ResponseWriter, r *http.Request, contract string) {
+	type holder struct {
+		address string
+		balance *big.Int
+	}
+
+	s.mu.Lock()
+	holders := []holder{}
+	for address, balance := range s.balances[contract] {
+		holders = append(holders, holder{address: address, balance: balance})
+	}
+	s.mu.Unlock()
+
+	asc := r.URL.Query().Get("order_by") == "balance,asc"
+	sort.Slice(holders, func(i, j int) bool {
+		if cmp := holders[i].balance.Cmp(holders[j].balance); cmp != 0 {
+			return cmp > 0 != asc
+		}
+
+		return holders[i].address < holders[j].address
+	})
+
+	start, end, meta, err := paginate(r, len(holders))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	data := make([]map[string]string, 0, end-start)
+	for _, h := range holders[start:end] {
+		data = append(data, map[string]string{h.address: h.balance.String()})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data, "success": true, "meta": meta})
+}
+
+func (s *Server) serveTRC20Balances(w http.ResponseWriter, r *http.Request, address string) {
+	s.mu.Lock()
+	data := []map[string]string{}
+	for contract, balances := range s.balances {
+		if balance, ok := balances[address]; ok {
+			data = append(data, map[string]string{contract: balance.String()})
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Slice(data, func(i, j int) bool { return firstKey(data[i]) < firstKey(data[j]) })
+
+	start, end, meta, err := paginate(r, len(data))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{"data": data[start:end], "success": true, "meta": meta})
+}
+
+func firstKey(m map[string]string) string {
+	for k := range m {
+		return k
+	}
+
+	return ""
+}
+
+// paginate returns the bounds of the requested page of total items and its meta.
+func paginate(r *http.Request, total int) (start, end int, meta *trongrid.Meta, err error) {
+	q := r.URL.Query()
+
+	limit := defaultLimit
+	if v := q.Get("limit"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			return 0, 0, nil, trongrid.ErrInvalidLimit
+		}
+	}
+
+	start, end, fingerprint, err := page(q.Get("fingerprint"), limit, total)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+
+	meta = &trongrid.Meta{
+		Links:       &trongrid.MetaLinks{},
+		Fingerprint: fingerprint,
+		At:          time.Now().UnixMilli(),
+		PageSize:    int32(end - start),
+	}
+	if fingerprint != "" {
+		next := *r.URL
+		next.Scheme = "http"
+		next.Host = r.Host
+		q.Set("fingerprint", fingerprint)
+		next.RawQuery = q.Encode()
+		meta.Links.Next = next.String()
+	}
+
+	return start, end, meta, nil
+}
+
+// page returns the bounds of a page of total items and the fingerprint of the next page, if any.
+// Fingerprints are opaque to clients; here they encode the offset of the next page.
+func page(fingerprint string, limit, total int) (start, end int, next string, err error) {
+	if limit == 0 {
+		limit = defaultLimit
+	}
+	if limit < 0 || limit > maxLimit {
+		return 0, 0, "", trongrid.ErrInvalidLimit
+	}
+
+	if fingerprint != "" {
+		b, err := base64.RawURLEncoding.DecodeString(fingerprint)
+		if err != nil {
+			return 0, 0, "", fmt.Errorf("%w: fingerprint", trongrid.ErrInvalidRequest)
+		}
+		if start, err = strconv.Atoi(string(b)); err != nil || start < 0 {
+			return 0, 0, "", fmt.Errorf("%w: fingerprint", trongrid.ErrInvalidRequest)
+		}
+	}
+
+	if start > total {
+		start = total
+	}
+	end = start + limit
+	if end > total {
+		end = total
+	}
+	if end < total {
+		next = base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(end)))
+	}
+
+	return start, end, next, nil
+}
+
+// timestampRange parses the bounds of a time filter, given in milliseconds or in the client layout.
+func timestampRange(minValue, maxValue string) (minTs, maxTs int64) {
+	minTs, maxTs = 0, int64(1)<<62
+	if v, ok := parseTimestamp(minValue); ok {
+		minTs = v
+	}
+	if v, ok := parseTimestamp(maxValue); ok {
+		maxTs = v
+	}
+
+	return minTs, maxTs
+}
+
+func parseTimestamp(v string) (int64, bool) {
+	if v == "" {
+		return 0, false
+	}
+
+	if ms, err := strconv.ParseInt(v, 10, 64); err == nil {
+		return ms, true
+	}
+
+	if t, err := time.ParseInLocation(timestampLayout, v, time.Local); err == nil {
+		return t.UnixMilli(), true
+	}
+
+	return 0, false
+}
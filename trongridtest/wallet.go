@@ -0,0 +1,158 @@
+package trongridtest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+type walletRequest struct {
+	Value            string `json:"value"`
+	Address          string `json:"address"`
+	Num              int64  `json:"num"`
+	ContractAddress  string `json:"contract_address"`
+	FunctionSelector string `json:"function_selector"`
+}
+
+func (s *Server) serveWallet(w http.ResponseWriter, path string, body []byte) {
+	var req walletRequest
+	if len(body) != 0 {
+		if err := json.Unmarshal(body, &req); err != nil {
+			writeJSON(w, http.StatusOK, map[string]string{"Error": err.Error()})
+
+			return
+		}
+	}
+
+	solidity := strings.HasPrefix(path, "/walletsolidity/")
+	endpoint := path[strings.LastIndex(path, "/")+1:]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var result interface{} = struct{}{}
+	switch endpoint {
+	case "gettransactionbyid":
+		if tx, ok := s.txByID[req.Value]; ok && (!solidity || s.isSolidified(int64(tx.BlockNumber))) {
+			result = tx
+		}
+	case "gettransactioninfobyid":
+		if info, ok := s.txInfo[req.Value]; ok && (!solidity || s.isSolidified(info.BlockNumber)) {
+			result = info
+		}
+	case "getblockbynum":
+		if block, ok := s.blocks[req.Num]; ok && (!solidity || s.isSolidified(req.Num)) {
+			result = block
+		}
+	case "getnowblock":
+		if block := s.nowBlock(solidity); block != nil {
+			result = block
+		}
+	case "getaccountresource":
+		if resources, ok := s.resources[req.Address]; ok {
+			result = resources
+		}
+	case "getchainparameters":
+		result = chainParameters(s.params)
+	case "getenergyprices", "getbandwidthprices":
+		result = map[string]string{"prices": s.prices[endpoint]}
+	case "getcontract":
+		if contract, ok := s.contracts[req.Value]; ok {
+			result = contract
+		}
+	case "getassetissuebyid":
+		if asset, ok := s.assets[req.Value]; ok {
+			result = asset
+		}
+	case "getassetissuebyaccount":
+		result = s.assetList(func(a *trongrid.AssetIssue) bool { return a.OwnerAddress == req.Address })
+	case "getassetissuelistbyname":
+		result = s.assetList(func(a *trongrid.AssetIssue) bool { return a.Name == req.Value })
+	case "triggerconstantcontract":
+		result = s.constantCall(&req)
+	default:
+		writeError(w, http.StatusNotFound, "not found: "+path)
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// constantCall answers the metadata methods of the tokens added with AddToken. s.mu must be held.
+func (s *Server) constantCall(req *walletRequest) interface{} {
+	type result struct {
+		Result  bool   `json:"result"`
+		Message string `json:"message,omitempty"`
+	}
+
+	token, ok := s.tokens[req.ContractAddress]
+	if !ok {
+		return map[string]interface{}{"result": result{Message: hex.EncodeToString([]byte("Smart contract is not exist."))}}
+	}
+
+	var b []byte
+	switch req.FunctionSelector {
+	case "name()":
+		b = abiString(token.Name)
+	case "symbol()":
+		b = abiString(token.Symbol)
+	case "decimals()":
+		b = abiUint(big.NewInt(int64(token.Decimals)))
+	default:
+		return map[string]interface{}{"result": result{Message: hex.EncodeToString([]byte("REVERT opcode executed"))}}
+	}
+
+	return map[string]interface{}{"result": result{Result: true}, "constant_result": []string{hex.EncodeToString(b)}}
+}
+
+// isSolidified reports whether block num is served by the solidity endpoints. s.mu must be held.
+func (s *Server) isSolidified(num int64) bool {
+	return s.solidified < 0 || num <= s.solidified
+}
+
+// nowBlock returns the highest (solidified) block. s.mu must be held.
+func (s *Server) nowBlock(solidity bool) *trongrid.Block {
+	var now *trongrid.Block
+	for num, block := range s.blocks {
+		if solidity && !s.isSolidified(num) {
+			continue
+		}
+		if now == nil || num > now.BlockHeader.RawData.Number {
+			now = block
+		}
+	}
+
+	return now
+}
+
+// assetList returns the matching assets in the wallet API shape. s.mu must be held.
+func (s *Server) assetList(match func(*trongrid.AssetIssue) bool) interface{} {
+	var issues []*trongrid.AssetIssue
+	for _, a := range s.sortedAssets() {
+		if match(a) {
+			issues = append(issues, a)
+		}
+	}
+	if len(issues) == 0 {
+		return struct{}{}
+	}
+
+	return map[string]interface{}{"assetIssue": issues}
+}
+
+// sortedAssets returns the assets ordered by ID. s.mu must be held.
+func (s *Server) sortedAssets() []*trongrid.AssetIssue {
+	issues := make([]*trongrid.AssetIssue, 0, len(s.assets))
+	for _, a := range s.assets {
+		issues = append(issues, a)
+	}
+	sort.Slice(issues, func(i, j int) bool { return issues[i].ID < issues[j].ID })
+
+	return issues
+}
@@ -23,41 +23,225 @@ type Token struct {
 }
 
 type Transaction struct {
-	Ret []struct {
-		ContractRet string `json:"contractRet"`
-		Fee         int    `json:"fee"`
-	} `json:"ret"`
-	Signature        []string `json:"signature"`
-	TxID             string   `json:"txID"`
-	NetUsage         int      `json:"net_usage"`
-	RawDataHex       string   `json:"raw_data_hex"`
-	NetFee           int      `json:"net_fee"`
-	EnergyUsage      int      `json:"energy_usage"`
-	BlockNumber      int      `json:"blockNumber"`
-	BlockTimestamp   int64    `json:"block_timestamp"`
-	EnergyFee        int      `json:"energy_fee"`
-	EnergyUsageTotal int      `json:"energy_usage_total"`
-	RawData          struct {
-		Contract []struct {
-			Parameter struct {
-				Value struct {
-					Amount       int    `json:"amount"`
-					OwnerAddress string `json:"owner_address"`
-					ToAddress    string `json:"to_address"`
-				} `json:"value"`
-				TypeUrl string `json:"type_url"`
-			} `json:"parameter"`
-			Type string `json:"type"`
-		} `json:"contract"`
-		RefBlockBytes string `json:"ref_block_bytes"`
-		RefBlockHash  string `json:"ref_block_hash"`
-		Expiration    int64  `json:"expiration"`
-		Timestamp     int64  `json:"timestamp"`
-	} `json:"raw_data"`
-	InternalTransactions []interface{} `json:"internal_transactions"`
+	Ret                  []TransactionRet      `json:"ret"`
+	Signature            []string              `json:"signature"`
+	TxID                 string                `json:"txID"`
+	NetUsage             int                   `json:"net_usage"`
+	RawDataHex           string                `json:"raw_data_hex"`
+	NetFee               int                   `json:"net_fee"`
+	EnergyUsage          int                   `json:"energy_usage"`
+	BlockNumber          int                   `json:"blockNumber"`
+	BlockTimestamp       int64                 `json:"block_timestamp"`
+	EnergyFee            int                   `json:"energy_fee"`
+	EnergyUsageTotal     int                   `json:"energy_usage_total"`
+	RawData              TransactionRawData    `json:"raw_data"`
+	InternalTransactions []InternalTransaction `json:"internal_transactions"`
+}
+
+type TransactionRet struct {
+	ContractRet string `json:"contractRet"`
+	Fee         int    `json:"fee"`
+}
+
+type TransactionRawData struct {
+	Contract      []Contract `json:"contract"`
+	RefBlockBytes string     `json:"ref_block_bytes"`
+	RefBlockHash  string     `json:"ref_block_hash"`
+	Expiration    int64      `json:"expiration"`
+	Timestamp     int64      `json:"timestamp"`
+	FeeLimit      int64      `json:"fee_limit,omitempty"`
+	Data          string     `json:"data,omitempty"`
+}
+
+type Contract struct {
+	Parameter    ContractParameter `json:"parameter"`
+	Type         string            `json:"type"`
+	PermissionID int32             `json:"Permission_id,omitempty"`
+}
+
+type ContractParameter struct {
+	Value   ContractValue `json:"value"`
+	TypeUrl string        `json:"type_url"`
+}
+
+type ContractValue struct {
+	Amount       int    `json:"amount"`
+	OwnerAddress string `json:"owner_address"`
+	ToAddress    string `json:"to_address"`
+	// TriggerSmartContract
+	ContractAddress string `json:"contract_address,omitempty"`
+	Data            string `json:"data,omitempty"`
+	CallValue       int64  `json:"call_value,omitempty"`
+	// TransferAssetContract: the asset ID, hex encoded unless the transaction is visible
+	AssetName string `json:"asset_name,omitempty"`
+	// Stake 2.0 contracts; amounts are in sun
+	FrozenBalance   int64  `json:"frozen_balance,omitempty"`
+	UnfreezeBalance int64  `json:"unfreeze_balance,omitempty"`
+	Balance         int64  `json:"balance,omitempty"`
+	ReceiverAddress string `json:"receiver_address,omitempty"`
+	Resource        string `json:"resource,omitempty"`
+	Lock            bool   `json:"lock,omitempty"`
+	LockPeriod      int64  `json:"lock_period,omitempty"`
+	// VoteWitnessContract
+	Votes []Vote `json:"votes,omitempty"`
+	// AccountPermissionUpdateContract
+	Owner   *Permission   `json:"owner,omitempty"`
+	Witness *Permission   `json:"witness,omitempty"`
+	Actives []*Permission `json:"actives,omitempty"`
 }
 type TransactionType string
 
+type Account struct {
+	Address            string              `json:"address"`
+	Balance            int64               `json:"balance"`
+	CreateTime         int64               `json:"create_time"`
+	LatestOprationTime int64               `json:"latest_opration_time"`
+	TRC20              []map[string]string `json:"trc20"`
+	AssetV2            []AccountAsset      `json:"assetV2"`
+	// FrozenV2 is the TRX staked with Stake 2.0, per resource
+	FrozenV2 []FrozenV2 `json:"frozenV2"`
+	// UnfrozenV2 is the TRX waiting to be withdrawn after UnfreezeBalanceV2
+	UnfrozenV2 []UnfrozenV2 `json:"unfrozenV2"`
+	// DelegatedFrozenV2BalanceForBandwidth is the staked TRX whose bandwidth is delegated to others
+	DelegatedFrozenV2BalanceForBandwidth int64 `json:"delegated_frozenV2_balance_for_bandwidth"`
+	// AcquiredDelegatedFrozenV2BalanceForBandwidth is the TRX staked by others for this account's bandwidth
+	AcquiredDelegatedFrozenV2BalanceForBandwidth int64 `json:"acquired_delegated_frozenV2_balance_for_bandwidth"`
+	// AccountResource holds the energy counterparts
+	AccountResource *AccountResource `json:"account_resource,omitempty"`
+	// Votes are the current votes of the account
+	Votes []Vote `json:"votes,omitempty"`
+	// LatestWithdrawTime is when voting rewards were last claimed, in milliseconds
+	LatestWithdrawTime int64 `json:"latest_withdraw_time,omitempty"`
+	// OwnerPermission is nil for accounts whose permissions were never set: the account key is the owner
+	OwnerPermission   *Permission   `json:"owner_permission,omitempty"`
+	WitnessPermission *Permission   `json:"witness_permission,omitempty"`
+	ActivePermission  []*Permission `json:"active_permission,omitempty"`
+}
+
+// FrozenV2 is TRX staked for a resource. An empty Type is bandwidth.
+type FrozenV2 struct {
+	Type   string `json:"type,omitempty"`
+	Amount int64  `json:"amount"`
+}
+
+// UnfrozenV2 is unstaked TRX that can be withdrawn from UnfreezeExpireTime, in milliseconds.
+type UnfrozenV2 struct {
+	Type               string `json:"type,omitempty"`
+	UnfreezeAmount     int64  `json:"unfreeze_amount"`
+	UnfreezeExpireTime int64  `json:"unfreeze_expire_time"`
+}
+
+// AccountResource is the energy part of an account's staking state.
+type AccountResource struct {
+	DelegatedFrozenV2BalanceForEnergy         int64 `json:"delegated_frozenV2_balance_for_energy"`
+	AcquiredDelegatedFrozenV2BalanceForEnergy int64 `json:"acquired_delegated_frozenV2_balance_for_energy"`
+}
+
+// AccountAsset is the balance of a TRC10 asset, keyed by asset ID.
+type AccountAsset struct {
+	Key   string `json:"key"`
+	Value int64  `json:"value"`
+}
+
+type AccountResponse struct {
+	Data    []*Account `json:"data"`
+	Success bool       `json:"success"`
+	Meta    *Meta      `json:"meta"`
+}
+
+type Event struct {
+	BlockNumber           int64             `json:"block_number"`
+	BlockTimestamp        int64             `json:"block_timestamp"`
+	CallerContractAddress string            `json:"caller_contract_address"`
+	ContractAddress       string            `json:"contract_address"`
+	EventIndex            int32             `json:"event_index"`
+	EventName             string            `json:"event_name"`
+	Event                 string            `json:"event"`
+	Result                map[string]string `json:"result"`
+	ResultType            map[string]string `json:"result_type"`
+	TransactionID         string            `json:"transaction_id"`
+}
+
+type ListEventsResponse struct {
+	Data    []*Event `json:"data"`
+	Success bool     `json:"success"`
+	Meta    *Meta    `json:"meta"`
+}
+
+type TransactionInfo struct {
+	ID              string   `json:"id"`
+	Fee             int64    `json:"fee"`
+	BlockNumber     int64    `json:"blockNumber"`
+	BlockTimeStamp  int64    `json:"blockTimeStamp"`
+	ContractResult  []string `json:"contractResult"`
+	ContractAddress string   `json:"contract_address"`
+	Receipt         struct {
+		EnergyUsage       int64  `json:"energy_usage"`
+		EnergyFee         int64  `json:"energy_fee"`
+		OriginEnergyUsage int64  `json:"origin_energy_usage"`
+		EnergyUsageTotal  int64  `json:"energy_usage_total"`
+		NetUsage          int64  `json:"net_usage"`
+		NetFee            int64  `json:"net_fee"`
+		Result            string `json:"result"`
+	} `json:"receipt"`
+	Log []struct {
+		Address string   `json:"address"`
+		Topics  []string `json:"topics"`
+		Data    string   `json:"data"`
+	} `json:"log"`
+	Result               string                `json:"result"`
+	ResMessage           string                `json:"resMessage"`
+	InternalTransactions []InternalTransaction `json:"internal_transactions"`
+}
+
+type Block struct {
+	BlockID     string `json:"blockID"`
+	BlockHeader struct {
+		RawData struct {
+			Number         int64  `json:"number"`
+			TxTrieRoot     string `json:"txTrieRoot"`
+			WitnessAddress string `json:"witness_address"`
+			ParentHash     string `json:"parentHash"`
+			Version        int32  `json:"version"`
+			Timestamp      int64  `json:"timestamp"`
+		} `json:"raw_data"`
+		WitnessSignature string `json:"witness_signature"`
+	} `json:"block_header"`
+	Transactions []*Transaction `json:"transactions"`
+}
+
+type SmartContract struct {
+	OriginAddress              string `json:"origin_address"`
+	ContractAddress            string `json:"contract_address"`
+	ABI                        ABI    `json:"abi"`
+	Bytecode                   string `json:"bytecode"`
+	Name                       string `json:"name"`
+	ConsumeUserResourcePercent int64  `json:"consume_user_resource_percent"`
+	OriginEnergyLimit          int64  `json:"origin_energy_limit"`
+	CodeHash                   string `json:"code_hash"`
+}
+
+type ABI struct {
+	Entrys []ABIEntry `json:"entrys"`
+}
+
+type ABIEntry struct {
+	Anonymous       bool       `json:"anonymous"`
+	Constant        bool       `json:"constant"`
+	Name            string     `json:"name"`
+	Inputs          []ABIParam `json:"inputs"`
+	Outputs         []ABIParam `json:"outputs"`
+	Type            string     `json:"type"`
+	Payable         bool       `json:"payable"`
+	StateMutability string     `json:"stateMutability"`
+}
+
+type ABIParam struct {
+	Indexed bool   `json:"indexed"`
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+}
+
 type TRC20Response struct {
 	Data    []TRC20Transaction `json:"data"`
 	Success bool               `json:"success"`
@@ -82,3 +266,26 @@ type TokenInfo struct {
 }
 
 // Meta和Error结构体可复用你现有的定义
+
+// AssetIssue is a TRC10 asset.
+type AssetIssue struct {
+	ID           string `json:"id"`
+	OwnerAddress string `json:"owner_address"`
+	Name         string `json:"name"`
+	Abbr         string `json:"abbr"`
+	TotalSupply  int64  `json:"total_supply"`
+	// TrxNum sun buy Num units of the asset during the issue
+	TrxNum      int64  `json:"trx_num"`
+	Num         int64  `json:"num"`
+	Precision   int32  `json:"precision"`
+	StartTime   int64  `json:"start_time"`
+	EndTime     int64  `json:"end_time"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+}
+
+type ListAssetsResponse struct {
+	Data    []*AssetIssue `json:"data"`
+	Success bool          `json:"success"`
+	Meta    *Meta         `json:"meta"`
+}
@@ -0,0 +1,473 @@
+package trongrid
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidTypedData is returned for typed data whose types or values cannot be encoded.
+var ErrInvalidTypedData = errors.New("invalid typed data")
+
+// typedDataDomainType is the struct type of the domain of typed data.
+const typedDataDomainType = "EIP712Domain"
+
+// TypedDataField is a member of a struct type of typed data.
+type TypedDataField struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// TypedDataDomain separates the signatures of an application from those of others. Only the fields
+// that are set are signed.
+type TypedDataDomain struct {
+	Name    string `json:"name,omitempty"`
+	Version string `json:"version,omitempty"`
+	// ChainID is Network.ChainID, the last 4 bytes of the genesis block hash
+	ChainID uint64 `json:"chainId,omitempty"`
+	// VerifyingContract is the address of the contract that verifies the signature
+	VerifyingContract string `json:"verifyingContract,omitempty"`
+	// Salt is 32 hex encoded bytes
+	Salt string `json:"salt,omitempty"`
+}
+
+// UnmarshalJSON accepts chainId as a number or as a string, decimal or 0x prefixed hex,
+// as wallets send both.
+func (d *TypedDataDomain) UnmarshalJSON(b []byte) error {
+	type domain TypedDataDomain
+
+	var v struct {
+		domain
+		ChainID interface{} `json:"chainId"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+
+	*d = TypedDataDomain(v.domain)
+	if v.ChainID == nil {
+		return nil
+	}
+	n, err := typedDataBigInt(v.ChainID)
+	if err != nil || !n.IsUint64() {
+		return fmt.Errorf("%w: chainId %v", ErrInvalidTypedData, v.ChainID)
+	}
+	d.ChainID = n.Uint64()
+
+	return nil
+}
+
+// TypedData is TIP-712 structured data, the JSON signed by tronWeb.trx._signTypedData and wallets.
+// TIP-712 is EIP-712 with TRON addresses, encoded without their 0x41 prefix, and the trcToken type,
+// encoded as uint256.
+//
+// Message values are Go values or values decoded from JSON: strings and json.Number or integers for
+// integers, addresses in any form ParseAddress accepts or Address, hex strings or []byte for bytes,
+// slices for arrays and maps for structs.
+type TypedData struct {
+	// Types are the struct types; the EIP712Domain type is derived from the domain if missing
+	Types       map[string][]TypedDataField `json:"types"`
+	PrimaryType string                      `json:"primaryType"`
+	Domain      TypedDataDomain             `json:"domain"`
+	Message     map[string]interface{}      `json:"message"`
+}
+
+// UnmarshalJSON decodes numbers as json.Number, so that large integers keep their precision.
+func (td *TypedData) UnmarshalJSON(b []byte) error {
+	type typedData TypedData
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	return dec.Decode((*typedData)(td))
+}
+
+// TypedDataHash returns the hash SignTypedData signs: keccak256 of 0x1901, the hash of the domain
+// and the hash of the message.
+func TypedDataHash(td *TypedData) ([]byte, error) {
+	types := typedDataTypes(td.Types)
+	domain := td.Domain.values()
+	if _, ok := types[typedDataDomainType]; !ok {
+		types = make(typedDataTypes, len(td.Types)+1)
+		for name, fields := range td.Types {
+			types[name] = fields
+		}
+		types[typedDataDomainType] = td.Domain.fields()
+	}
+	if _, ok := types[td.PrimaryType]; !ok || td.PrimaryType == typedDataDomainType {
+		return nil, fmt.Errorf("%w: unknown primary type %q", ErrInvalidTypedData, td.PrimaryType)
+	}
+
+	domainHash, err := types.hashStruct(typedDataDomainType, domain)
+	if err != nil {
+		return nil, fmt.Errorf("%w: domain: %v", ErrInvalidTypedData, err)
+	}
+	messageHash, err := types.hashStruct(td.PrimaryType, td.Message)
+	if err != nil {
+		return nil, fmt.Errorf("%w: message: %v", ErrInvalidTypedData, err)
+	}
+
+	return Keccak256([]byte{0x19, 0x01}, domainHash, messageHash), nil
+}
+
+// SignTypedData signs td with key and returns the 0x prefixed hex signature TronWeb returns.
+func SignTypedData(td *TypedData, key *PrivateKey) (string, error) {
+	hash, err := TypedDataHash(td)
+	if err != nil {
+		return "", err
+	}
+
+	return signHash(hash, key)
+}
+
+// VerifyTypedData checks that signature was made by address over td. It returns ErrInvalidSignature otherwise.
+// Build the domain on the verifying side: a domain sent along with the signature proves nothing.
+func VerifyTypedData(td *TypedData, signature string, address Address) error {
+	hash, err := TypedDataHash(td)
+	if err != nil {
+		return err
+	}
+
+	return verifyHash(hash, signature, address)
+}
+
+// fields returns the fields of the EIP712Domain type of d, in the order of EIP-712.
+func (d *TypedDataDomain) fields() []TypedDataField {
+	var fields []TypedDataField
+	if d.Name != "" {
+		fields = append(fields, TypedDataField{Name: "name", Type: "string"})
+	}
+	if d.Version != "" {
+		fields = append(fields, TypedDataField{Name: "version", Type: "string"})
+	}
+	if d.ChainID != 0 {
+		fields = append(fields, TypedDataField{Name: "chainId", Type: "uint256"})
+	}
+	if d.VerifyingContract != "" {
+		fields = append(fields, TypedDataField{Name: "verifyingContract", Type: "address"})
+	}
+	if d.Salt != "" {
+		fields = append(fields, TypedDataField{Name: "salt", Type: "bytes32"})
+	}
+
+	return fields
+}
+
+// values returns the fields of d that are set.
+func (d *TypedDataDomain) values() map[string]interface{} {
+	values := make(map[string]interface{})
+	if d.Name != "" {
+		values["name"] = d.Name
+	}
+	if d.Version != "" {
+		values["version"] = d.Version
+	}
+	if d.ChainID != 0 {
+		values["chainId"] = d.ChainID
+	}
+	if d.VerifyingContract != "" {
+		values["verifyingContract"] = d.VerifyingContract
+	}
+	if d.Salt != "" {
+		values["salt"] = d.Salt
+	}
+
+	return values
+}
+
+type typedDataTypes map[string][]TypedDataField
+
+// hashStruct returns keccak256 of the type hash of typ followed by the encoded fields of data.
+func (types typedDataTypes) hashStruct(typ string, data map[string]interface{}) ([]byte, error) {
+	fields := types[typ]
+	for name := range data {
+		if !hasTypedDataField(fields, name) {
+			return nil, fmt.Errorf("%s has no field %s", typ, name)
+		}
+	}
+
+	enc := make([]byte, 0, 32*(len(fields)+1))
+	enc = append(enc, Keccak256([]byte(types.encodeType(typ)))...)
+	for _, f := range fields {
+		v, ok := data[f.Name]
+		if !ok {
+			return nil, fmt.Errorf("%s: missing", f.Name)
+		}
+		word, err := types.encodeValue(f.Type, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		enc = append(enc, word...)
+	}
+
+	return Keccak256(enc), nil
+}
+
+// encodeType returns the signature of typ followed by those of the struct types it references, sorted,
+// e.g. "Mail(Person from,Person to,string contents)Person(string name,address wallet)".
+func (types typedDataTypes) encodeType(typ string) string {
+	deps := make(map[string]bool)
+	types.dependencies(typ, deps)
+	delete(deps, typ)
+
+	names := make([]string, 0, len(deps))
+	for name := range deps {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	for _, name := range append([]string{typ}, names...) {
+		b.WriteString(name)
+		b.WriteByte('(')
+		for i, f := range types[name] {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(f.Type + " " + f.Name)
+		}
+		b.WriteByte(')')
+	}
+
+	return b.String()
+}
+
+func (types typedDataTypes) dependencies(typ string, deps map[string]bool) {
+	if i := strings.IndexByte(typ, '['); i >= 0 {
+		typ = typ[:i]
+	}
+	if _, ok := types[typ]; !ok || deps[typ] {
+		return
+	}
+
+	deps[typ] = true
+	for _, f := range types[typ] {
+		types.dependencies(f.Type, deps)
+	}
+}
+
+// encodeValue returns the 32 byte encoding of v of type typ.
+func (types typedDataTypes) encodeValue(typ string, v interface{}) ([]byte, error) {
+	if strings.HasSuffix(typ, "]") {
+		return types.encodeArray(typ, v)
+	}
+	if _, ok := types[typ]; ok {
+		data, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%s must be an object, not %T", typ, v)
+		}
+
+		return types.hashStruct(typ, data)
+	}
+
+	switch {
+	case typ == "string":
+		s, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("string expected, not %T", v)
+		}
+
+		return Keccak256([]byte(s)), nil
+	case typ == "bytes":
+		b, err := typedDataBytes(v)
+		if err != nil {
+			return nil, err
+		}
+
+		return Keccak256(b), nil
+	case typ == "bool":
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("bool expected, not %T", v)
+		}
+		word := make([]byte, 32)
+		if b {
+			word[31] = 1
+		}
+
+		return word, nil
+	case typ == "address":
+		return typedDataAddress(v)
+	case typ == "trcToken":
+		return typedDataInt(v, 256, false)
+	case strings.HasPrefix(typ, "bytes"):
+		n, err := strconv.Atoi(typ[len("bytes"):])
+		if err != nil || n < 1 || n > 32 {
+			return nil, fmt.Errorf("unknown type %s", typ)
+		}
+		b, err := typedDataBytes(v)
+		if err != nil {
+			return nil, err
+		}
+		if len(b) > n {
+			return nil, fmt.Errorf("%d bytes do not fit %s", len(b), typ)
+		}
+
+		return append(b, make([]byte, 32-len(b))...), nil
+	case strings.HasPrefix(typ, "uint"), strings.HasPrefix(typ, "int"):
+		signed := strings.HasPrefix(typ, "int")
+		bits, err := strconv.Atoi(strings.TrimPrefix(strings.TrimPrefix(typ, "u"), "int"))
+		if err != nil || bits < 8 || bits > 256 || bits%8 != 0 {
+			return nil, fmt.Errorf("unknown type %s", typ)
+		}
+
+		return typedDataInt(v, bits, signed)
+	default:
+		return nil, fmt.Errorf("unknown type %s", typ)
+	}
+}
+
+// encodeArray returns keccak256 of the encoded elements of v, an array of type typ such as "Person[]"
+// or "uint256[2]".
+func (types typedDataTypes) encodeArray(typ string, v interface{}) ([]byte, error) {
+	i := strings.LastIndexByte(typ, '[')
+	if i < 0 {
+		return nil, fmt.Errorf("unknown type %s", typ)
+	}
+	elem, length := typ[:i], typ[i+1:len(typ)-1]
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, fmt.Errorf("%s must be an array, not %T", typ, v)
+	}
+	if length != "" {
+		if n, err := strconv.Atoi(length); err != nil || n != rv.Len() {
+			return nil, fmt.Errorf("%s has %d elements", typ, rv.Len())
+		}
+	}
+
+	enc := make([]byte, 0, 32*rv.Len())
+	for j := 0; j < rv.Len(); j++ {
+		word, err := types.encodeValue(elem, rv.Index(j).Interface())
+		if err != nil {
+			return nil, fmt.Errorf("%d: %w", j, err)
+		}
+		enc = append(enc, word...)
+	}
+
+	return Keccak256(enc), nil
+}
+
+func hasTypedDataField(fields []TypedDataField, name string) bool {
+	for _, f := range fields {
+		if f.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// typedDataAddress encodes an address as uint160, without its 0x41 prefix.
+func typedDataAddress(v interface{}) ([]byte, error) {
+	var a Address
+	switch v := v.(type) {
+	case Address:
+		a = v
+	case string:
+		var err error
+		if a, err = ParseAddress(v); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("address expected, not %T", v)
+	}
+
+	return append(make([]byte, 32-(AddressLength-1)), a[1:]...), nil
+}
+
+func typedDataBytes(v interface{}) ([]byte, error) {
+	switch v := v.(type) {
+	case []byte:
+		return append([]byte(nil), v...), nil
+	case string:
+		b, err := hex.DecodeString(trimHexPrefix(v))
+		if err != nil {
+			return nil, fmt.Errorf("bytes must be hex encoded: %v", err)
+		}
+
+		return b, nil
+	default:
+		return nil, fmt.Errorf("bytes expected, not %T", v)
+	}
+}
+
+// typedDataInt encodes an integer of bits bits as a 32 byte two's complement word.
+func typedDataInt(v interface{}, bits int, signed bool) ([]byte, error) {
+	n, err := typedDataBigInt(v)
+	if err != nil {
+		return nil, err
+	}
+
+	lo, hi := new(big.Int), new(big.Int).Lsh(big.NewInt(1), uint(bits))
+	if signed {
+		hi.Rsh(hi, 1)
+		lo.Neg(hi)
+	}
+	if n.Cmp(lo) < 0 || n.Cmp(hi) >= 0 {
+		return nil, fmt.Errorf("%s does not fit %d bits", n, bits)
+	}
+
+	if n.Sign() < 0 {
+		n.Add(n, new(big.Int).Lsh(big.NewInt(1), 256))
+	}
+
+	return n.FillBytes(make([]byte, 32)), nil
+}
+
+// parseTypedDataInt parses a decimal integer, or a hex one with the 0x prefix. Unlike big.Int with
+// base 0, a leading zero does not make it octal.
+func parseTypedDataInt(s string) (*big.Int, bool) {
+	digits := strings.TrimPrefix(s, "-")
+	if len(digits) < 3 || !strings.HasPrefix(strings.ToLower(digits), "0x") {
+		return new(big.Int).SetString(s, 10)
+	}
+	if digits[2] == '+' || digits[2] == '-' {
+		return nil, false
+	}
+
+	n, ok := new(big.Int).SetString(digits[2:], 16)
+	if ok && len(digits) < len(s) {
+		n.Neg(n)
+	}
+
+	return n, ok
+}
+
+func typedDataBigInt(v interface{}) (*big.Int, error) {
+	switch v := v.(type) {
+	case *big.Int:
+		return new(big.Int).Set(v), nil
+	case json.Number:
+		if n, ok := new(big.Int).SetString(v.String(), 10); ok {
+			return n, nil
+		}
+	case string:
+		if n, ok := parseTypedDataInt(v); ok {
+			return n, nil
+		}
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) <= 1<<53 {
+			return big.NewInt(int64(v)), nil
+		}
+	default:
+		switch rv := reflect.ValueOf(v); rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return big.NewInt(rv.Int()), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return new(big.Int).SetUint64(rv.Uint()), nil
+		}
+	}
+
+	return nil, fmt.Errorf("integer expected, not %T %v", v, v)
+}
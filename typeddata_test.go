@@ -0,0 +1,208 @@
+package trongrid_test
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/eliohn/go-trongrid"
+)
+
+// mailTypedData is the example of EIP-712, whose hashes TIP-712 keeps: addresses are encoded
+// without their 0x41 prefix.
+func mailTypedData(from, to, contract string) *trongrid.TypedData {
+	return &trongrid.TypedData{
+		Types: map[string][]trongrid.TypedDataField{
+			"Person": {{Name: "name", Type: "string"}, {Name: "wallet", Type: "address"}},
+			"Mail": {
+				{Name: "from", Type: "Person"},
+				{Name: "to", Type: "Person"},
+				{Name: "contents", Type: "string"},
+			},
+		},
+		PrimaryType: "Mail",
+		Domain: trongrid.TypedDataDomain{
+			Name:              "Ether Mail",
+			Version:           "1",
+			ChainID:           1,
+			VerifyingContract: contract,
+		},
+		Message: map[string]interface{}{
+			"from":     map[string]interface{}{"name": "Cow", "wallet": from},
+			"to":       map[string]interface{}{"name": "Bob", "wallet": to},
+			"contents": "Hello, Bob!",
+		},
+	}
+}
+
+func TestTypedDataHash(t *testing.T) {
+	td := mailTypedData(
+		"0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826",
+		"0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB",
+		"0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
+	)
+
+	hash, err := trongrid.TypedDataHash(td)
+	require.NoError(t, err)
+	assert.Equal(t, "be609aee343fb3c4b28e1df9e632fca64fcfaede20f02e86244efddf30957bd2", hex.EncodeToString(hash))
+
+	// the same addresses in TRON form
+	tron := mailTypedData(
+		trongrid.MustParseAddress("0xCD2a3d9F938E13CD947Ec05AbC7FE734Df8DD826").String(),
+		"41bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb",
+		trongrid.MustParseAddress("0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC").String(),
+	)
+	tronHash, err := trongrid.TypedDataHash(tron)
+	require.NoError(t, err)
+	assert.Equal(t, hash, tronHash)
+
+	// an explicit domain type gives the same hash
+	tron.Types["EIP712Domain"] = []trongrid.TypedDataField{
+		{Name: "name", Type: "string"},
+		{Name: "version", Type: "string"},
+		{Name: "chainId", Type: "uint256"},
+		{Name: "verifyingContract", Type: "address"},
+	}
+	tronHash, err = trongrid.TypedDataHash(tron)
+	require.NoError(t, err)
+	assert.Equal(t, hash, tronHash)
+}
+
+func TestSignTypedData(t *testing.T) {
+	key, err := trongrid.PrivateKeyFromBytes(trongrid.Keccak256([]byte("cow")))
+	require.NoError(t, err)
+	td := mailTypedData(
+		key.Address().String(),
+		"0xbBbBBBBbbBBBbbbBbbBbbbbBBbBbbbbBbBbbBBbB",
+		"0xCcCCccccCCCCcCCCCCCcCcCccCcCCCcCcccccccC",
+	)
+
+	sig, err := trongrid.SignTypedData(td, key)
+	require.NoError(t, err)
+	assert.Equal(t, "0x4355c47d63924e8a72e509b65029052eb6c299d53a04e167c5775fd466751c9d"+
+		"07299936d304c153f6443dfa05f40ff007d72911b6f72307f996231605b915621c", sig)
+
+	require.NoError(t, trongrid.VerifyTypedData(td, sig, key.Address()))
+	require.ErrorIs(t, trongrid.VerifyTypedData(td, sig, mustGenerateKey(t).Address()), trongrid.ErrInvalidSignature)
+
+	td.Domain.ChainID = 0x2b6653dc
+	require.ErrorIs(t, trongrid.VerifyTypedData(td, sig, key.Address()), trongrid.ErrInvalidSignature)
+}
+
+func TestTypedData_UnmarshalJSON(t *testing.T) {
+	const typedJSON = `{
+		"types": {
+			"Order": [
+				{"name": "owner", "type": "address"},
+				{"name": "token", "type": "trcToken"},
+				{"name": "amount", "type": "uint256"},
+				{"name": "delta", "type": "int64"},
+				{"name": "ids", "type": "uint32[]"},
+				{"name": "tags", "type": "bytes4[2]"},
+				{"name": "active", "type": "bool"},
+				{"name": "memo", "type": "bytes"}
+			]
+		},
+		"primaryType": "Order",
+		"domain": {"name": "Exchange", "version": "2", "chainId": 728126428},
+		"message": {
+			"owner": "TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH",
+			"token": 1002000,
+			"amount": 115792089237316195423570985008687907853269984665640564039457584007913129639935,
+			"delta": -5,
+			"ids": [1, 2, 3],
+			"tags": ["0x01020304", "0xa0b0"],
+			"active": true,
+			"memo": "0xdeadbeef"
+		}
+	}`
+
+	var td trongrid.TypedData
+	require.NoError(t, json.Unmarshal([]byte(typedJSON), &td))
+	hash, err := trongrid.TypedDataHash(&td)
+	require.NoError(t, err)
+
+	// the same message built from Go values
+	maxUint256, _ := new(big.Int).SetString("ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff", 16)
+	built := &trongrid.TypedData{
+		Types:       td.Types,
+		PrimaryType: "Order",
+		Domain:      trongrid.TypedDataDomain{Name: "Exchange", Version: "2", ChainID: 0x2b6653dc},
+		Message: map[string]interface{}{
+			"owner":  trongrid.MustParseAddress("TUEZSdKsoDHQMeZwihtdoBiN46zxhGWYdH"),
+			"token":  int64(1002000),
+			"amount": maxUint256,
+			"delta":  -5,
+			"ids":    []uint32{1, 2, 3},
+			"tags":   [][]byte{{1, 2, 3, 4}, {0xa0, 0xb0}},
+			"active": true,
+			"memo":   []byte{0xde, 0xad, 0xbe, 0xef},
+		},
+	}
+	builtHash, err := trongrid.TypedDataHash(built)
+	require.NoError(t, err)
+	assert.Equal(t, hash, builtHash)
+
+	invalid := map[string]interface{}{
+		"amount": "-1",
+		"delta":  "0x8000000000000000",
+		"ids":    []int{1, 2, 1 << 32},
+		"tags":   []string{"0x01"},
+		"active": "yes",
+		"memo":   "0xzz",
+		"token":  "0b11",
+		"extra":  1,
+	}
+	for field, v := range invalid {
+		message := make(map[string]interface{}, len(built.Message))
+		for k, v := range built.Message {
+			message[k] = v
+		}
+		message[field] = v
+		_, err = trongrid.TypedDataHash(&trongrid.TypedData{Types: td.Types, PrimaryType: "Order", Message: message})
+		require.ErrorIs(t, err, trongrid.ErrInvalidTypedData, field)
+	}
+
+	// a leading zero is not octal, and hex needs the 0x prefix
+	built.Message["delta"] = "-010"
+	decimal, err := trongrid.TypedDataHash(built)
+	require.NoError(t, err)
+	built.Message["delta"] = int64(-10)
+	builtHash, err = trongrid.TypedDataHash(built)
+	require.NoError(t, err)
+	assert.Equal(t, builtHash, decimal)
+	built.Message["delta"] = "-0xa"
+	hexHash, err := trongrid.TypedDataHash(built)
+	require.NoError(t, err)
+	assert.Equal(t, builtHash, hexHash)
+	built.Message["delta"] = -5
+
+	delete(built.Message, "memo")
+	_, err = trongrid.TypedDataHash(built)
+	require.ErrorIs(t, err, trongrid.ErrInvalidTypedData)
+
+	built.PrimaryType = "Unknown"
+	_, err = trongrid.TypedDataHash(built)
+	require.ErrorIs(t, err, trongrid.ErrInvalidTypedData)
+}
+
+func TestTypedDataDomain_UnmarshalJSON(t *testing.T) {
+	for _, chainID := range []string{`728126428`, `"728126428"`, `"0x2b6653dc"`} {
+		var domain trongrid.TypedDataDomain
+		require.NoError(t, json.Unmarshal([]byte(`{"name": "Exchange", "chainId": `+chainID+`}`), &domain), chainID)
+		assert.Equal(t, trongrid.TypedDataDomain{Name: "Exchange", ChainID: 0x2b6653dc}, domain, chainID)
+	}
+
+	var domain trongrid.TypedDataDomain
+	require.NoError(t, json.Unmarshal([]byte(`{"name": "Exchange"}`), &domain))
+	assert.Zero(t, domain.ChainID)
+
+	for _, chainID := range []string{`-1`, `"0x"`, `"tron"`, `1.5`, `"0x10000000000000000"`} {
+		err := json.Unmarshal([]byte(`{"chainId": `+chainID+`}`), &domain)
+		require.ErrorIs(t, err, trongrid.ErrInvalidTypedData, chainID)
+	}
+}